@@ -4,6 +4,9 @@ import (
 	"errors"
 	"flag"
 	"fmt"
+	"os"
+	"slices"
+	"strings"
 
 	"github.com/lyracampos/go-clean-architecture/config"
 	"github.com/lyracampos/go-clean-architecture/internal/app"
@@ -11,15 +14,21 @@ import (
 
 const (
 	defaultConfigFilePath = "../config/config.yaml"
-	apiEntrypoint         = "api"
-	workerEntrypoint      = "worker"
+	apiEntrypoint         = app.APIComponent
+	workerEntrypoint      = app.WorkerComponent
+	allEntrypoint         = "all"
+	purgeEntrypoint       = "purge"
+	migrateEntrypoint     = "migrate"
+	bootstrapEntrypoint   = "bootstrap"
+	configEntrypoint      = "config"
 )
 
-var errInvalidAppEntrypoint = errors.New("invalid entrypoint, must be one of [api, worker]")
+var errInvalidAppEntrypoint = errors.New("invalid entrypoint, must be one of [api, worker, all, purge, migrate, bootstrap, config] or a comma-separated list of [api, worker]")
 
 func main() {
 	if err := run(); err != nil {
-		panic(err)
+		fmt.Fprintln(os.Stderr, err)
+		os.Exit(1)
 	}
 }
 
@@ -27,8 +36,12 @@ func run() error {
 	var appEntrypoint string
 	var configFilePath string
 
-	flag.StringVar(&appEntrypoint, "e", apiEntrypoint, "Entrypoint to define which application will be started. [api, worker]")
+	flag.StringVar(&appEntrypoint, "e", apiEntrypoint, "Entrypoint to define which application will be started. [api, worker, all, purge, migrate, bootstrap, config], api and worker can be combined as in api,worker")
 	flag.StringVar(&configFilePath, "c", defaultConfigFilePath, "File path with app configs file.")
+	flag.Usage = func() {
+		fmt.Fprintf(flag.CommandLine.Output(), "Usage: %s [flags] [migrate command: up | down N | status | goto V | force V]\n", os.Args[0])
+		flag.PrintDefaults()
+	}
 
 	flag.Parse()
 
@@ -37,14 +50,55 @@ func run() error {
 		return fmt.Errorf("failed to build config: %w", err)
 	}
 
+	if appEntrypoint == configEntrypoint {
+		// printed even when it is invalid, to help fixing it
+		if err := app.RunConfig(config); err != nil {
+			return err
+		}
+	}
+
+	if err := config.Validate(); err != nil {
+		return fmt.Errorf("invalid config %s:\n%w", configFilePath, err)
+	}
+
 	switch appEntrypoint {
-	case apiEntrypoint:
-		app.RunAPI(config)
-	case workerEntrypoint:
-		app.RunWorker(config)
+	case configEntrypoint:
+		// already printed
+	case purgeEntrypoint:
+		return app.RunPurge(config)
+	case migrateEntrypoint:
+		return app.RunMigrate(config, flag.Args())
+	case bootstrapEntrypoint:
+		return app.RunBootstrap(config)
 	default:
-		return errInvalidAppEntrypoint
+		components, err := parseComponents(appEntrypoint)
+		if err != nil {
+			return err
+		}
+
+		return app.Run(config, components...)
 	}
 
 	return nil
 }
+
+// parseComponents reads the entrypoints running in one process, all of them for the all entrypoint.
+func parseComponents(appEntrypoint string) ([]string, error) {
+	if appEntrypoint == allEntrypoint {
+		return []string{apiEntrypoint, workerEntrypoint}, nil
+	}
+
+	var components []string
+	for _, component := range strings.Split(appEntrypoint, ",") {
+		component = strings.TrimSpace(component)
+		if component != apiEntrypoint && component != workerEntrypoint {
+			return nil, errInvalidAppEntrypoint
+		}
+
+		if !slices.Contains(components, component) {
+			components = append(components, component)
+		}
+	}
+
+	return components, nil
+}
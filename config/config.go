@@ -2,67 +2,444 @@ package config
 
 import (
 	"fmt"
+	"net/url"
+	"os"
 	"strings"
+	"time"
 
+	"github.com/spf13/cast"
 	"github.com/spf13/viper"
 )
 
+const (
+	envPrefix = "go_clean_architecture"
+	// secretFileSuffix suffixes the environment variables naming a file the secret is read from.
+	secretFileSuffix = "_FILE"
+	redacted         = "REDACTED"
+)
+
+var envKeyReplacer = strings.NewReplacer(".", "_")
+
+// defaults apply to the keys missing from both the config file and the environment.
+// Secrets have none, so that they are never used by mistake.
+var defaults = map[string]any{
+	"app.name":                     "go-clean-architecture",
+	"log.level":                    "info",
+	"api.http.host":                "localhost",
+	"api.http.port":                8080,
+	"api.timeouts.default":         "10s",
+	"api.health.checkTimeout":      "2s",
+	"api.shutdown.drainDelay":      "5s",
+	"api.shutdown.gracePeriod":     "30s",
+	"database.maxIdleConnections":  2,
+	"database.verifySchemaVersion": true,
+	"users.softDeleteRetention":    "720h",
+	"auth.signingMethod":           "HS256",
+	"auth.accessTokenTTL":          "15m",
+	"auth.refreshTokenTTL":         "720h",
+	"worker.pollInterval":          "1s",
+	"worker.batchSize":             100,
+	"worker.lease":                 "30s",
+	"worker.maxAttempts":           10,
+	"worker.retryBaseDelay":        "1s",
+	"worker.retryMaxDelay":         "10m",
+	"worker.publishers":            []string{"log"},
+	"worker.shutdownGracePeriod":   "30s",
+	"webhooks.timeout":             "10s",
+	"webhooks.batchSize":           50,
+	"webhooks.lease":               "1m",
+	"webhooks.maxAttempts":         8,
+	"webhooks.retryBaseDelay":      "30s",
+	"webhooks.retryMaxDelay":       "6h",
+	"webhooks.disableAfter":        20,
+	"idempotency.ttl":              "24h",
+	"idempotency.lease":            "1m",
+	"metrics.enabled":              true,
+	"tracing.exporter":             "none",
+	"tracing.insecure":             true,
+	"tracing.sampleRatio":          1,
+}
+
+// secretKeys can be read from a file named by their environment variable suffixed with _FILE,
+// e.g. GO_CLEAN_ARCHITECTURE_DATABASE_CONNECTIONSTRING_FILE.
+var secretKeys = []string{
+	"database.connectionString",
+	"auth.signingKey",
+	"auth.ed25519PrivateKey",
+	"bootstrap.admin.password",
+}
+
 type (
 	Config struct {
-		App      App
-		API      API
-		Database Database
+		App         App         `yaml:"app"`
+		Log         Log         `yaml:"log"`
+		API         API         `yaml:"api"`
+		Database    Database    `yaml:"database"`
+		Users       Users       `yaml:"users"`
+		Auth        Auth        `yaml:"auth"`
+		Bootstrap   Bootstrap   `yaml:"bootstrap"`
+		Worker      Worker      `yaml:"worker"`
+		Webhooks    Webhooks    `yaml:"webhooks"`
+		Idempotency Idempotency `yaml:"idempotency"`
+		Metrics     Metrics     `yaml:"metrics"`
+		Tracing     Tracing     `yaml:"tracing"`
+
+		// filePath is where the config was read from, watched by the Watcher.
+		filePath string
 	}
 
 	App struct {
-		Name string
+		Name string `yaml:"name"`
+	}
+
+	Log struct {
+		// Level is the minimum level of the logged entries, one of debug, info, warn or error.
+		Level string `yaml:"level"`
 	}
 
+	// API is nested as in the config file when marshaled, see MarshalYAML.
 	API struct {
 		Host string
 		Port int
+		// RequestTimeout bounds how long a request may take, database queries included, zero disables it.
+		RequestTimeout time.Duration
+		// RouteTimeouts overrides RequestTimeout per route, keyed by the lowercased operation ID, e.g. listusers.
+		RouteTimeouts map[string]time.Duration
+		// HealthCheckTimeout bounds the dependency checks of the readiness probe.
+		HealthCheckTimeout time.Duration
+		// DrainDelay is how long the readiness probe fails before the server stops, so that load balancers
+		// stop sending traffic first.
+		DrainDelay time.Duration
+		// ShutdownGracePeriod is how long the requests in flight have to complete once the server stops.
+		ShutdownGracePeriod time.Duration
 	}
 
 	Database struct {
-		ConnectionString   string
-		MaxOpenConnections int
-		MaxIdleConnections int
+		ConnectionString   string `yaml:"connectionString"`
+		MaxOpenConnections int    `yaml:"maxOpenConnections"`
+		MaxIdleConnections int    `yaml:"maxIdleConnections"`
+		// VerifySchemaVersion makes the API refuse to start when the
+		// database schema is behind the embedded migrations.
+		VerifySchemaVersion bool `yaml:"verifySchemaVersion"`
+		// LogQueries logs every query with its arguments, failed queries are always logged without them.
+		LogQueries bool `yaml:"logQueries"`
+	}
+
+	Users struct {
+		SoftDeleteRetention time.Duration `yaml:"softDeleteRetention"`
+	}
+
+	Auth struct {
+		// SigningMethod is either HS256 or EdDSA, defaults to HS256.
+		SigningMethod string `yaml:"signingMethod"`
+		// SigningKey is the HMAC secret the access tokens are signed with.
+		SigningKey string `yaml:"signingKey"`
+		// Ed25519PrivateKey is the PEM encoded key the access tokens are signed with when using EdDSA.
+		Ed25519PrivateKey string        `yaml:"ed25519PrivateKey"`
+		AccessTokenTTL    time.Duration `yaml:"accessTokenTTL"`
+		RefreshTokenTTL   time.Duration `yaml:"refreshTokenTTL"`
+		// PasswordHashCost is the bcrypt cost, zero uses the bcrypt default.
+		PasswordHashCost int `yaml:"passwordHashCost"`
+	}
+
+	Worker struct {
+		// PollInterval is how long the worker waits for new events once the outbox is drained.
+		PollInterval time.Duration `yaml:"pollInterval"`
+		BatchSize    int           `yaml:"batchSize"`
+		// Lease is how long claimed events are hidden from other workers while they are delivered.
+		Lease          time.Duration `yaml:"lease"`
+		MaxAttempts    int           `yaml:"maxAttempts"`
+		RetryBaseDelay time.Duration `yaml:"retryBaseDelay"`
+		RetryMaxDelay  time.Duration `yaml:"retryMaxDelay"`
+		// Publishers are the names of the publishers the events are delivered to, [log, webhook].
+		Publishers []string `yaml:"publishers"`
+		// ShutdownGracePeriod is how long the batches in flight have to complete on shutdown.
+		ShutdownGracePeriod time.Duration `yaml:"shutdownGracePeriod"`
+	}
+
+	// Webhooks configures how the worker posts the deliveries of the webhook publisher.
+	Webhooks struct {
+		// Timeout is how long a receiver has to answer a delivery.
+		Timeout   time.Duration `yaml:"timeout"`
+		BatchSize int           `yaml:"batchSize"`
+		Lease     time.Duration `yaml:"lease"`
+		// MaxAttempts is the number of tries of a delivery before it is given up.
+		MaxAttempts    int           `yaml:"maxAttempts"`
+		RetryBaseDelay time.Duration `yaml:"retryBaseDelay"`
+		RetryMaxDelay  time.Duration `yaml:"retryMaxDelay"`
+		// DisableAfter is the number of failed deliveries in a row that disables a webhook, 0 never does.
+		DisableAfter int `yaml:"disableAfter"`
+	}
+
+	Idempotency struct {
+		// TTL is for how long the responses of requests with an Idempotency-Key are replayed.
+		TTL time.Duration `yaml:"ttl"`
+		// Lease is how long a request keeps its Idempotency-Key reserved, the keys of the requests
+		// still uncompleted after it, such as when the process died, can be used again.
+		Lease time.Duration `yaml:"lease"`
+	}
+
+	Metrics struct {
+		// Enabled exposes the Prometheus metrics at /metrics.
+		Enabled bool `yaml:"enabled"`
+		// Port serves the metrics apart from the API, zero serves them on the API port.
+		Port int `yaml:"port"`
+	}
+
+	// Tracing configures where the OpenTelemetry spans are exported, the service is named after App.Name.
+	Tracing struct {
+		// Exporter is one of none, stdout or otlp-http.
+		Exporter string `yaml:"exporter"`
+		// Endpoint is the host:port of the OTLP HTTP collector, empty uses the OTEL_EXPORTER_OTLP_* variables or localhost:4318.
+		Endpoint string `yaml:"endpoint"`
+		// Insecure sends the spans to the collector over plain HTTP.
+		Insecure bool `yaml:"insecure"`
+		// SampleRatio is the fraction of the new traces that is sampled, traces started by callers follow their decision.
+		SampleRatio float64 `yaml:"sampleRatio"`
+	}
+
+	// Bootstrap is the first admin user created by the bootstrap entrypoint,
+	// nested as in the config file when marshaled.
+	Bootstrap struct {
+		AdminFirstName string
+		AdminLastName  string
+		AdminEmail     string
+		AdminPassword  string
 	}
 )
 
+// NewConfig reads the config file, overridden by the environment, the missing keys taking their defaults.
+// The config is not validated, see Validate.
 func NewConfig(configFilePath string) (*Config, error) {
 	config, err := readConfig(configFilePath)
 	if err != nil {
 		return &Config{}, fmt.Errorf("failed to get viper config: %w", err)
 	}
 
+	return newConfig(config, configFilePath), nil
+}
+
+func newConfig(config *viper.Viper, configFilePath string) *Config {
 	return &Config{
 		App: App{
 			Name: config.GetString("app.name"),
 		},
+		Log: Log{
+			Level: config.GetString("log.level"),
+		},
 		API: API{
-			Host: config.GetString("api.http.host"),
-			Port: config.GetInt("api.http.port"),
+			Host:                config.GetString("api.http.host"),
+			Port:                config.GetInt("api.http.port"),
+			RequestTimeout:      config.GetDuration("api.timeouts.default"),
+			RouteTimeouts:       getDurationMap(config, "api.timeouts.routes"),
+			HealthCheckTimeout:  config.GetDuration("api.health.checkTimeout"),
+			DrainDelay:          config.GetDuration("api.shutdown.drainDelay"),
+			ShutdownGracePeriod: config.GetDuration("api.shutdown.gracePeriod"),
 		},
 		Database: Database{
-			ConnectionString:   config.GetString("database.connectionString"),
-			MaxOpenConnections: config.GetInt("database.maxOpenConnections"),
-			MaxIdleConnections: config.GetInt("database.maxIdleConnections"),
+			ConnectionString:    config.GetString("database.connectionString"),
+			MaxOpenConnections:  config.GetInt("database.maxOpenConnections"),
+			MaxIdleConnections:  config.GetInt("database.maxIdleConnections"),
+			VerifySchemaVersion: config.GetBool("database.verifySchemaVersion"),
+			LogQueries:          config.GetBool("database.logQueries"),
 		},
-	}, nil
+		Users: Users{
+			SoftDeleteRetention: config.GetDuration("users.softDeleteRetention"),
+		},
+		Auth: Auth{
+			SigningMethod:     config.GetString("auth.signingMethod"),
+			SigningKey:        config.GetString("auth.signingKey"),
+			Ed25519PrivateKey: config.GetString("auth.ed25519PrivateKey"),
+			AccessTokenTTL:    config.GetDuration("auth.accessTokenTTL"),
+			RefreshTokenTTL:   config.GetDuration("auth.refreshTokenTTL"),
+			PasswordHashCost:  config.GetInt("auth.passwordHashCost"),
+		},
+		Worker: Worker{
+			PollInterval:        config.GetDuration("worker.pollInterval"),
+			BatchSize:           config.GetInt("worker.batchSize"),
+			Lease:               config.GetDuration("worker.lease"),
+			MaxAttempts:         config.GetInt("worker.maxAttempts"),
+			RetryBaseDelay:      config.GetDuration("worker.retryBaseDelay"),
+			RetryMaxDelay:       config.GetDuration("worker.retryMaxDelay"),
+			Publishers:          config.GetStringSlice("worker.publishers"),
+			ShutdownGracePeriod: config.GetDuration("worker.shutdownGracePeriod"),
+		},
+		Webhooks: Webhooks{
+			Timeout:        config.GetDuration("webhooks.timeout"),
+			BatchSize:      config.GetInt("webhooks.batchSize"),
+			Lease:          config.GetDuration("webhooks.lease"),
+			MaxAttempts:    config.GetInt("webhooks.maxAttempts"),
+			RetryBaseDelay: config.GetDuration("webhooks.retryBaseDelay"),
+			RetryMaxDelay:  config.GetDuration("webhooks.retryMaxDelay"),
+			DisableAfter:   config.GetInt("webhooks.disableAfter"),
+		},
+		Idempotency: Idempotency{
+			TTL:   config.GetDuration("idempotency.ttl"),
+			Lease: config.GetDuration("idempotency.lease"),
+		},
+		Metrics: Metrics{
+			Enabled: config.GetBool("metrics.enabled"),
+			Port:    config.GetInt("metrics.port"),
+		},
+		Tracing: Tracing{
+			Exporter:    config.GetString("tracing.exporter"),
+			Endpoint:    config.GetString("tracing.endpoint"),
+			Insecure:    config.GetBool("tracing.insecure"),
+			SampleRatio: config.GetFloat64("tracing.sampleRatio"),
+		},
+		Bootstrap: Bootstrap{
+			AdminFirstName: config.GetString("bootstrap.admin.firstName"),
+			AdminLastName:  config.GetString("bootstrap.admin.lastName"),
+			AdminEmail:     config.GetString("bootstrap.admin.email"),
+			AdminPassword:  config.GetString("bootstrap.admin.password"),
+		},
+		filePath: configFilePath,
+	}
 }
 
 func readConfig(configFilePath string) (*viper.Viper, error) {
 	config := viper.New()
-	config.SetEnvPrefix("go_clean_architecture")
+	config.SetEnvPrefix(envPrefix)
 	config.SetConfigType("yaml")
 	config.SetConfigFile(configFilePath)
-	config.SetEnvKeyReplacer(strings.NewReplacer(".", "_"))
+	config.SetEnvKeyReplacer(envKeyReplacer)
 	config.AutomaticEnv()
 
+	for key, value := range defaults {
+		config.SetDefault(key, value)
+	}
+
 	if err := config.ReadInConfig(); err != nil {
 		return nil, fmt.Errorf("failed to read config file: %w", err)
 	}
 
+	if err := readSecretFiles(config); err != nil {
+		return nil, err
+	}
+
 	return config, nil
 }
+
+// readSecretFiles overrides the secrets whose _FILE environment variable is set with the content
+// of the file it names, such as a mounted Docker or Kubernetes secret.
+func readSecretFiles(config *viper.Viper) error {
+	for _, key := range secretKeys {
+		envName := strings.ToUpper(envPrefix + "_" + envKeyReplacer.Replace(key))
+
+		path := os.Getenv(envName + secretFileSuffix)
+		if path == "" {
+			continue
+		}
+
+		if _, ok := os.LookupEnv(envName); ok {
+			return fmt.Errorf("only one of %s and %s%s can be set", envName, envName, secretFileSuffix)
+		}
+
+		content, err := os.ReadFile(path)
+		if err != nil {
+			return fmt.Errorf("failed to read %s from %s%s: %w", key, envName, secretFileSuffix, err)
+		}
+
+		// files usually end with a newline that is not part of the secret
+		config.Set(key, strings.TrimRight(string(content), "\r\n"))
+	}
+
+	return nil
+}
+
+// getDurationMap reads a map of durations, viper lowercases its keys.
+func getDurationMap(config *viper.Viper, key string) map[string]time.Duration {
+	durations := map[string]time.Duration{}
+	for name, value := range config.GetStringMap(key) {
+		durations[name] = cast.ToDuration(value)
+	}
+
+	return durations
+}
+
+// Redacted returns a copy of the config whose secrets are masked, so that it can be printed.
+// Only the password of the connection string is masked, when it can be parsed.
+func (c Config) Redacted() Config {
+	c.Database.ConnectionString = redactConnectionString(c.Database.ConnectionString)
+	c.Auth.SigningKey = redact(c.Auth.SigningKey)
+	c.Auth.Ed25519PrivateKey = redact(c.Auth.Ed25519PrivateKey)
+	c.Bootstrap.AdminPassword = redact(c.Bootstrap.AdminPassword)
+
+	return c
+}
+
+func redact(secret string) string {
+	if secret == "" {
+		return ""
+	}
+
+	return redacted
+}
+
+func redactConnectionString(connectionString string) string {
+	u, err := url.Parse(connectionString)
+	if err != nil {
+		return redact(connectionString)
+	}
+
+	if _, ok := u.User.Password(); ok {
+		u.User = url.UserPassword(u.User.Username(), redacted)
+	}
+
+	query := u.Query()
+	if query.Has("password") {
+		query.Set("password", redacted)
+		u.RawQuery = query.Encode()
+	}
+
+	return u.String()
+}
+
+// MarshalYAML nests the API settings as in the config file.
+func (a API) MarshalYAML() (any, error) {
+	type (
+		http struct {
+			Host string `yaml:"host"`
+			Port int    `yaml:"port"`
+		}
+		timeouts struct {
+			Default time.Duration            `yaml:"default"`
+			Routes  map[string]time.Duration `yaml:"routes"`
+		}
+		health struct {
+			CheckTimeout time.Duration `yaml:"checkTimeout"`
+		}
+		shutdown struct {
+			DrainDelay  time.Duration `yaml:"drainDelay"`
+			GracePeriod time.Duration `yaml:"gracePeriod"`
+		}
+	)
+
+	return struct {
+		HTTP     http     `yaml:"http"`
+		Timeouts timeouts `yaml:"timeouts"`
+		Health   health   `yaml:"health"`
+		Shutdown shutdown `yaml:"shutdown"`
+	}{
+		HTTP:     http{Host: a.Host, Port: a.Port},
+		Timeouts: timeouts{Default: a.RequestTimeout, Routes: a.RouteTimeouts},
+		Health:   health{CheckTimeout: a.HealthCheckTimeout},
+		Shutdown: shutdown{DrainDelay: a.DrainDelay, GracePeriod: a.ShutdownGracePeriod},
+	}, nil
+}
+
+// MarshalYAML nests the admin user as in the config file.
+func (b Bootstrap) MarshalYAML() (any, error) {
+	type admin struct {
+		FirstName string `yaml:"firstName"`
+		LastName  string `yaml:"lastName"`
+		Email     string `yaml:"email"`
+		Password  string `yaml:"password"`
+	}
+
+	return struct {
+		Admin admin `yaml:"admin"`
+	}{
+		Admin: admin{FirstName: b.AdminFirstName, LastName: b.AdminLastName, Email: b.AdminEmail, Password: b.AdminPassword},
+	}, nil
+}
@@ -0,0 +1,261 @@
+package config
+
+import (
+	"errors"
+	"fmt"
+	"path/filepath"
+	"reflect"
+	"slices"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/fsnotify/fsnotify"
+	"github.com/spf13/viper"
+	"go.uber.org/zap"
+)
+
+var errNoConfigFile = errors.New("the config was not read from a file")
+
+// ReloadStatus tells which config is applied, and why the last reload failed when it did.
+type ReloadStatus struct {
+	// Generation is 1 for the config the process started with, and grows with every applied change.
+	Generation uint64
+	// ReloadedAt is when the current generation was applied, zero for the first one.
+	ReloadedAt time.Time
+	// LastError is why the last reload failed, cleared once the file is valid again.
+	LastError   string
+	LastErrorAt time.Time
+}
+
+// Watcher reloads the config file when it changes, and applies the settings that are safe
+// to change at runtime: the log level and the API timeouts. Changes to the other settings,
+// such as the listen address or the connection string, are ignored with a warning as they
+// require a restart. Environment variables and secret files are only read on startup.
+type Watcher struct {
+	log      *zap.SugaredLogger
+	viper    *viper.Viper
+	filePath string
+	notifier *fsnotify.Watcher
+	done     chan struct{}
+
+	mu          sync.Mutex
+	current     *Config
+	lastRead    *Config
+	status      ReloadStatus
+	subscribers []func(*Config)
+}
+
+// NewWatcher starts watching the file the config was read from, until it is closed.
+func NewWatcher(log *zap.SugaredLogger, config *Config) (*Watcher, error) {
+	if config.filePath == "" {
+		return nil, errNoConfigFile
+	}
+
+	v, err := readConfig(config.filePath)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get viper config: %w", err)
+	}
+
+	// the directory is watched, as editors and config maps replace the file rather than write to it
+	notifier, err := fsnotify.NewWatcher()
+	if err != nil {
+		return nil, fmt.Errorf("failed to watch config file: %w", err)
+	}
+
+	if err := notifier.Add(filepath.Dir(config.filePath)); err != nil {
+		notifier.Close()
+		return nil, fmt.Errorf("failed to watch config file: %w", err)
+	}
+
+	w := &Watcher{
+		log:      log,
+		viper:    v,
+		filePath: config.filePath,
+		notifier: notifier,
+		done:     make(chan struct{}),
+		current:  config,
+		lastRead: config,
+		status:   ReloadStatus{Generation: 1},
+	}
+
+	go w.watch()
+
+	return w, nil
+}
+
+// Close stops watching the file, waiting for a reload in progress to finish.
+func (w *Watcher) Close() error {
+	err := w.notifier.Close()
+	<-w.done
+
+	return err
+}
+
+// watch reloads the config when the file is written, created, or when the path it links to changes.
+func (w *Watcher) watch() {
+	defer close(w.done)
+
+	filePath := filepath.Clean(w.filePath)
+	realPath, _ := filepath.EvalSymlinks(filePath)
+
+	for {
+		select {
+		case event, ok := <-w.notifier.Events:
+			if !ok {
+				return
+			}
+
+			currentRealPath, _ := filepath.EvalSymlinks(filePath)
+			written := filepath.Clean(event.Name) == filePath && (event.Has(fsnotify.Write) || event.Has(fsnotify.Create))
+			relinked := currentRealPath != "" && currentRealPath != realPath
+			if written || relinked {
+				realPath = currentRealPath
+				w.reload()
+			}
+		case err, ok := <-w.notifier.Errors:
+			if !ok {
+				return
+			}
+
+			w.log.Errorf("config - failed to watch %s: %v", w.filePath, err)
+		}
+	}
+}
+
+// Current returns the config applied last, which must not be modified.
+func (w *Watcher) Current() *Config {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+
+	return w.current
+}
+
+// Status tells which config generation is applied and how the last reload went.
+func (w *Watcher) Status() ReloadStatus {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+
+	return w.status
+}
+
+// Subscribe calls onChange with the part of the config picked by selector every time a reload changes it.
+// The subscribers are called one at a time, in the order they subscribed.
+func Subscribe[T any](w *Watcher, selector func(*Config) T, onChange func(T)) {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+
+	last := selector(w.current)
+	w.subscribers = append(w.subscribers, func(config *Config) {
+		next := selector(config)
+		if reflect.DeepEqual(next, last) {
+			return
+		}
+
+		last = next
+		onChange(next)
+	})
+}
+
+func (w *Watcher) reload() {
+	if err := w.viper.ReadInConfig(); err != nil {
+		w.fail(fmt.Errorf("failed to read config file: %w", err))
+		return
+	}
+
+	next := newConfig(w.viper, w.filePath)
+	if err := next.Validate(); err != nil {
+		w.fail(fmt.Errorf("invalid config:\n%w", err))
+		return
+	}
+
+	w.mu.Lock()
+
+	// the settings requiring a restart keep their current values, which the reloaded ones must still agree with
+	applied := w.current.withReloadable(next)
+	if err := applied.Validate(); err != nil {
+		w.mu.Unlock()
+		w.fail(fmt.Errorf("invalid config along with the settings requiring a restart:\n%w", err))
+		return
+	}
+
+	// the ignored settings are only reported when their value differs from the last valid file read,
+	// not on every later save
+	changed := structuralChanges(w.lastRead, next)
+	ignored := slices.DeleteFunc(structuralChanges(w.current, next), func(key string) bool {
+		return !slices.Contains(changed, key)
+	})
+	if len(ignored) > 0 {
+		w.log.Warnf("config - ignoring changes to %s, they require a restart", strings.Join(ignored, ", "))
+	}
+
+	w.lastRead = next
+
+	w.status.LastError = ""
+	w.status.LastErrorAt = time.Time{}
+
+	if reflect.DeepEqual(applied, w.current) {
+		w.mu.Unlock()
+		return
+	}
+
+	w.current = applied
+	w.status.Generation++
+	w.status.ReloadedAt = time.Now()
+
+	generation := w.status.Generation
+	subscribers := slices.Clone(w.subscribers)
+
+	w.mu.Unlock()
+
+	w.log.Infof("config - reloaded %s, generation %d", w.filePath, generation)
+
+	for _, subscriber := range subscribers {
+		subscriber(applied)
+	}
+}
+
+// fail keeps the current config, the file may be saved again once fixed.
+func (w *Watcher) fail(err error) {
+	w.log.Errorf("config - reload of %s failed, keeping the current config: %v", w.filePath, err)
+
+	w.mu.Lock()
+	defer w.mu.Unlock()
+
+	w.status.LastError = err.Error()
+	w.status.LastErrorAt = time.Now()
+}
+
+// withReloadable returns a copy of the config with the settings that are safe to change at runtime taken from next.
+func (c *Config) withReloadable(next *Config) *Config {
+	applied := *c
+	applied.Log.Level = next.Log.Level
+	applied.API.RequestTimeout = next.API.RequestTimeout
+	applied.API.RouteTimeouts = next.API.RouteTimeouts
+	applied.API.HealthCheckTimeout = next.API.HealthCheckTimeout
+
+	return &applied
+}
+
+// structuralChanges names the settings that differ between the configs besides the reloadable ones,
+// without their values as some are secrets.
+func structuralChanges(current, next *Config) []string {
+	currentValue := reflect.ValueOf(*current)
+	nextValue := reflect.ValueOf(*next.withReloadable(current))
+
+	var changes []string
+	for i := 0; i < currentValue.NumField(); i++ {
+		section := currentValue.Type().Field(i)
+		if !section.IsExported() || section.Type.Kind() != reflect.Struct {
+			continue
+		}
+
+		for j := 0; j < section.Type.NumField(); j++ {
+			if !reflect.DeepEqual(currentValue.Field(i).Field(j).Interface(), nextValue.Field(i).Field(j).Interface()) {
+				changes = append(changes, section.Name+"."+section.Type.Field(j).Name)
+			}
+		}
+	}
+
+	return changes
+}
@@ -0,0 +1,207 @@
+package config
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"go.uber.org/zap"
+	"go.uber.org/zap/zaptest/observer"
+)
+
+func TestWatcherReload(t *testing.T) {
+	original, err := os.ReadFile("config.yaml")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	configFilePath := filepath.Join(t.TempDir(), "config.yaml")
+	writeConfig := func(replacer *strings.Replacer) {
+		if err := os.WriteFile(configFilePath, []byte(replacer.Replace(string(original))), 0o600); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	writeConfig(strings.NewReplacer())
+
+	config, err := NewConfig(configFilePath)
+	if err != nil {
+		t.Fatalf("NewConfig() error = %v", err)
+	}
+
+	watcher, err := NewWatcher(zap.NewNop().Sugar(), config)
+	if err != nil {
+		t.Fatalf("NewWatcher() error = %v", err)
+	}
+	defer watcher.Close()
+
+	levels := make(chan string, 10)
+	Subscribe(watcher, func(c *Config) string { return c.Log.Level }, func(level string) {
+		levels <- level
+	})
+
+	// the listen port requires a restart, the log level doesn't
+	writeConfig(strings.NewReplacer("level: info", "level: debug", "port: 8080", "port: 9090"))
+
+	select {
+	case level := <-levels:
+		if level != "debug" {
+			t.Errorf("Subscribe() level = %v, want debug", level)
+		}
+	case <-time.After(5 * time.Second):
+		t.Fatal("Subscribe() was not called after the config file changed")
+	}
+
+	current := watcher.Current()
+	if current.Log.Level != "debug" || current.API.Port != 8080 {
+		t.Errorf("Current() level = %v, port = %v, want debug and 8080", current.Log.Level, current.API.Port)
+	}
+
+	if status := watcher.Status(); status.Generation != 2 || status.LastError != "" {
+		t.Errorf("Status() = %+v, want generation 2 without error", status)
+	}
+
+	writeConfig(strings.NewReplacer("level: info", "level: verbose"))
+
+	deadline := time.Now().Add(5 * time.Second)
+	for !strings.Contains(watcher.Status().LastError, "log.level") {
+		if time.Now().After(deadline) {
+			t.Fatalf("Status() = %+v, want the invalid log level reported", watcher.Status())
+		}
+
+		time.Sleep(10 * time.Millisecond)
+	}
+
+	if status := watcher.Status(); status.Generation != 2 || watcher.Current().Log.Level != "debug" {
+		t.Errorf("Status() = %+v, level = %v, want the invalid config ignored", status, watcher.Current().Log.Level)
+	}
+}
+
+func TestWatcherIgnoredChangesWarning(t *testing.T) {
+	original, err := os.ReadFile("config.yaml")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	configFilePath := filepath.Join(t.TempDir(), "config.yaml")
+	writeConfig := func(replacer *strings.Replacer) {
+		if err := os.WriteFile(configFilePath, []byte(replacer.Replace(string(original))), 0o600); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	writeConfig(strings.NewReplacer())
+
+	config, err := NewConfig(configFilePath)
+	if err != nil {
+		t.Fatalf("NewConfig() error = %v", err)
+	}
+
+	core, logs := observer.New(zap.WarnLevel)
+
+	watcher, err := NewWatcher(zap.New(core).Sugar(), config)
+	if err != nil {
+		t.Fatalf("NewWatcher() error = %v", err)
+	}
+	defer watcher.Close()
+
+	levels := make(chan string, 10)
+	Subscribe(watcher, func(c *Config) string { return c.Log.Level }, func(level string) {
+		levels <- level
+	})
+
+	// every save is told apart by the log level, so that the reload can be awaited
+	saves := []struct {
+		name     string
+		replacer *strings.Replacer
+		level    string
+		warnings int
+	}{
+		{
+			name:     "port changed",
+			replacer: strings.NewReplacer("level: info", "level: debug", "port: 8080", "port: 9090"),
+			level:    "debug",
+			warnings: 1,
+		},
+		{
+			name:     "port unchanged since the last save",
+			replacer: strings.NewReplacer("level: info", "level: warn", "port: 8080", "port: 9090"),
+			level:    "warn",
+			warnings: 1,
+		},
+		{
+			name:     "port changed again",
+			replacer: strings.NewReplacer("level: info", "level: error", "port: 8080", "port: 9091"),
+			level:    "error",
+			warnings: 2,
+		},
+		{
+			name:     "port restored",
+			replacer: strings.NewReplacer(),
+			level:    "info",
+			warnings: 2,
+		},
+	}
+
+	for _, save := range saves {
+		writeConfig(save.replacer)
+
+		select {
+		case level := <-levels:
+			if level != save.level {
+				t.Fatalf("%s: Subscribe() level = %v, want %v", save.name, level, save.level)
+			}
+		case <-time.After(5 * time.Second):
+			t.Fatalf("%s: Subscribe() was not called after the config file changed", save.name)
+		}
+
+		if warnings := logs.FilterMessageSnippet("ignoring changes to API.Port").Len(); warnings != save.warnings {
+			t.Errorf("%s: got %d warnings, want %d", save.name, warnings, save.warnings)
+		}
+	}
+}
+
+func TestWatcherReloadValidatesAppliedConfig(t *testing.T) {
+	original, err := os.ReadFile("config.yaml")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	configFilePath := filepath.Join(t.TempDir(), "config.yaml")
+	writeConfig := func(replacer *strings.Replacer) {
+		if err := os.WriteFile(configFilePath, []byte(replacer.Replace(string(original))), 0o600); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	writeConfig(strings.NewReplacer())
+
+	config, err := NewConfig(configFilePath)
+	if err != nil {
+		t.Fatalf("NewConfig() error = %v", err)
+	}
+
+	watcher, err := NewWatcher(zap.NewNop().Sugar(), config)
+	if err != nil {
+		t.Fatalf("NewWatcher() error = %v", err)
+	}
+	defer watcher.Close()
+
+	// the file is valid, but the lease requires a restart while the timeout doesn't
+	writeConfig(strings.NewReplacer("default: 10s", "default: 2m", "lease: 1m #longer than the api", "lease: 5m #longer than the api"))
+
+	deadline := time.Now().Add(5 * time.Second)
+	for !strings.Contains(watcher.Status().LastError, "idempotency.lease") {
+		if time.Now().After(deadline) {
+			t.Fatalf("Status() = %+v, want the lease shorter than the reloaded timeout reported", watcher.Status())
+		}
+
+		time.Sleep(10 * time.Millisecond)
+	}
+
+	if status := watcher.Status(); status.Generation != 1 || watcher.Current().API.RequestTimeout != 10*time.Second {
+		t.Errorf("Status() = %+v, timeout = %v, want the reload rejected", status, watcher.Current().API.RequestTimeout)
+	}
+}
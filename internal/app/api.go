@@ -3,112 +3,259 @@ package app
 import (
 	"context"
 	"fmt"
-	"log"
+	"net"
 	"net/http"
-	"os"
-	"os/signal"
 	"time"
 
 	"github.com/go-openapi/runtime/middleware"
 	"github.com/gorilla/mux"
-	"github.com/lyracampos/go-clean-architecture/config"
 	"github.com/lyracampos/go-clean-architecture/internal/domain"
 	"github.com/lyracampos/go-clean-architecture/internal/domain/usecases"
+	"github.com/lyracampos/go-clean-architecture/internal/gateways/configs"
+	"github.com/lyracampos/go-clean-architecture/internal/gateways/passwords"
 	"github.com/lyracampos/go-clean-architecture/internal/gateways/postgres"
+	"github.com/lyracampos/go-clean-architecture/internal/gateways/tokens"
 	"github.com/lyracampos/go-clean-architecture/internal/services/api/handlers"
-	"go.uber.org/zap"
+	"github.com/lyracampos/go-clean-architecture/internal/services/api/middlewares"
+	"github.com/lyracampos/go-clean-architecture/internal/telemetry"
 )
 
-func RunAPI(config *config.Config) {
+// addAPI adds the HTTP API servers to the process, stopped once its readiness probe has failed
+// long enough for the load balancers to stop sending requests.
+func addAPI(p *process) error {
+	config, sugar, lifecycle, metrics, postgresClient := p.config, p.log, p.lifecycle, p.metrics, p.postgresClient
+
 	router := mux.NewRouter()
 
-	logger, err := zap.NewProduction()
+	migrator, err := postgres.NewMigrator(sugar, postgresClient)
 	if err != nil {
-		log.Fatalf("can't initialize zap logger: %v", err)
+		return fmt.Errorf("can't initialize postgres migrator: %w", err)
 	}
-	defer func() {
-		if err := logger.Sync(); err != nil {
-			log.Fatalf("failed to defer logger sync: %v", err)
+
+	if config.Database.VerifySchemaVersion {
+		if err := migrator.Verify(context.Background()); err != nil {
+			return fmt.Errorf("database schema is not up to date, run the migrate entrypoint: %w", err)
 		}
-	}()
+	}
 
-	sugar := logger.Sugar()
+	userDatabaseGateway := postgres.NewUserDatabase(postgresClient)
+	credentialsDatabaseGateway := postgres.NewCredentialsDatabase(postgresClient)
+	refreshTokenDatabaseGateway := postgres.NewRefreshTokenDatabase(postgresClient)
+	apiKeyDatabaseGateway := postgres.NewAPIKeyDatabase(postgresClient)
+	webhookDatabaseGateway := postgres.NewWebhookDatabase(postgresClient)
+	idempotencyDatabaseGateway := postgres.NewIdempotencyDatabase(postgresClient)
+	configStatusGateway := configs.NewStatusGateway(p.watcher)
 
-	// database
-	postgresClient, err := postgres.NewClient(sugar, config)
+	// auth
+	passwordHasher, err := passwords.NewBcryptHasher(config.Auth.PasswordHashCost)
 	if err != nil {
-		log.Fatalf("can't initialize postgres client: %v", err)
+		return fmt.Errorf("can't initialize password hasher: %w", err)
 	}
 
-	userDatabaseGateway := postgres.NewUserDatabase(postgresClient)
+	tokenService, err := tokens.NewJWTService(config)
+	if err != nil {
+		return fmt.Errorf("can't initialize token service: %w", err)
+	}
+
+	apiKeyService := tokens.NewAPIKeyService()
 
 	validator := domain.NewValidatorService()
 
-	// use cases
-	listUserUseCase := usecases.NewListUserUseCase(userDatabaseGateway)
-	getUserUseCase := usecases.NewGetUserUseCase(userDatabaseGateway)
-	createUserUseCase := usecases.NewCreateUserUseCase(userDatabaseGateway, validator)
+	// use cases, measured under their names
+	listUserUseCase := telemetry.InstrumentUseCase(metrics, "ListUser", usecases.NewListUserUseCase(userDatabaseGateway, validator))
+	getUserUseCase := telemetry.InstrumentUseCase(metrics, "GetUser", usecases.NewGetUserUseCase(userDatabaseGateway))
+	createUserUseCase := telemetry.InstrumentUseCase(metrics, "CreateUser", usecases.NewCreateUserUseCase(userDatabaseGateway, validator))
+	updateUserUseCase := telemetry.InstrumentUseCase(metrics, "UpdateUser", usecases.NewUpdateUserUseCase(userDatabaseGateway, validator))
+	deleteUserUseCase := telemetry.InstrumentUseCaseWithoutOutput(metrics, "DeleteUser", usecases.NewDeleteUserUseCase(userDatabaseGateway))
+	restoreUserUseCase := telemetry.InstrumentUseCase(metrics, "RestoreUser", usecases.NewRestoreUserUseCase(userDatabaseGateway))
+	loginUseCase := telemetry.InstrumentUseCase(metrics, "Login", usecases.NewLoginUseCase(userDatabaseGateway, credentialsDatabaseGateway, refreshTokenDatabaseGateway, passwordHasher, tokenService, validator))
+	refreshTokenUseCase := telemetry.InstrumentUseCase(metrics, "RefreshToken", usecases.NewRefreshTokenUseCase(userDatabaseGateway, refreshTokenDatabaseGateway, tokenService, validator))
+	setPasswordUseCase := telemetry.InstrumentUseCaseWithoutOutput(metrics, "SetPassword", usecases.NewSetPasswordUseCase(userDatabaseGateway, credentialsDatabaseGateway, refreshTokenDatabaseGateway, postgresClient, passwordHasher, validator))
+	createAPIKeyUseCase := telemetry.InstrumentUseCase(metrics, "CreateAPIKey", usecases.NewCreateAPIKeyUseCase(apiKeyDatabaseGateway, apiKeyService, validator))
+	listAPIKeysUseCase := telemetry.InstrumentUseCaseWithoutInput(metrics, "ListAPIKeys", usecases.NewListAPIKeysUseCase(apiKeyDatabaseGateway))
+	deleteAPIKeyUseCase := telemetry.InstrumentUseCaseWithoutOutput(metrics, "DeleteAPIKey", usecases.NewDeleteAPIKeyUseCase(apiKeyDatabaseGateway))
+	authenticateAPIKeyUseCase := telemetry.InstrumentUseCase(metrics, "AuthenticateAPIKey", usecases.NewAuthenticateAPIKeyUseCase(userDatabaseGateway, apiKeyDatabaseGateway, apiKeyService))
+	createWebhookUseCase := telemetry.InstrumentUseCase(metrics, "CreateWebhook", usecases.NewCreateWebhookUseCase(webhookDatabaseGateway, validator))
+	listWebhooksUseCase := telemetry.InstrumentUseCaseWithoutInput(metrics, "ListWebhooks", usecases.NewListWebhooksUseCase(webhookDatabaseGateway))
+	getWebhookUseCase := telemetry.InstrumentUseCase(metrics, "GetWebhook", usecases.NewGetWebhookUseCase(webhookDatabaseGateway))
+	updateWebhookUseCase := telemetry.InstrumentUseCase(metrics, "UpdateWebhook", usecases.NewUpdateWebhookUseCase(webhookDatabaseGateway, validator))
+	deleteWebhookUseCase := telemetry.InstrumentUseCaseWithoutOutput(metrics, "DeleteWebhook", usecases.NewDeleteWebhookUseCase(webhookDatabaseGateway))
+	listWebhookDeliveriesUseCase := telemetry.InstrumentUseCase(metrics, "ListWebhookDeliveries", usecases.NewListWebhookDeliveriesUseCase(webhookDatabaseGateway, validator))
+	beginIdempotentRequestUseCase := telemetry.InstrumentUseCase(metrics, "BeginIdempotentRequest", usecases.NewBeginIdempotentRequestUseCase(idempotencyDatabaseGateway, config.Idempotency.TTL, config.Idempotency.Lease, validator))
+	completeIdempotentRequestUseCase := telemetry.InstrumentUseCaseWithoutOutput(metrics, "CompleteIdempotentRequest", usecases.NewCompleteIdempotentRequestUseCase(idempotencyDatabaseGateway, validator))
+	getConfigStatusUseCase := telemetry.InstrumentUseCaseWithoutInput(metrics, "GetConfigStatus", usecases.NewGetConfigStatusUseCase(configStatusGateway))
 
 	// health handler
-	healthHandler := handlers.NewHealthHandler(sugar)
+	healthChecks := append([]handlers.HealthCheck{
+		databaseHealthCheck(postgresClient),
+		schemaHealthCheck(migrator),
+	}, p.healthChecks...)
+	healthHandler := handlers.NewHealthHandler(sugar, config.API.HealthCheckTimeout, healthChecks...)
 	healthRouter := router.Methods(http.MethodGet).Subrouter()
-	healthRouter.HandleFunc("/health", healthHandler.CheckStatus)
+	healthRouter.HandleFunc("/health/live", healthHandler.Live).Name("Live")
+	healthRouter.HandleFunc("/health/ready", healthHandler.Ready).Name("Ready")
+
+	// middlewares
+	tracing := middlewares.NewTracing()
+	requestLogging := middlewares.NewRequestLogging(sugar)
+	requestMetrics := middlewares.NewMetrics(metrics)
+	timeout := middlewares.NewTimeout(config.API.RequestTimeout, config.API.RouteTimeouts)
+	router.Use(tracing.Middleware, requestLogging.Middleware, requestMetrics.Middleware, timeout.Middleware)
+
+	subscribeAPITimeouts(p.watcher, timeout.SetTimeouts, healthHandler.SetCheckTimeout)
+
+	authentication := middlewares.NewAuthentication(sugar, tokenService, authenticateAPIKeyUseCase)
+	idempotency := middlewares.NewIdempotency(sugar, beginIdempotentRequestUseCase, completeIdempotentRequestUseCase)
 
 	// user handlers
-	userHandler := handlers.NewUserHandler(sugar, listUserUseCase, getUserUseCase, createUserUseCase)
+	userHandler := handlers.NewUserHandler(
+		sugar,
+		listUserUseCase,
+		getUserUseCase,
+		createUserUseCase,
+		updateUserUseCase,
+		deleteUserUseCase,
+		restoreUserUseCase,
+	)
 
 	listUserRouter := router.Methods(http.MethodGet).Subrouter()
-	listUserRouter.HandleFunc("/users", userHandler.ListUsers)
+	listUserRouter.Use(authentication.Middleware)
+	listUserRouter.HandleFunc("/users", userHandler.ListUsers).Name("ListUsers")
 
 	getUserRouter := router.Methods(http.MethodGet).Subrouter()
-	getUserRouter.HandleFunc("/users/{id:[0-9]+}", userHandler.GetUser)
+	getUserRouter.Use(authentication.Middleware)
+	getUserRouter.HandleFunc("/users/{id:[0-9]+}", userHandler.GetUser).Name("GetUser")
 
 	createUserRouter := router.Methods(http.MethodPost).Subrouter()
-	createUserRouter.HandleFunc("/users", userHandler.CreateUser)
+	createUserRouter.Use(authentication.Middleware, idempotency.Middleware)
+	createUserRouter.HandleFunc("/users", userHandler.CreateUser).Name("AddUser")
+
+	updateUserRouter := router.Methods(http.MethodPut).Subrouter()
+	updateUserRouter.Use(authentication.Middleware)
+	updateUserRouter.HandleFunc("/users/{id:[0-9]+}", userHandler.UpdateUser).Name("UpdateUser")
+
+	patchUserRouter := router.Methods(http.MethodPatch).Subrouter()
+	patchUserRouter.Use(authentication.Middleware)
+	patchUserRouter.HandleFunc("/users/{id:[0-9]+}", userHandler.PatchUser).Name("PatchUser")
+
+	deleteUserRouter := router.Methods(http.MethodDelete).Subrouter()
+	deleteUserRouter.Use(authentication.Middleware)
+	deleteUserRouter.HandleFunc("/users/{id:[0-9]+}", userHandler.DeleteUser).Name("DeleteUser")
+
+	restoreUserRouter := router.Methods(http.MethodPost).Subrouter()
+	restoreUserRouter.Use(authentication.Middleware)
+	restoreUserRouter.HandleFunc("/users/{id:[0-9]+}/restore", userHandler.RestoreUser).Name("RestoreUser")
+
+	// auth handlers
+	authHandler := handlers.NewAuthHandler(sugar, loginUseCase, refreshTokenUseCase, setPasswordUseCase)
+
+	loginRouter := router.Methods(http.MethodPost).Subrouter()
+	loginRouter.HandleFunc("/auth/login", authHandler.Login).Name("Login")
+
+	refreshTokenRouter := router.Methods(http.MethodPost).Subrouter()
+	refreshTokenRouter.HandleFunc("/auth/refresh", authHandler.RefreshToken).Name("RefreshToken")
+
+	setPasswordRouter := router.Methods(http.MethodPut).Subrouter()
+	setPasswordRouter.Use(authentication.Middleware)
+	setPasswordRouter.HandleFunc("/users/{id:[0-9]+}/password", authHandler.SetPassword).Name("SetPassword")
+
+	// api key handlers
+	apiKeyHandler := handlers.NewAPIKeyHandler(sugar, createAPIKeyUseCase, listAPIKeysUseCase, deleteAPIKeyUseCase)
+
+	createAPIKeyRouter := router.Methods(http.MethodPost).Subrouter()
+	createAPIKeyRouter.Use(authentication.Middleware)
+	createAPIKeyRouter.HandleFunc("/api-keys", apiKeyHandler.CreateAPIKey).Name("CreateAPIKey")
+
+	listAPIKeysRouter := router.Methods(http.MethodGet).Subrouter()
+	listAPIKeysRouter.Use(authentication.Middleware)
+	listAPIKeysRouter.HandleFunc("/api-keys", apiKeyHandler.ListAPIKeys).Name("ListAPIKeys")
+
+	deleteAPIKeyRouter := router.Methods(http.MethodDelete).Subrouter()
+	deleteAPIKeyRouter.Use(authentication.Middleware)
+	deleteAPIKeyRouter.HandleFunc("/api-keys/{id:[0-9]+}", apiKeyHandler.DeleteAPIKey).Name("DeleteAPIKey")
+
+	// webhook handlers
+	webhookHandler := handlers.NewWebhookHandler(sugar, createWebhookUseCase, listWebhooksUseCase, getWebhookUseCase,
+		updateWebhookUseCase, deleteWebhookUseCase, listWebhookDeliveriesUseCase)
+
+	createWebhookRouter := router.Methods(http.MethodPost).Subrouter()
+	createWebhookRouter.Use(authentication.Middleware)
+	createWebhookRouter.HandleFunc("/webhooks", webhookHandler.CreateWebhook).Name("CreateWebhook")
+
+	listWebhooksRouter := router.Methods(http.MethodGet).Subrouter()
+	listWebhooksRouter.Use(authentication.Middleware)
+	listWebhooksRouter.HandleFunc("/webhooks", webhookHandler.ListWebhooks).Name("ListWebhooks")
+	listWebhooksRouter.HandleFunc("/webhooks/{id:[0-9]+}", webhookHandler.GetWebhook).Name("GetWebhook")
+	listWebhooksRouter.HandleFunc("/webhooks/{id:[0-9]+}/deliveries", webhookHandler.ListWebhookDeliveries).Name("ListWebhookDeliveries")
+
+	updateWebhookRouter := router.Methods(http.MethodPut).Subrouter()
+	updateWebhookRouter.Use(authentication.Middleware)
+	updateWebhookRouter.HandleFunc("/webhooks/{id:[0-9]+}", webhookHandler.UpdateWebhook).Name("UpdateWebhook")
+
+	deleteWebhookRouter := router.Methods(http.MethodDelete).Subrouter()
+	deleteWebhookRouter.Use(authentication.Middleware)
+	deleteWebhookRouter.HandleFunc("/webhooks/{id:[0-9]+}", webhookHandler.DeleteWebhook).Name("DeleteWebhook")
+
+	// admin handlers
+	adminHandler := handlers.NewAdminHandler(sugar, getConfigStatusUseCase)
+
+	getConfigStatusRouter := router.Methods(http.MethodGet).Subrouter()
+	getConfigStatusRouter.Use(authentication.Middleware)
+	getConfigStatusRouter.HandleFunc("/admin/config", adminHandler.GetConfigStatus).Name("GetConfigStatus")
+
+	// metrics, unless they have their own port
+	if config.Metrics.Enabled && config.Metrics.Port == 0 {
+		router.Handle("/metrics", metrics.Handler()).Methods(http.MethodGet)
+	}
 
 	router.Handle("/swagger.yaml", http.FileServer(http.Dir("./")))
 	opts := middleware.SwaggerUIOpts{SpecURL: "swagger.yaml"}
 	sh := middleware.SwaggerUI(opts, nil)
 	router.Handle("/docs", sh)
 
-	http.Handle("/", router)
-
 	address := fmt.Sprintf("%s:%d", config.API.Host, config.API.Port)
 
+	// every request context derives from baseCtx, canceling it stops the requests still running on shutdown
+	baseCtx, cancelBaseCtx := context.WithCancel(context.Background())
+
 	server := &http.Server{
 		Addr:         address,
 		WriteTimeout: time.Second * 15,
 		ReadTimeout:  time.Second * 15,
 		IdleTimeout:  time.Second * 60,
 		Handler:      router,
+		BaseContext: func(net.Listener) context.Context {
+			return baseCtx
+		},
 	}
 
-	go func() {
-		sugar.Infof("running API HTTP server at: %s", address)
-
-		if err := server.ListenAndServe(); err != nil {
-			sugar.Errorln("Error starting server: %w", err)
-		}
-	}()
+	// the components are stopped in reverse order: the readiness probe fails first, giving
+	// the load balancers time to stop sending requests, then the servers stop, and the requests
+	// that outlived their grace period are canceled along with their queries
+	lifecycle.add(component{name: "in-flight requests", stop: func(context.Context) error {
+		cancelBaseCtx()
+		return nil
+	}})
 
-	c := make(chan os.Signal, 1)
-	// We'll accept graceful shutdowns when quit via SIGINT (Ctrl+C)
-	// SIGKILL, SIGQUIT or SIGTERM (Ctrl+/) will not be caught.
-	signal.Notify(c, os.Interrupt)
+	lifecycle.add(
+		httpServerComponent("api server", server, config.API.ShutdownGracePeriod),
+		component{
+			name: "readiness",
+			stop: func(ctx context.Context) error {
+				healthHandler.ShutDown()
 
-	// Block until a signal is received.
-	sig := <-c
-	log.Println("Got signal:", sig)
+				select {
+				case <-time.After(config.API.DrainDelay):
+				case <-ctx.Done():
+				}
 
-	// gracefully shutdown the server, waiting max 30 seconds for current operations to complete
-	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
-	defer cancel()
+				return nil
+			},
+			gracePeriod: config.API.DrainDelay + defaultGracePeriod,
+		},
+	)
 
-	// Doesn't block if no connections, but will otherwise wait
-	// until the timeout deadline.
-	if err := server.Shutdown(ctx); err != nil {
-		sugar.Errorln("Error shutting down server: %w", err)
-	}
+	sugar.Infof("running API HTTP server at: %s", address)
 
-	log.Println("shutting down")
+	return nil
 }
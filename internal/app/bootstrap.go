@@ -0,0 +1,90 @@
+package app
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+
+	"github.com/lyracampos/go-clean-architecture/config"
+	"github.com/lyracampos/go-clean-architecture/internal/domain"
+	"github.com/lyracampos/go-clean-architecture/internal/domain/usecases"
+	"github.com/lyracampos/go-clean-architecture/internal/gateways/passwords"
+	"github.com/lyracampos/go-clean-architecture/internal/gateways/postgres"
+)
+
+var errBootstrapAdminNotConfigured = errors.New("bootstrap admin email and password must be configured")
+
+// RunBootstrap creates the first admin user from the configuration, as every
+// users route requires an authenticated admin. It does nothing when the
+// email is already in use, so it can run on every deployment.
+func RunBootstrap(config *config.Config) error {
+	if config.Bootstrap.AdminEmail == "" || config.Bootstrap.AdminPassword == "" {
+		return errBootstrapAdminNotConfigured
+	}
+
+	logger, _, err := newLogger(config.Log.Level)
+	if err != nil {
+		return fmt.Errorf("can't initialize zap logger: %w", err)
+	}
+	defer func() {
+		if err := logger.Sync(); err != nil {
+			log.Printf("failed to defer logger sync: %v", err)
+		}
+	}()
+
+	sugar := logger.Sugar()
+
+	// database
+	postgresClient, err := postgres.NewClient(sugar, config)
+	if err != nil {
+		return fmt.Errorf("can't initialize postgres client: %w", err)
+	}
+	defer postgresClient.DB.Close()
+
+	userDatabaseGateway := postgres.NewUserDatabase(postgresClient)
+	credentialsDatabaseGateway := postgres.NewCredentialsDatabase(postgresClient)
+	refreshTokenDatabaseGateway := postgres.NewRefreshTokenDatabase(postgresClient)
+
+	passwordHasher, err := passwords.NewBcryptHasher(config.Auth.PasswordHashCost)
+	if err != nil {
+		return fmt.Errorf("can't initialize password hasher: %w", err)
+	}
+
+	validator := domain.NewValidatorService()
+
+	createUserUseCase := usecases.NewCreateUserUseCase(userDatabaseGateway, validator)
+	setPasswordUseCase := usecases.NewSetPasswordUseCase(userDatabaseGateway, credentialsDatabaseGateway, refreshTokenDatabaseGateway, postgresClient, passwordHasher, validator)
+
+	// the password is checked first, as an admin created without credentials would be skipped by the next runs
+	if err := validator.Validate(usecases.SetPasswordInput{Password: config.Bootstrap.AdminPassword}); err != nil {
+		return fmt.Errorf("invalid bootstrap admin password: %w", err)
+	}
+
+	ctx := domain.WithCaller(context.Background(), domain.SystemCaller)
+
+	admin, err := createUserUseCase.Execute(ctx, *usecases.NewCreateUserInput(
+		usecases.WithCreateUserInputFirstName(config.Bootstrap.AdminFirstName),
+		usecases.WithCreateUserInputLastName(config.Bootstrap.AdminLastName),
+		usecases.WithCreateUserInputEmail(config.Bootstrap.AdminEmail),
+		usecases.WithCreateUserInputRole(domain.RoleAdmin),
+	))
+	if errors.Is(err, domain.ErrEmailAlreadyInUse) {
+		sugar.Infof("bootstrap admin %s already exists, skipping", config.Bootstrap.AdminEmail)
+		return nil
+	}
+	if err != nil {
+		return fmt.Errorf("failed to create bootstrap admin: %w", err)
+	}
+
+	if err := setPasswordUseCase.Execute(ctx, usecases.SetPasswordInput{
+		UserID:   admin.ID,
+		Password: config.Bootstrap.AdminPassword,
+	}); err != nil {
+		return fmt.Errorf("failed to set bootstrap admin password: %w", err)
+	}
+
+	sugar.Infof("created bootstrap admin %s with id %d", admin.Email, admin.ID)
+
+	return nil
+}
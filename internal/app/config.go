@@ -0,0 +1,24 @@
+package app
+
+import (
+	"fmt"
+	"os"
+
+	"github.com/lyracampos/go-clean-architecture/config"
+	"gopkg.in/yaml.v3"
+)
+
+// RunConfig prints the effective config, once the defaults, the environment and the secret files
+// are applied, with its secrets redacted.
+func RunConfig(config *config.Config) error {
+	out, err := yaml.Marshal(config.Redacted())
+	if err != nil {
+		return fmt.Errorf("failed to marshal config: %w", err)
+	}
+
+	if _, err := os.Stdout.Write(out); err != nil {
+		return fmt.Errorf("failed to print config: %w", err)
+	}
+
+	return nil
+}
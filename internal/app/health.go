@@ -0,0 +1,62 @@
+package app
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/lyracampos/go-clean-architecture/internal/gateways/postgres"
+	"github.com/lyracampos/go-clean-architecture/internal/services/api/handlers"
+)
+
+var errComponentNotRunning = errors.New("component is not running")
+
+// databaseHealthCheck pings the database through the connection pool.
+func databaseHealthCheck(client *postgres.Client) handlers.HealthCheck {
+	return handlers.HealthCheck{
+		Name: "database",
+		Check: func(ctx context.Context) (string, error) {
+			return "", client.DB.PingContext(ctx)
+		},
+	}
+}
+
+// schemaHealthCheck reports the schema version, failing when it is dirty or behind the binary migrations.
+func schemaHealthCheck(migrator *postgres.Migrator) handlers.HealthCheck {
+	return handlers.HealthCheck{
+		Name: "schema",
+		Check: func(ctx context.Context) (string, error) {
+			version, dirty, err := migrator.Version(ctx)
+			if err != nil {
+				return "", err
+			}
+
+			detail := fmt.Sprintf("version %d, latest %d", version, migrator.Latest())
+
+			if dirty {
+				return detail + ", dirty", postgres.ErrSchemaDirty
+			}
+
+			if version < migrator.Latest() {
+				return detail, postgres.ErrSchemaOutdated
+			}
+
+			return detail, nil
+		},
+	}
+}
+
+// componentHealthCheck reports the state of a component run by the lifecycle, failing unless it is running.
+func componentHealthCheck(lifecycle *lifecycle, name string) handlers.HealthCheck {
+	return handlers.HealthCheck{
+		Name: name,
+		Check: func(context.Context) (string, error) {
+			state := lifecycle.state(name)
+			if state != componentRunning {
+				return state, errComponentNotRunning
+			}
+
+			return state, nil
+		},
+	}
+}
@@ -0,0 +1,249 @@
+package app
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net"
+	"net/http"
+	"os/signal"
+	"sync"
+	"syscall"
+	"time"
+
+	"go.uber.org/zap"
+)
+
+// defaultGracePeriod bounds the shutdown of the components without a configured grace period.
+const defaultGracePeriod = 5 * time.Second
+
+// states of a component, reported by its health check
+const (
+	componentPending  = "pending"
+	componentRunning  = "running"
+	componentFailed   = "failed"
+	componentStopping = "stopping"
+	componentStopped  = "stopped"
+)
+
+var errComponentStopped = errors.New("stopped unexpectedly")
+
+// component is a part of the application started and stopped by the lifecycle,
+// such as the database client, an HTTP server or a worker loop. Every step is optional.
+type component struct {
+	name string
+	// start prepares the component, a failure aborts the startup.
+	start func(ctx context.Context) error
+	// run blocks while the component works, its context is canceled when the component is stopped.
+	// Returning before that is a failure that shuts the application down.
+	run func(ctx context.Context) error
+	// stop releases the component, it must return before its context is done.
+	stop func(ctx context.Context) error
+	// gracePeriod bounds how long stopping the component may take, defaultGracePeriod when zero.
+	gracePeriod time.Duration
+}
+
+// lifecycle starts the components in order, and stops the started ones in reverse order
+// on SIGINT, SIGTERM or as soon as one of them fails.
+type lifecycle struct {
+	log        *zap.SugaredLogger
+	components []component
+
+	mu     sync.Mutex
+	states map[string]string
+}
+
+func newLifecycle(log *zap.SugaredLogger) *lifecycle {
+	return &lifecycle{
+		log:    log,
+		states: map[string]string{},
+	}
+}
+
+func (l *lifecycle) add(components ...component) {
+	l.components = append(l.components, components...)
+}
+
+// state tells where the named component is in its lifecycle, pending until it is started.
+func (l *lifecycle) state(name string) string {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	if state, ok := l.states[name]; ok {
+		return state
+	}
+
+	return componentPending
+}
+
+func (l *lifecycle) setState(name, state string) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	// a failure is kept once the component is stopped, so that it can still be told apart
+	if l.states[name] == componentFailed && state != componentRunning {
+		return
+	}
+
+	l.states[name] = state
+}
+
+// run blocks until the application is shut down, returning why it was when it was not by a signal,
+// along with the failures to stop the components.
+func (l *lifecycle) run(ctx context.Context) error {
+	ctx, stopSignals := signal.NotifyContext(ctx, syscall.SIGINT, syscall.SIGTERM)
+	defer stopSignals()
+
+	failures := make(chan error, len(l.components))
+	started := make([]*runningComponent, 0, len(l.components))
+
+	var err error
+	for _, c := range l.components {
+		if c.start != nil {
+			if startErr := c.start(ctx); startErr != nil {
+				l.setState(c.name, componentFailed)
+				err = fmt.Errorf("failed to start %s: %w", c.name, startErr)
+				break
+			}
+		}
+
+		l.setState(c.name, componentRunning)
+		started = append(started, l.startComponent(c, failures))
+		l.log.Infof("lifecycle - %s started", c.name)
+	}
+
+	if err == nil {
+		select {
+		case <-ctx.Done():
+			l.log.Info("lifecycle - shutdown signal received")
+		case err = <-failures:
+			l.log.Errorf("lifecycle - shutting down: %v", err)
+		}
+	}
+
+	for i := len(started) - 1; i >= 0; i-- {
+		l.setState(started[i].name, componentStopping)
+
+		if stopErr := started[i].shutdown(); stopErr != nil {
+			l.setState(started[i].name, componentFailed)
+			l.log.Errorf("lifecycle - %v", stopErr)
+			err = errors.Join(err, stopErr)
+		} else {
+			l.setState(started[i].name, componentStopped)
+			l.log.Infof("lifecycle - %s stopped", started[i].name)
+		}
+	}
+
+	return err
+}
+
+type runningComponent struct {
+	component
+	cancel context.CancelFunc
+	done   chan struct{}
+	err    error
+}
+
+func (l *lifecycle) startComponent(c component, failures chan<- error) *runningComponent {
+	// the components are stopped one by one, so their contexts don't follow the signals
+	ctx, cancel := context.WithCancel(context.Background())
+
+	rc := &runningComponent{
+		component: c,
+		cancel:    cancel,
+		done:      make(chan struct{}),
+	}
+
+	if c.run == nil {
+		close(rc.done)
+		return rc
+	}
+
+	go func() {
+		defer close(rc.done)
+
+		err := c.run(ctx)
+		if ctx.Err() != nil {
+			// asked to stop, the error is reported by shutdown
+			rc.err = err
+			return
+		}
+
+		if err == nil {
+			err = errComponentStopped
+		}
+
+		l.setState(c.name, componentFailed)
+		failures <- fmt.Errorf("%s: %w", c.name, err)
+	}()
+
+	return rc
+}
+
+// shutdown stops the component and waits for it to return within its grace period.
+func (rc *runningComponent) shutdown() error {
+	gracePeriod := rc.gracePeriod
+	if gracePeriod <= 0 {
+		gracePeriod = defaultGracePeriod
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), gracePeriod)
+	defer cancel()
+
+	rc.cancel()
+
+	var err error
+	if rc.stop != nil {
+		if stopErr := rc.stop(ctx); stopErr != nil {
+			err = fmt.Errorf("failed to stop %s: %w", rc.name, stopErr)
+		}
+	}
+
+	select {
+	case <-rc.done:
+		if rc.err != nil {
+			err = errors.Join(err, fmt.Errorf("%s: %w", rc.name, rc.err))
+		}
+	case <-ctx.Done():
+		err = errors.Join(err, fmt.Errorf("%s did not stop within %s", rc.name, gracePeriod))
+	}
+
+	return err
+}
+
+// httpServerComponent listens as soon as it is started, so that errors such as a port
+// already in use abort the startup instead of being noticed later.
+func httpServerComponent(name string, server *http.Server, gracePeriod time.Duration) component {
+	var listener net.Listener
+
+	return component{
+		name: name,
+		start: func(context.Context) error {
+			var err error
+			listener, err = net.Listen("tcp", server.Addr)
+
+			return err
+		},
+		run: func(context.Context) error {
+			if err := server.Serve(listener); !errors.Is(err, http.ErrServerClosed) {
+				return err
+			}
+
+			return nil
+		},
+		stop:        server.Shutdown,
+		gracePeriod: gracePeriod,
+	}
+}
+
+// loopComponent runs a loop returning once its context is canceled, such as the worker ones.
+func loopComponent(name string, loop func(ctx context.Context), gracePeriod time.Duration) component {
+	return component{
+		name: name,
+		run: func(ctx context.Context) error {
+			loop(ctx)
+			return nil
+		},
+		gracePeriod: gracePeriod,
+	}
+}
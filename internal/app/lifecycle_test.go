@@ -0,0 +1,159 @@
+package app
+
+import (
+	"context"
+	"errors"
+	"slices"
+	"testing"
+	"time"
+
+	"go.uber.org/zap"
+)
+
+func TestLifecycleRun(t *testing.T) {
+	errStart := errors.New("address already in use")
+	errRun := errors.New("connection lost")
+
+	tests := []struct {
+		name      string
+		failStart bool
+		wantSteps []string
+		wantErr   bool
+		err       error
+	}{
+		{
+			name:      "stop started components in reverse order when one fails",
+			wantSteps: []string{"start first", "start second", "stop second", "stop first"},
+			wantErr:   true,
+			err:       errors.New("second: connection lost"),
+		},
+		{
+			name:      "stop started components when one fails to start",
+			failStart: true,
+			wantSteps: []string{"start first", "start second", "stop first"},
+			wantErr:   true,
+			err:       errors.New("failed to start second: address already in use"),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var steps []string
+
+			step := func(name string, err error) func(context.Context) error {
+				return func(context.Context) error {
+					steps = append(steps, name)
+					return err
+				}
+			}
+
+			var startErr error
+			if tt.failStart {
+				startErr = errStart
+			}
+
+			lifecycle := newLifecycle(zap.NewNop().Sugar())
+			lifecycle.add(
+				component{
+					name:  "first",
+					start: step("start first", nil),
+					run: func(ctx context.Context) error {
+						<-ctx.Done()
+						return nil
+					},
+					stop: step("stop first", nil),
+				},
+				component{
+					name:  "second",
+					start: step("start second", startErr),
+					run: func(ctx context.Context) error {
+						return errRun
+					},
+					stop: step("stop second", nil),
+				},
+			)
+
+			err := lifecycle.run(context.Background())
+			if (err != nil) != tt.wantErr {
+				t.Errorf("run() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+
+			if tt.wantErr && err.Error() != tt.err.Error() {
+				t.Errorf("run() error = %v, want %v", err, tt.err)
+			}
+
+			if !slices.Equal(steps, tt.wantSteps) {
+				t.Errorf("run() steps = %v, want %v", steps, tt.wantSteps)
+			}
+		})
+	}
+}
+
+func TestLifecycleRunGracePeriod(t *testing.T) {
+	lifecycle := newLifecycle(zap.NewNop().Sugar())
+	lifecycle.add(
+		component{
+			name: "stuck",
+			run: func(ctx context.Context) error {
+				<-ctx.Done()
+				time.Sleep(time.Second)
+				return nil
+			},
+			gracePeriod: 10 * time.Millisecond,
+		},
+		component{
+			name: "failing",
+			run: func(context.Context) error {
+				return nil
+			},
+		},
+	)
+
+	err := lifecycle.run(context.Background())
+
+	want := "failing: stopped unexpectedly\nstuck did not stop within 10ms"
+	if err == nil || err.Error() != want {
+		t.Errorf("run() error = %v, want %v", err, want)
+	}
+}
+
+func TestLifecycleState(t *testing.T) {
+	var stateWhileRunning string
+
+	lifecycle := newLifecycle(zap.NewNop().Sugar())
+	lifecycle.add(
+		component{
+			name: "first",
+			run: func(ctx context.Context) error {
+				<-ctx.Done()
+				return nil
+			},
+		},
+		component{
+			name: "second",
+			run: func(context.Context) error {
+				stateWhileRunning = lifecycle.state("first")
+				return errors.New("connection lost")
+			},
+		},
+	)
+
+	if state := lifecycle.state("first"); state != componentPending {
+		t.Errorf("state() before run = %v, want %v", state, componentPending)
+	}
+
+	_ = lifecycle.run(context.Background())
+
+	if stateWhileRunning != componentRunning {
+		t.Errorf("state() while running = %v, want %v", stateWhileRunning, componentRunning)
+	}
+
+	if state := lifecycle.state("first"); state != componentStopped {
+		t.Errorf("state() of first = %v, want %v", state, componentStopped)
+	}
+
+	if state := lifecycle.state("second"); state != componentFailed {
+		t.Errorf("state() of second = %v, want %v", state, componentFailed)
+	}
+}
@@ -0,0 +1,23 @@
+package app
+
+import (
+	"fmt"
+
+	"go.uber.org/zap"
+)
+
+// newLogger builds the production logger logging from the given level on,
+// which can be changed while it is used through the returned level.
+func newLogger(level string) (*zap.Logger, zap.AtomicLevel, error) {
+	zapConfig := zap.NewProductionConfig()
+	if err := zapConfig.Level.UnmarshalText([]byte(level)); err != nil {
+		return nil, zapConfig.Level, fmt.Errorf("invalid log level: %w", err)
+	}
+
+	logger, err := zapConfig.Build()
+	if err != nil {
+		return nil, zapConfig.Level, err
+	}
+
+	return logger, zapConfig.Level, nil
+}
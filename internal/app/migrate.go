@@ -0,0 +1,122 @@
+package app
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"strconv"
+
+	"github.com/lyracampos/go-clean-architecture/config"
+	"github.com/lyracampos/go-clean-architecture/internal/gateways/postgres"
+)
+
+const (
+	migrateUpCommand     = "up"
+	migrateDownCommand   = "down"
+	migrateStatusCommand = "status"
+	migrateGotoCommand   = "goto"
+	migrateForceCommand  = "force"
+)
+
+var errInvalidMigrateCommand = errors.New("invalid migrate command, must be one of [up, down N, status, goto V, force V]")
+
+// RunMigrate runs the embedded database migrations according to the command in args.
+func RunMigrate(config *config.Config, args []string) error {
+	if len(args) == 0 {
+		return errInvalidMigrateCommand
+	}
+
+	logger, _, err := newLogger(config.Log.Level)
+	if err != nil {
+		return fmt.Errorf("can't initialize zap logger: %w", err)
+	}
+	defer func() {
+		if err := logger.Sync(); err != nil {
+			log.Printf("failed to defer logger sync: %v", err)
+		}
+	}()
+
+	sugar := logger.Sugar()
+
+	// database
+	postgresClient, err := postgres.NewClient(sugar, config)
+	if err != nil {
+		return fmt.Errorf("can't initialize postgres client: %w", err)
+	}
+	defer postgresClient.DB.Close()
+
+	migrator, err := postgres.NewMigrator(sugar, postgresClient)
+	if err != nil {
+		return err
+	}
+
+	ctx := context.Background()
+
+	switch command := args[0]; command {
+	case migrateUpCommand:
+		return migrator.Up(ctx)
+	case migrateDownCommand:
+		steps, err := migrateArg(args)
+		if err != nil {
+			return err
+		}
+
+		return migrator.Down(ctx, int(steps))
+	case migrateGotoCommand:
+		version, err := migrateArg(args)
+		if err != nil {
+			return err
+		}
+
+		return migrator.Goto(ctx, version)
+	case migrateForceCommand:
+		version, err := migrateArg(args)
+		if err != nil {
+			return err
+		}
+
+		return migrator.Force(ctx, version)
+	case migrateStatusCommand:
+		status, err := migrator.Status(ctx)
+		if err != nil {
+			return err
+		}
+
+		printSchemaStatus(status)
+
+		return nil
+	default:
+		return fmt.Errorf("%w, got %q", errInvalidMigrateCommand, command)
+	}
+}
+
+func migrateArg(args []string) (int64, error) {
+	if len(args) != 2 {
+		return 0, fmt.Errorf("%w: %s expects one numeric argument", errInvalidMigrateCommand, args[0])
+	}
+
+	value, err := strconv.ParseInt(args[1], 10, 64)
+	if err != nil || value < 0 {
+		return 0, fmt.Errorf("%w: %s expects a non negative number, got %q", errInvalidMigrateCommand, args[0], args[1])
+	}
+
+	return value, nil
+}
+
+func printSchemaStatus(status postgres.SchemaStatus) {
+	fmt.Printf("version: %d (latest %d)\n", status.Version, status.Latest)
+
+	if status.Dirty {
+		fmt.Println("dirty: true, fix the schema manually and run force")
+	}
+
+	for _, migration := range status.Migrations {
+		state := "applied"
+		if migration.Version > status.Version {
+			state = "pending"
+		}
+
+		fmt.Printf("%06d_%s\t%s\n", migration.Version, migration.Name, state)
+	}
+}
@@ -0,0 +1,64 @@
+package app
+
+import (
+	"context"
+	"fmt"
+	"log"
+
+	"github.com/lyracampos/go-clean-architecture/config"
+	"github.com/lyracampos/go-clean-architecture/internal/domain"
+	"github.com/lyracampos/go-clean-architecture/internal/domain/usecases"
+	"github.com/lyracampos/go-clean-architecture/internal/gateways/postgres"
+)
+
+// RunPurge permanently deletes the users soft deleted for longer than the
+// configured retention period, and the expired idempotency keys.
+// It runs once, so it can be scheduled as a cron job.
+func RunPurge(config *config.Config) error {
+	logger, _, err := newLogger(config.Log.Level)
+	if err != nil {
+		return fmt.Errorf("can't initialize zap logger: %w", err)
+	}
+	defer func() {
+		if err := logger.Sync(); err != nil {
+			log.Printf("failed to defer logger sync: %v", err)
+		}
+	}()
+
+	sugar := logger.Sugar()
+
+	// database
+	postgresClient, err := postgres.NewClient(sugar, config)
+	if err != nil {
+		return fmt.Errorf("can't initialize postgres client: %w", err)
+	}
+	defer postgresClient.DB.Close()
+
+	userDatabaseGateway := postgres.NewUserDatabase(postgresClient)
+	idempotencyDatabaseGateway := postgres.NewIdempotencyDatabase(postgresClient)
+
+	validator := domain.NewValidatorService()
+
+	purgeUsersUseCase := usecases.NewPurgeUsersUseCase(userDatabaseGateway, validator)
+	purgeIdempotencyKeysUseCase := usecases.NewPurgeIdempotencyKeysUseCase(idempotencyDatabaseGateway)
+
+	ctx := domain.WithCaller(context.Background(), domain.SystemCaller)
+
+	purgeResult, err := purgeUsersUseCase.Execute(ctx, usecases.PurgeUsersInput{
+		Retention: config.Users.SoftDeleteRetention,
+	})
+	if err != nil {
+		return fmt.Errorf("failed to purge soft deleted users: %w", err)
+	}
+
+	sugar.Infof("purged %d soft deleted users older than %s", purgeResult.Purged, config.Users.SoftDeleteRetention)
+
+	purgeKeysResult, err := purgeIdempotencyKeysUseCase.Execute(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to purge expired idempotency keys: %w", err)
+	}
+
+	sugar.Infof("purged %d expired idempotency keys", purgeKeysResult.Purged)
+
+	return nil
+}
@@ -0,0 +1,41 @@
+package app
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/lyracampos/go-clean-architecture/config"
+	"go.uber.org/zap"
+)
+
+// watchConfig reloads the config file while the process runs, applying the log level right away.
+// The components subscribe to the other settings they can apply at runtime.
+func watchConfig(log *zap.SugaredLogger, current *config.Config, level zap.AtomicLevel) (*config.Watcher, error) {
+	watcher, err := config.NewWatcher(log, current)
+	if err != nil {
+		return nil, fmt.Errorf("can't watch config file: %w", err)
+	}
+
+	config.Subscribe(watcher, func(c *config.Config) string { return c.Log.Level }, func(logLevel string) {
+		if err := level.UnmarshalText([]byte(logLevel)); err != nil {
+			log.Errorf("config - can't apply log level: %v", err)
+			return
+		}
+
+		log.Infof("config - log level set to %s", level)
+	})
+
+	return watcher, nil
+}
+
+// subscribeAPITimeouts applies the request and health check timeouts reloaded at runtime.
+func subscribeAPITimeouts(
+	watcher *config.Watcher,
+	setTimeouts func(defaultTimeout time.Duration, routeTimeouts map[string]time.Duration),
+	setCheckTimeout func(checkTimeout time.Duration),
+) {
+	config.Subscribe(watcher, func(c *config.Config) config.API { return c.API }, func(api config.API) {
+		setTimeouts(api.RequestTimeout, api.RouteTimeouts)
+		setCheckTimeout(api.HealthCheckTimeout)
+	})
+}
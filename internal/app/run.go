@@ -0,0 +1,140 @@
+package app
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"net/http"
+	"slices"
+	"time"
+
+	"github.com/lyracampos/go-clean-architecture/config"
+	"github.com/lyracampos/go-clean-architecture/internal/gateways/postgres"
+	"github.com/lyracampos/go-clean-architecture/internal/services/api/handlers"
+	"github.com/lyracampos/go-clean-architecture/internal/telemetry"
+	"go.uber.org/zap"
+)
+
+// Components that can run together in one process.
+const (
+	APIComponent    = "api"
+	WorkerComponent = "worker"
+)
+
+// process holds what the components running in the same process share.
+type process struct {
+	config         *config.Config
+	log            *zap.SugaredLogger
+	lifecycle      *lifecycle
+	watcher        *config.Watcher
+	metrics        *telemetry.Metrics
+	postgresClient *postgres.Client
+	// healthChecks are the components reported by the API readiness probe, besides its own dependencies
+	healthChecks []handlers.HealthCheck
+}
+
+// Run runs the given components in one process until it receives SIGINT or SIGTERM, or one of them
+// fails, in which case all of them are stopped. They share the logger, the metrics, the tracing,
+// the postgres client and the config, whose file is reloaded when it changes.
+func Run(config *config.Config, components ...string) (err error) {
+	if len(components) == 0 {
+		return fmt.Errorf("at least one component must be run, among [%s, %s]", APIComponent, WorkerComponent)
+	}
+
+	for _, name := range components {
+		if name != APIComponent && name != WorkerComponent {
+			return fmt.Errorf("unknown component %q, must be one of [%s, %s]", name, APIComponent, WorkerComponent)
+		}
+	}
+
+	logger, logLevel, err := newLogger(config.Log.Level)
+	if err != nil {
+		return fmt.Errorf("can't initialize zap logger: %w", err)
+	}
+	defer func() {
+		if err := logger.Sync(); err != nil {
+			log.Printf("failed to defer logger sync: %v", err)
+		}
+	}()
+
+	sugar := logger.Sugar()
+
+	p := &process{
+		config:    config,
+		log:       sugar,
+		lifecycle: newLifecycle(sugar),
+		metrics:   telemetry.NewMetrics(),
+	}
+
+	// the shared resources are released once the components are stopped,
+	// or as soon as one of them fails to be set up
+	p.watcher, err = watchConfig(sugar, config, logLevel)
+	if err != nil {
+		return err
+	}
+	defer func() {
+		if closeErr := p.watcher.Close(); closeErr != nil {
+			err = errors.Join(err, fmt.Errorf("failed to stop config watcher: %w", closeErr))
+		}
+	}()
+
+	shutdownTracing, err := telemetry.SetupTracing(context.Background(), config.App.Name, config.Tracing)
+	if err != nil {
+		return fmt.Errorf("can't initialize tracing: %w", err)
+	}
+	defer func() {
+		ctx, cancel := context.WithTimeout(context.Background(), defaultGracePeriod)
+		defer cancel()
+
+		if shutdownErr := shutdownTracing(ctx); shutdownErr != nil {
+			err = errors.Join(err, fmt.Errorf("failed to stop tracing: %w", shutdownErr))
+		}
+	}()
+
+	// database
+	p.postgresClient, err = postgres.NewClient(sugar, config)
+	if err != nil {
+		return fmt.Errorf("can't initialize postgres client: %w", err)
+	}
+	defer func() {
+		if closeErr := p.postgresClient.DB.Close(); closeErr != nil {
+			err = errors.Join(err, fmt.Errorf("failed to stop postgres client: %w", closeErr))
+		}
+	}()
+
+	if err := p.postgresClient.RegisterMetrics(p.metrics.Registry); err != nil {
+		return fmt.Errorf("can't register postgres metrics: %w", err)
+	}
+
+	// metrics on their own port, otherwise they are served by the API router when it runs
+	if config.Metrics.Enabled && config.Metrics.Port != 0 {
+		metricsRouter := http.NewServeMux()
+		metricsRouter.Handle("/metrics", p.metrics.Handler())
+
+		metricsServer := &http.Server{
+			Addr:        fmt.Sprintf("%s:%d", config.API.Host, config.Metrics.Port),
+			ReadTimeout: time.Second * 15,
+			IdleTimeout: time.Second * 60,
+			Handler:     metricsRouter,
+		}
+
+		p.lifecycle.add(httpServerComponent("metrics server", metricsServer, config.API.ShutdownGracePeriod))
+	}
+
+	// the worker is added first, so that the API readiness probe reports its loops,
+	// and so that the API stops taking requests before the loops are stopped
+	if slices.Contains(components, WorkerComponent) {
+		if err := addWorker(p); err != nil {
+			return err
+		}
+	}
+
+	if slices.Contains(components, APIComponent) {
+		if err := addAPI(p); err != nil {
+			return err
+		}
+	}
+
+	return p.lifecycle.run(context.Background())
+}
@@ -1,19 +1,92 @@
 package app
 
 import (
+	"errors"
 	"fmt"
-	"time"
+	"slices"
 
-	"github.com/lyracampos/go-clean-architecture/config"
+	"github.com/lyracampos/go-clean-architecture/internal/domain"
+	"github.com/lyracampos/go-clean-architecture/internal/domain/ports"
+	"github.com/lyracampos/go-clean-architecture/internal/domain/usecases"
+	"github.com/lyracampos/go-clean-architecture/internal/gateways/postgres"
+	"github.com/lyracampos/go-clean-architecture/internal/gateways/publishers"
+	"github.com/lyracampos/go-clean-architecture/internal/gateways/webhooks"
+	"github.com/lyracampos/go-clean-architecture/internal/services/worker"
+	"github.com/lyracampos/go-clean-architecture/internal/telemetry"
+	"go.uber.org/zap"
 )
 
-func RunWorker(config *config.Config) {
-	keepRunning := true
-	count := 1
-	for keepRunning {
-		time.Sleep(2 * time.Second)
-		fmt.Println("...")
-		fmt.Println("worker is running...%i:", count)
-		count++
+const (
+	logPublisher     = "log"
+	webhookPublisher = "webhook"
+)
+
+var errNoEventPublishers = errors.New("at least one event publisher must be configured")
+
+// addWorker adds the loops relaying the outbox events to the configured publishers to the process,
+// along with the one posting the webhook deliveries when the webhook publisher is one of them.
+// Each loop is reported by the API readiness probe when the API runs in the same process.
+func addWorker(p *process) error {
+	config, sugar, lifecycle, metrics, postgresClient := p.config, p.log, p.lifecycle, p.metrics, p.postgresClient
+
+	outboxDatabaseGateway := postgres.NewOutboxDatabase(postgresClient)
+	webhookDatabaseGateway := postgres.NewWebhookDatabase(postgresClient)
+
+	// publishers
+	eventPublishers, err := newEventPublishers(sugar, webhookDatabaseGateway, config.Worker.Publishers)
+	if err != nil {
+		return fmt.Errorf("can't initialize event publishers: %w", err)
+	}
+
+	validator := domain.NewValidatorService()
+
+	relayEventsUseCase := telemetry.InstrumentUseCase(metrics, "RelayEvents", usecases.NewRelayEventsUseCase(outboxDatabaseGateway, eventPublishers, usecases.RetryPolicy{
+		MaxAttempts: config.Worker.MaxAttempts,
+		BaseDelay:   config.Worker.RetryBaseDelay,
+		MaxDelay:    config.Worker.RetryMaxDelay,
+	}, validator))
+
+	relay := worker.NewRelay(sugar, relayEventsUseCase, config.Worker)
+	lifecycle.add(loopComponent("outbox relay", relay.Run, config.Worker.ShutdownGracePeriod))
+	p.healthChecks = append(p.healthChecks, componentHealthCheck(lifecycle, "outbox relay"))
+
+	if slices.Contains(config.Worker.Publishers, webhookPublisher) {
+		deliverWebhooksUseCase := telemetry.InstrumentUseCase(metrics, "DeliverWebhooks", usecases.NewDeliverWebhooksUseCase(
+			webhookDatabaseGateway,
+			webhooks.NewHTTPSender(config.Webhooks.Timeout),
+			usecases.RetryPolicy{
+				MaxAttempts: config.Webhooks.MaxAttempts,
+				BaseDelay:   config.Webhooks.RetryBaseDelay,
+				MaxDelay:    config.Webhooks.RetryMaxDelay,
+			},
+			config.Webhooks.DisableAfter,
+			validator,
+		))
+
+		dispatcher := worker.NewDispatcher(sugar, deliverWebhooksUseCase, config.Worker.PollInterval, config.Webhooks)
+		lifecycle.add(loopComponent("webhook dispatcher", dispatcher.Run, config.Worker.ShutdownGracePeriod))
+		p.healthChecks = append(p.healthChecks, componentHealthCheck(lifecycle, "webhook dispatcher"))
 	}
+
+	return nil
+}
+
+func newEventPublishers(log *zap.SugaredLogger, webhookDatabase ports.WebhookDatabaseGateway, names []string) ([]ports.EventPublisher, error) {
+	eventPublishers := make([]ports.EventPublisher, 0, len(names))
+	for _, name := range names {
+		switch name {
+		case logPublisher:
+			eventPublishers = append(eventPublishers, publishers.NewLogPublisher(log))
+		case webhookPublisher:
+			eventPublishers = append(eventPublishers, publishers.NewWebhookPublisher(webhookDatabase))
+		default:
+			return nil, fmt.Errorf("unknown event publisher %q, must be one of [%s, %s]", name, logPublisher, webhookPublisher)
+		}
+	}
+
+	if len(eventPublishers) == 0 {
+		return nil, errNoEventPublishers
+	}
+
+	return eventPublishers, nil
 }
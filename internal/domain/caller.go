@@ -0,0 +1,28 @@
+package domain
+
+import "context"
+
+// Caller is the authenticated identity a request is made on behalf of.
+type Caller struct {
+	UserID int64
+	Role   string
+	// Scopes restricts the permissions of the role when not nil, such as for API keys.
+	Scopes []Permission
+}
+
+// SystemCaller is the identity of the jobs started by the operators, such as the purge.
+var SystemCaller = Caller{Role: RoleAdmin}
+
+type callerContextKey struct{}
+
+// WithCaller returns a copy of the context carrying the caller.
+func WithCaller(ctx context.Context, caller Caller) context.Context {
+	return context.WithValue(ctx, callerContextKey{}, caller)
+}
+
+// CallerFromContext returns the caller carried by the context, if any.
+func CallerFromContext(ctx context.Context) (Caller, bool) {
+	caller, ok := ctx.Value(callerContextKey{}).(Caller)
+
+	return caller, ok
+}
@@ -0,0 +1,30 @@
+package entities
+
+import "time"
+
+// APIKey authenticates service callers on behalf of the user who created it,
+// limited to its scopes. Only the hash of the key is stored, the prefix is used to find it.
+type APIKey struct {
+	ID        int64
+	UserID    int64
+	Name      string
+	Prefix    string
+	KeyHash   string
+	Scopes    []string
+	ExpiresAt *time.Time
+
+	CreatedAt  time.Time
+	LastUsedAt *time.Time
+}
+
+// NewAPIKey instantiates an API key, a nil expiry means it never expires.
+func NewAPIKey(userID int64, name, prefix, keyHash string, scopes []string, expiresAt *time.Time) *APIKey {
+	return &APIKey{
+		UserID:    userID,
+		Name:      name,
+		Prefix:    prefix,
+		KeyHash:   keyHash,
+		Scopes:    scopes,
+		ExpiresAt: expiresAt,
+	}
+}
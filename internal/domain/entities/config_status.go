@@ -0,0 +1,15 @@
+package entities
+
+import "time"
+
+// ConfigStatus tells which generation of the config reloaded at runtime is applied,
+// and why the last reload failed when it did.
+type ConfigStatus struct {
+	// Generation is 1 for the config the process started with
+	Generation uint64
+	// ReloadedAt is nil until the config is reloaded
+	ReloadedAt *time.Time
+	// LastError is empty unless the last reload failed
+	LastError   string
+	LastErrorAt *time.Time
+}
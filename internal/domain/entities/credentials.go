@@ -0,0 +1,19 @@
+package entities
+
+import "time"
+
+// Credentials holds the secret an user authenticates with, kept apart from the user profile.
+type Credentials struct {
+	UserID       int64
+	PasswordHash string
+
+	CreatedAt time.Time
+	UpdatedAt time.Time
+}
+
+func NewCredentials(userID int64, passwordHash string) *Credentials {
+	return &Credentials{
+		UserID:       userID,
+		PasswordHash: passwordHash,
+	}
+}
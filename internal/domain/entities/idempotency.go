@@ -0,0 +1,24 @@
+package entities
+
+import "time"
+
+// IdempotencyRecord is the response stored for a request made with an Idempotency-Key,
+// so that retrying the request returns it instead of running the request again.
+type IdempotencyRecord struct {
+	// Scope keeps the keys of different callers apart
+	Scope string
+	Key   string
+	// Fingerprint identifies the request the key was first used with
+	Fingerprint string
+	// Completed is false while the first request is still running
+	Completed  bool
+	StatusCode int
+	Header     map[string][]string
+	Body       []byte
+
+	CreatedAt time.Time
+	ExpiresAt time.Time
+	// LockedUntil is when an uncompleted record is considered abandoned, such as by a crashed process,
+	// and can be reserved again
+	LockedUntil time.Time
+}
@@ -0,0 +1,27 @@
+package entities
+
+import "time"
+
+// RefreshToken is a single use token exchanged for a new access token. Every rotation
+// issues a new token in the same family, so a reused token can revoke all of them.
+type RefreshToken struct {
+	ID        int64
+	UserID    int64
+	FamilyID  string
+	TokenHash string
+	ExpiresAt time.Time
+
+	CreatedAt time.Time
+	UsedAt    *time.Time
+	RevokedAt *time.Time
+}
+
+// NewRefreshToken instantiates a refresh token, an empty family starts a new one.
+func NewRefreshToken(userID int64, familyID, tokenHash string, expiresAt time.Time) *RefreshToken {
+	return &RefreshToken{
+		UserID:    userID,
+		FamilyID:  familyID,
+		TokenHash: tokenHash,
+		ExpiresAt: expiresAt,
+	}
+}
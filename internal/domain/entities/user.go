@@ -1,6 +1,12 @@
 package entities
 
-import "time"
+import (
+	"slices"
+	"time"
+)
+
+// AnyVersion matches whatever the current version of the user is, skipping the concurrency check.
+const AnyVersion int64 = 0
 
 type User struct {
 	ID        int64
@@ -11,6 +17,10 @@ type User struct {
 
 	CreatedAt time.Time
 	UpdatedAt time.Time
+	DeletedAt *time.Time
+
+	// Version is incremented on every change and used for optimistic concurrency control.
+	Version int64
 }
 
 func NewUser(firstName, lastName, email, role string) *User {
@@ -21,3 +31,8 @@ func NewUser(firstName, lastName, email, role string) *User {
 		Role:      role,
 	}
 }
+
+// MatchesVersion tells whether the user is at one of the versions a change was based on.
+func (u *User) MatchesVersion(versions []int64) bool {
+	return slices.Contains(versions, AnyVersion) || slices.Contains(versions, u.Version)
+}
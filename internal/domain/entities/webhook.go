@@ -0,0 +1,61 @@
+package entities
+
+import "time"
+
+// Webhook is a subscription of an external URL to some event types.
+// It is disabled after failing too many deliveries in a row.
+type Webhook struct {
+	ID         int64
+	URL        string
+	EventTypes []string
+	// Secret signs the deliveries, it is never returned once set
+	Secret              string
+	Enabled             bool
+	ConsecutiveFailures int
+
+	CreatedAt  time.Time
+	UpdatedAt  time.Time
+	DisabledAt *time.Time
+}
+
+func NewWebhook(url string, eventTypes []string, secret string) *Webhook {
+	return &Webhook{
+		URL:        url,
+		EventTypes: eventTypes,
+		Secret:     secret,
+		Enabled:    true,
+	}
+}
+
+// WebhookDelivery is an event waiting to be posted to a webhook,
+// along with the webhook URL and secret at the time it is claimed.
+type WebhookDelivery struct {
+	ID        int64
+	WebhookID int64
+	URL       string
+	Secret    string
+	EventID   string
+	EventType string
+	// Payload is the JSON body posted to the webhook
+	Payload  []byte
+	Attempts int
+
+	CreatedAt time.Time
+}
+
+// WebhookDeliveryAttempt is the log entry of a single try to post a delivery.
+type WebhookDeliveryAttempt struct {
+	ID         int64
+	DeliveryID int64
+	WebhookID  int64
+	EventID    string
+	EventType  string
+	Attempt    int
+	// StatusCode is zero when no response was received
+	StatusCode int
+	Error      string
+	Duration   time.Duration
+	Succeeded  bool
+
+	AttemptedAt time.Time
+}
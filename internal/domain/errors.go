@@ -10,8 +10,94 @@ import (
 var (
 	ErrUserDoesNotExist  = errors.New("user does not exist")
 	ErrEmailAlreadyInUse = errors.New("email is arealdy in use")
+	ErrVersionConflict   = errors.New("user was changed by another request")
+	ErrInvalidCursor     = errors.New("cursor is invalid")
+
+	ErrCredentialsDoNotExist = errors.New("credentials do not exist")
+	ErrInvalidCredentials    = errors.New("email or password is invalid")
+
+	ErrInvalidAccessToken       = errors.New("access token is invalid")
+	ErrInvalidRefreshToken      = errors.New("refresh token is invalid")
+	ErrRefreshTokenReused       = errors.New("refresh token was already used, its family was revoked")
+	ErrRefreshTokenDoesNotExist = errors.New("refresh token does not exist")
+	ErrRefreshTokenAlreadyUsed  = errors.New("refresh token is already used or revoked")
+
+	ErrInvalidAPIKey      = errors.New("api key is invalid")
+	ErrAPIKeyDoesNotExist = errors.New("api key does not exist")
+
+	ErrEventDoesNotExist = errors.New("event does not exist")
+	ErrEventRejected     = errors.New("event was rejected, retrying won't help")
+
+	ErrWebhookDoesNotExist         = errors.New("webhook does not exist")
+	ErrWebhookDeliveryDoesNotExist = errors.New("webhook delivery does not exist")
+
+	ErrIdempotencyKeyDoesNotExist = errors.New("idempotency key does not exist")
+	ErrIdempotencyKeyReused       = errors.New("idempotency key was already used with another request")
+	ErrIdempotencyKeyInProgress   = errors.New("request with the same idempotency key is still in progress")
+
+	ErrUniqueViolation      = errors.New("unique constraint violated")
+	ErrForeignKeyViolation  = errors.New("foreign key constraint violated")
+	ErrCheckViolation       = errors.New("check constraint violated")
+	ErrNotNullViolation     = errors.New("not null constraint violated")
+	ErrSerializationFailure = errors.New("could not serialize access due to concurrent update")
+	ErrDeadlock             = errors.New("deadlock detected")
+	ErrDeadlineExceeded     = errors.New("operation deadline exceeded")
+	ErrCanceled             = errors.New("operation was canceled")
 )
 
+// ConstraintError is thrown when a storage constraint rejected a change.
+type ConstraintError struct {
+	kind       error
+	Constraint string
+	Column     string
+	cause      error
+}
+
+// NewConstraintError instantiates a constraint error of the given kind, such as ErrUniqueViolation.
+func NewConstraintError(kind error, constraint, column string, cause error) error {
+	return &ConstraintError{
+		kind:       kind,
+		Constraint: constraint,
+		Column:     column,
+		cause:      cause,
+	}
+}
+
+// Error is the implementation of Error interface.
+func (c *ConstraintError) Error() string {
+	return fmt.Sprintf("%s: %s", c.kind.Error(), c.cause.Error())
+}
+
+// Unwrap matches both the kind of violation and the original cause.
+func (c *ConstraintError) Unwrap() []error {
+	return []error{c.kind, c.cause}
+}
+
+// TransientError is thrown when an operation failed due to concurrent operations
+// and may succeed if it is retried.
+type TransientError struct {
+	kind  error
+	cause error
+}
+
+// NewTransientError instantiates a transient error of the given kind, such as ErrDeadlock.
+func NewTransientError(kind error, cause error) error {
+	return &TransientError{
+		kind:  kind,
+		cause: cause,
+	}
+}
+
+// Error is the implementation of Error interface.
+func (t *TransientError) Error() string {
+	return fmt.Sprintf("%s: %s", t.kind.Error(), t.cause.Error())
+}
+
+// Unwrap matches both the kind of failure and the original cause.
+func (t *TransientError) Unwrap() []error {
+	return []error{t.kind, t.cause}
+}
+
 // ValidationError is thrown when a validation error happened.
 type ValidationError struct {
 	validationErrors []FieldError
@@ -51,7 +137,7 @@ func NewFieldError(fieldError validator.FieldError) FieldError {
 
 func (f *FieldError) Error() string {
 	switch f.FieldError.Tag() {
-	case "required":
+	case "required", "required_if":
 		return fmt.Sprintf("the field '%s' should not be empty", f.FieldError.Field())
 	case "min", "max", "maxBytes":
 		return fmt.Sprintf("the field '%s' %s size is %s", f.FieldError.Field(), f.FieldError.Tag(), f.FieldError.Param())
@@ -59,6 +145,8 @@ func (f *FieldError) Error() string {
 		return fmt.Sprintf("the field '%s' is not valid, expected one of [%s]", f.FieldError.Field(), f.FieldError.Param())
 	case "uuid":
 		return fmt.Sprintf("the field '%s' is not a valid UUID", f.FieldError.Field())
+	case "notcommon":
+		return fmt.Sprintf("the field '%s' is too common, choose a less predictable one", f.FieldError.Field())
 	default:
 		return fmt.Sprintf("the field '%s' is invalid", f.FieldError.Field())
 	}
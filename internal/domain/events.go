@@ -0,0 +1,107 @@
+package domain
+
+import (
+	"strconv"
+	"time"
+
+	"github.com/lyracampos/go-clean-architecture/internal/domain/entities"
+)
+
+// EventType names what happened, consumers dispatch on it.
+type EventType string
+
+const (
+	EventUserCreated     EventType = "user.created"
+	EventUserUpdated     EventType = "user.updated"
+	EventUserDeleted     EventType = "user.deleted"
+	EventUserRoleChanged EventType = "user.role_changed"
+)
+
+// UserAggregate is the aggregate type of the user events.
+const UserAggregate = "user"
+
+// UserEventsSchemaVersion is the version of the user event payloads,
+// it is bumped on changes consumers can't ignore, such as a removed field.
+const UserEventsSchemaVersion = 1
+
+// Event is a fact about an aggregate that other systems are notified of.
+type Event struct {
+	// ID is unique per event, consumers use it to discard duplicates
+	ID            string
+	AggregateType string
+	AggregateID   string
+	Type          EventType
+	SchemaVersion int
+	// OccurredAt is set by the storage when zero, to the time of the change
+	OccurredAt time.Time
+	Payload    any
+}
+
+// UserPayload is the state of the user after the change.
+type UserPayload struct {
+	ID        int64  `json:"id"`
+	FirstName string `json:"first_name"`
+	LastName  string `json:"last_name"`
+	Email     string `json:"email"`
+	Role      string `json:"role"`
+	Version   int64  `json:"version"`
+}
+
+// UserDeletedPayload identifies the deleted user.
+type UserDeletedPayload struct {
+	ID      int64 `json:"id"`
+	Version int64 `json:"version"`
+}
+
+// UserRoleChangedPayload is the role of the user before and after the change.
+type UserRoleChangedPayload struct {
+	ID           int64  `json:"id"`
+	PreviousRole string `json:"previous_role"`
+	Role         string `json:"role"`
+	Version      int64  `json:"version"`
+}
+
+func NewUserCreatedEvent(user *entities.User) Event {
+	return newUserEvent(EventUserCreated, user.ID, newUserPayload(user))
+}
+
+func NewUserUpdatedEvent(user *entities.User) Event {
+	return newUserEvent(EventUserUpdated, user.ID, newUserPayload(user))
+}
+
+func NewUserDeletedEvent(id, version int64) Event {
+	return newUserEvent(EventUserDeleted, id, UserDeletedPayload{
+		ID:      id,
+		Version: version,
+	})
+}
+
+func NewUserRoleChangedEvent(user *entities.User, previousRole string) Event {
+	return newUserEvent(EventUserRoleChanged, user.ID, UserRoleChangedPayload{
+		ID:           user.ID,
+		PreviousRole: previousRole,
+		Role:         user.Role,
+		Version:      user.Version,
+	})
+}
+
+func newUserEvent(eventType EventType, userID int64, payload any) Event {
+	return Event{
+		AggregateType: UserAggregate,
+		AggregateID:   strconv.FormatInt(userID, 10),
+		Type:          eventType,
+		SchemaVersion: UserEventsSchemaVersion,
+		Payload:       payload,
+	}
+}
+
+func newUserPayload(user *entities.User) UserPayload {
+	return UserPayload{
+		ID:        user.ID,
+		FirstName: user.FirstName,
+		LastName:  user.LastName,
+		Email:     user.Email,
+		Role:      user.Role,
+		Version:   user.Version,
+	}
+}
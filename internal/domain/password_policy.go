@@ -0,0 +1,52 @@
+package domain
+
+import (
+	_ "embed"
+	"strconv"
+	"strings"
+
+	"github.com/go-playground/validator/v10"
+)
+
+//go:embed common_passwords.txt
+var commonPasswordList string
+
+// commonPasswords is the deny list of passwords found in leaked password dumps, lower cased.
+var commonPasswords = parseCommonPasswords(commonPasswordList)
+
+func parseCommonPasswords(list string) map[string]struct{} {
+	passwords := map[string]struct{}{}
+	for _, line := range strings.Split(list, "\n") {
+		password := strings.ToLower(strings.TrimSpace(line))
+		if password == "" || strings.HasPrefix(password, "#") {
+			continue
+		}
+
+		passwords[password] = struct{}{}
+	}
+
+	return passwords
+}
+
+// IsCommonPassword reports whether the password is in the deny list, ignoring case.
+func IsCommonPassword(password string) bool {
+	_, ok := commonPasswords[strings.ToLower(password)]
+
+	return ok
+}
+
+// validateNotCommon is the "notcommon" rule, it rejects passwords from the deny list.
+func validateNotCommon(fl validator.FieldLevel) bool {
+	return !IsCommonPassword(fl.Field().String())
+}
+
+// validateMaxBytes is the "maxBytes" rule, it limits the length in bytes instead of characters,
+// as password hashes such as bcrypt only take the first 72 bytes into account.
+func validateMaxBytes(fl validator.FieldLevel) bool {
+	limit, err := strconv.Atoi(fl.Param())
+	if err != nil {
+		return false
+	}
+
+	return len(fl.Field().String()) <= limit
+}
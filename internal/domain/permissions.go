@@ -0,0 +1,100 @@
+package domain
+
+import (
+	"context"
+	"errors"
+	"slices"
+)
+
+var (
+	ErrUnauthenticated = errors.New("caller is not authenticated")
+	ErrForbidden       = errors.New("caller is not allowed to perform this operation")
+)
+
+// Roles an user can have.
+const (
+	RoleAdmin       = "admin"
+	RoleContributor = "contributor"
+)
+
+// Permission is an action callers may perform on users.
+type Permission string
+
+const (
+	PermissionUsersRead       Permission = "users:read"
+	PermissionUsersCreate     Permission = "users:create"
+	PermissionUsersWrite      Permission = "users:write"
+	PermissionUsersDelete     Permission = "users:delete"
+	PermissionUsersChangeRole Permission = "users:change_role"
+	PermissionAPIKeysManage   Permission = "api_keys:manage"
+	PermissionWebhooksManage  Permission = "webhooks:manage"
+	PermissionConfigRead      Permission = "config:read"
+)
+
+// Scope limits on which users a permission applies.
+type Scope int
+
+const (
+	// ScopeOwn grants the permission only on the caller's own user.
+	ScopeOwn Scope = iota + 1
+	// ScopeAny grants the permission on every user.
+	ScopeAny
+)
+
+// rolePermissions maps each role to the permissions it grants and their scope.
+var rolePermissions = map[string]map[Permission]Scope{
+	RoleAdmin: {
+		PermissionUsersRead:       ScopeAny,
+		PermissionUsersCreate:     ScopeAny,
+		PermissionUsersWrite:      ScopeAny,
+		PermissionUsersDelete:     ScopeAny,
+		PermissionUsersChangeRole: ScopeAny,
+		PermissionAPIKeysManage:   ScopeAny,
+		PermissionWebhooksManage:  ScopeAny,
+		PermissionConfigRead:      ScopeAny,
+	},
+	RoleContributor: {
+		PermissionUsersRead:  ScopeOwn,
+		PermissionUsersWrite: ScopeOwn,
+	},
+}
+
+// Scope returns how far the caller's role grants the permission, zero when it doesn't
+// or when the caller is restricted to scopes that don't include it.
+func (c Caller) Scope(permission Permission) Scope {
+	if c.Scopes != nil && !slices.Contains(c.Scopes, permission) {
+		return 0
+	}
+
+	return rolePermissions[c.Role][permission]
+}
+
+// RequirePermission returns the caller from the context and the scope it has the permission on,
+// failing with ErrUnauthenticated when there is no caller or ErrForbidden when it lacks the permission.
+func RequirePermission(ctx context.Context, permission Permission) (Caller, Scope, error) {
+	caller, ok := CallerFromContext(ctx)
+	if !ok {
+		return Caller{}, 0, ErrUnauthenticated
+	}
+
+	scope := caller.Scope(permission)
+	if scope == 0 {
+		return Caller{}, 0, ErrForbidden
+	}
+
+	return caller, scope, nil
+}
+
+// Authorize checks the caller from the context has the permission on the given user.
+func Authorize(ctx context.Context, permission Permission, userID int64) error {
+	caller, scope, err := RequirePermission(ctx, permission)
+	if err != nil {
+		return err
+	}
+
+	if scope == ScopeOwn && caller.UserID != userID {
+		return ErrForbidden
+	}
+
+	return nil
+}
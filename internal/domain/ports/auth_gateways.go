@@ -0,0 +1,76 @@
+package ports
+
+import (
+	"context"
+	"time"
+
+	"github.com/lyracampos/go-clean-architecture/internal/domain"
+	"github.com/lyracampos/go-clean-architecture/internal/domain/entities"
+)
+
+type CredentialsDatabaseGateway interface {
+	GetCredentials(ctx context.Context, userID int64) (*entities.Credentials, error)
+	UpsertCredentials(ctx context.Context, credentials *entities.Credentials) (*entities.Credentials, error)
+}
+
+type RefreshTokenDatabaseGateway interface {
+	InsertRefreshToken(ctx context.Context, token *entities.RefreshToken) (*entities.RefreshToken, error)
+	GetRefreshToken(ctx context.Context, tokenHash string) (*entities.RefreshToken, error)
+	// RotateRefreshToken marks the used token and stores its successor atomically,
+	// failing with domain.ErrRefreshTokenAlreadyUsed if it was used or revoked meanwhile.
+	RotateRefreshToken(ctx context.Context, usedID int64, next *entities.RefreshToken) (*entities.RefreshToken, error)
+	RevokeRefreshTokenFamily(ctx context.Context, familyID string) error
+	// RevokeUserRefreshTokens revokes every refresh token of the user, such as when its password changes.
+	RevokeUserRefreshTokens(ctx context.Context, userID int64) error
+}
+
+type APIKeyDatabaseGateway interface {
+	InsertAPIKey(ctx context.Context, key *entities.APIKey) (*entities.APIKey, error)
+	ListAPIKeys(ctx context.Context) ([]*entities.APIKey, error)
+	GetAPIKey(ctx context.Context, prefix string) (*entities.APIKey, error)
+	DeleteAPIKey(ctx context.Context, id int64) error
+	// TouchAPIKey records the last time the key authenticated a request.
+	TouchAPIKey(ctx context.Context, id int64, usedAt time.Time) error
+}
+
+type PasswordHasher interface {
+	Hash(password string) (string, error)
+	// Compare fails when the password does not match the hash. Comparing against an empty
+	// hash takes as long as a real comparison, so callers can hide whether an account exists.
+	Compare(hash, password string) error
+}
+
+type TokenService interface {
+	IssueAccessToken(ctx context.Context, user *entities.User) (AccessToken, error)
+	// ParseAccessToken verifies the token and returns whom it was issued to,
+	// failing with domain.ErrInvalidAccessToken.
+	ParseAccessToken(ctx context.Context, token string) (domain.Caller, error)
+	IssueRefreshToken(ctx context.Context) (RefreshToken, error)
+	HashRefreshToken(token string) string
+}
+
+type AccessToken struct {
+	Token     string
+	ExpiresAt time.Time
+}
+
+// RefreshToken is an opaque token, only its hash is meant to be stored.
+type RefreshToken struct {
+	Token     string
+	Hash      string
+	ExpiresAt time.Time
+}
+
+type APIKeyService interface {
+	IssueAPIKey(ctx context.Context) (APIKey, error)
+	// ParseAPIKey returns the lookup prefix and the hash of the key,
+	// failing with domain.ErrInvalidAPIKey when it is malformed.
+	ParseAPIKey(key string) (prefix string, hash string, err error)
+}
+
+// APIKey is shown once to its creator, only its prefix and hash are meant to be stored.
+type APIKey struct {
+	Key    string
+	Prefix string
+	Hash   string
+}
@@ -0,0 +1,12 @@
+package ports
+
+import (
+	"context"
+
+	"github.com/lyracampos/go-clean-architecture/internal/domain/entities"
+)
+
+type ConfigStatusGateway interface {
+	// ConfigStatus returns how the reloads of the config went so far.
+	ConfigStatus(ctx context.Context) (*entities.ConfigStatus, error)
+}
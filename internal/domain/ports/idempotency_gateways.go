@@ -0,0 +1,21 @@
+package ports
+
+import (
+	"context"
+	"time"
+
+	"github.com/lyracampos/go-clean-architecture/internal/domain/entities"
+)
+
+type IdempotencyDatabaseGateway interface {
+	// ReserveIdempotencyKey stores the uncompleted record unless an unexpired record has the same scope and key,
+	// in which case that record is returned and the bool is false. Uncompleted records past their lock don't count.
+	ReserveIdempotencyKey(ctx context.Context, record *entities.IdempotencyRecord) (*entities.IdempotencyRecord, bool, error)
+	// CompleteIdempotencyKey stores the response of the record reserved at its CreatedAt, and fails with
+	// ErrIdempotencyKeyDoesNotExist when that reservation was completed, released or replaced meanwhile.
+	CompleteIdempotencyKey(ctx context.Context, record *entities.IdempotencyRecord) error
+	// ReleaseIdempotencyKey deletes the uncompleted record reserved at reservedAt, so that the key can be used again.
+	ReleaseIdempotencyKey(ctx context.Context, scope, key string, reservedAt time.Time) error
+	// PurgeIdempotencyKeys deletes the records expired before the given time.
+	PurgeIdempotencyKeys(ctx context.Context, expiredBefore time.Time) (int64, error)
+}
@@ -0,0 +1,454 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: ./internal/domain/ports/auth_gateways.go
+//
+// Generated by this command:
+//
+//	mockgen -source=./internal/domain/ports/auth_gateways.go -destination=./internal/domain/ports/mocks/auth_gateways_mock.go -package=mock
+//
+
+// Package mock is a generated GoMock package.
+package mock
+
+import (
+	context "context"
+	reflect "reflect"
+	time "time"
+
+	domain "github.com/lyracampos/go-clean-architecture/internal/domain"
+	entities "github.com/lyracampos/go-clean-architecture/internal/domain/entities"
+	ports "github.com/lyracampos/go-clean-architecture/internal/domain/ports"
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockCredentialsDatabaseGateway is a mock of CredentialsDatabaseGateway interface.
+type MockCredentialsDatabaseGateway struct {
+	ctrl     *gomock.Controller
+	recorder *MockCredentialsDatabaseGatewayMockRecorder
+}
+
+// MockCredentialsDatabaseGatewayMockRecorder is the mock recorder for MockCredentialsDatabaseGateway.
+type MockCredentialsDatabaseGatewayMockRecorder struct {
+	mock *MockCredentialsDatabaseGateway
+}
+
+// NewMockCredentialsDatabaseGateway creates a new mock instance.
+func NewMockCredentialsDatabaseGateway(ctrl *gomock.Controller) *MockCredentialsDatabaseGateway {
+	mock := &MockCredentialsDatabaseGateway{ctrl: ctrl}
+	mock.recorder = &MockCredentialsDatabaseGatewayMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockCredentialsDatabaseGateway) EXPECT() *MockCredentialsDatabaseGatewayMockRecorder {
+	return m.recorder
+}
+
+// GetCredentials mocks base method.
+func (m *MockCredentialsDatabaseGateway) GetCredentials(ctx context.Context, userID int64) (*entities.Credentials, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetCredentials", ctx, userID)
+	ret0, _ := ret[0].(*entities.Credentials)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetCredentials indicates an expected call of GetCredentials.
+func (mr *MockCredentialsDatabaseGatewayMockRecorder) GetCredentials(ctx, userID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetCredentials", reflect.TypeOf((*MockCredentialsDatabaseGateway)(nil).GetCredentials), ctx, userID)
+}
+
+// UpsertCredentials mocks base method.
+func (m *MockCredentialsDatabaseGateway) UpsertCredentials(ctx context.Context, credentials *entities.Credentials) (*entities.Credentials, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpsertCredentials", ctx, credentials)
+	ret0, _ := ret[0].(*entities.Credentials)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// UpsertCredentials indicates an expected call of UpsertCredentials.
+func (mr *MockCredentialsDatabaseGatewayMockRecorder) UpsertCredentials(ctx, credentials any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpsertCredentials", reflect.TypeOf((*MockCredentialsDatabaseGateway)(nil).UpsertCredentials), ctx, credentials)
+}
+
+// MockRefreshTokenDatabaseGateway is a mock of RefreshTokenDatabaseGateway interface.
+type MockRefreshTokenDatabaseGateway struct {
+	ctrl     *gomock.Controller
+	recorder *MockRefreshTokenDatabaseGatewayMockRecorder
+}
+
+// MockRefreshTokenDatabaseGatewayMockRecorder is the mock recorder for MockRefreshTokenDatabaseGateway.
+type MockRefreshTokenDatabaseGatewayMockRecorder struct {
+	mock *MockRefreshTokenDatabaseGateway
+}
+
+// NewMockRefreshTokenDatabaseGateway creates a new mock instance.
+func NewMockRefreshTokenDatabaseGateway(ctrl *gomock.Controller) *MockRefreshTokenDatabaseGateway {
+	mock := &MockRefreshTokenDatabaseGateway{ctrl: ctrl}
+	mock.recorder = &MockRefreshTokenDatabaseGatewayMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockRefreshTokenDatabaseGateway) EXPECT() *MockRefreshTokenDatabaseGatewayMockRecorder {
+	return m.recorder
+}
+
+// GetRefreshToken mocks base method.
+func (m *MockRefreshTokenDatabaseGateway) GetRefreshToken(ctx context.Context, tokenHash string) (*entities.RefreshToken, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetRefreshToken", ctx, tokenHash)
+	ret0, _ := ret[0].(*entities.RefreshToken)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetRefreshToken indicates an expected call of GetRefreshToken.
+func (mr *MockRefreshTokenDatabaseGatewayMockRecorder) GetRefreshToken(ctx, tokenHash any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetRefreshToken", reflect.TypeOf((*MockRefreshTokenDatabaseGateway)(nil).GetRefreshToken), ctx, tokenHash)
+}
+
+// InsertRefreshToken mocks base method.
+func (m *MockRefreshTokenDatabaseGateway) InsertRefreshToken(ctx context.Context, token *entities.RefreshToken) (*entities.RefreshToken, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "InsertRefreshToken", ctx, token)
+	ret0, _ := ret[0].(*entities.RefreshToken)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// InsertRefreshToken indicates an expected call of InsertRefreshToken.
+func (mr *MockRefreshTokenDatabaseGatewayMockRecorder) InsertRefreshToken(ctx, token any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "InsertRefreshToken", reflect.TypeOf((*MockRefreshTokenDatabaseGateway)(nil).InsertRefreshToken), ctx, token)
+}
+
+// RevokeRefreshTokenFamily mocks base method.
+func (m *MockRefreshTokenDatabaseGateway) RevokeRefreshTokenFamily(ctx context.Context, familyID string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RevokeRefreshTokenFamily", ctx, familyID)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// RevokeRefreshTokenFamily indicates an expected call of RevokeRefreshTokenFamily.
+func (mr *MockRefreshTokenDatabaseGatewayMockRecorder) RevokeRefreshTokenFamily(ctx, familyID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RevokeRefreshTokenFamily", reflect.TypeOf((*MockRefreshTokenDatabaseGateway)(nil).RevokeRefreshTokenFamily), ctx, familyID)
+}
+
+// RevokeUserRefreshTokens mocks base method.
+func (m *MockRefreshTokenDatabaseGateway) RevokeUserRefreshTokens(ctx context.Context, userID int64) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RevokeUserRefreshTokens", ctx, userID)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// RevokeUserRefreshTokens indicates an expected call of RevokeUserRefreshTokens.
+func (mr *MockRefreshTokenDatabaseGatewayMockRecorder) RevokeUserRefreshTokens(ctx, userID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RevokeUserRefreshTokens", reflect.TypeOf((*MockRefreshTokenDatabaseGateway)(nil).RevokeUserRefreshTokens), ctx, userID)
+}
+
+// RotateRefreshToken mocks base method.
+func (m *MockRefreshTokenDatabaseGateway) RotateRefreshToken(ctx context.Context, usedID int64, next *entities.RefreshToken) (*entities.RefreshToken, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RotateRefreshToken", ctx, usedID, next)
+	ret0, _ := ret[0].(*entities.RefreshToken)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// RotateRefreshToken indicates an expected call of RotateRefreshToken.
+func (mr *MockRefreshTokenDatabaseGatewayMockRecorder) RotateRefreshToken(ctx, usedID, next any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RotateRefreshToken", reflect.TypeOf((*MockRefreshTokenDatabaseGateway)(nil).RotateRefreshToken), ctx, usedID, next)
+}
+
+// MockAPIKeyDatabaseGateway is a mock of APIKeyDatabaseGateway interface.
+type MockAPIKeyDatabaseGateway struct {
+	ctrl     *gomock.Controller
+	recorder *MockAPIKeyDatabaseGatewayMockRecorder
+}
+
+// MockAPIKeyDatabaseGatewayMockRecorder is the mock recorder for MockAPIKeyDatabaseGateway.
+type MockAPIKeyDatabaseGatewayMockRecorder struct {
+	mock *MockAPIKeyDatabaseGateway
+}
+
+// NewMockAPIKeyDatabaseGateway creates a new mock instance.
+func NewMockAPIKeyDatabaseGateway(ctrl *gomock.Controller) *MockAPIKeyDatabaseGateway {
+	mock := &MockAPIKeyDatabaseGateway{ctrl: ctrl}
+	mock.recorder = &MockAPIKeyDatabaseGatewayMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockAPIKeyDatabaseGateway) EXPECT() *MockAPIKeyDatabaseGatewayMockRecorder {
+	return m.recorder
+}
+
+// DeleteAPIKey mocks base method.
+func (m *MockAPIKeyDatabaseGateway) DeleteAPIKey(ctx context.Context, id int64) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteAPIKey", ctx, id)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteAPIKey indicates an expected call of DeleteAPIKey.
+func (mr *MockAPIKeyDatabaseGatewayMockRecorder) DeleteAPIKey(ctx, id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteAPIKey", reflect.TypeOf((*MockAPIKeyDatabaseGateway)(nil).DeleteAPIKey), ctx, id)
+}
+
+// GetAPIKey mocks base method.
+func (m *MockAPIKeyDatabaseGateway) GetAPIKey(ctx context.Context, prefix string) (*entities.APIKey, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetAPIKey", ctx, prefix)
+	ret0, _ := ret[0].(*entities.APIKey)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetAPIKey indicates an expected call of GetAPIKey.
+func (mr *MockAPIKeyDatabaseGatewayMockRecorder) GetAPIKey(ctx, prefix any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetAPIKey", reflect.TypeOf((*MockAPIKeyDatabaseGateway)(nil).GetAPIKey), ctx, prefix)
+}
+
+// InsertAPIKey mocks base method.
+func (m *MockAPIKeyDatabaseGateway) InsertAPIKey(ctx context.Context, key *entities.APIKey) (*entities.APIKey, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "InsertAPIKey", ctx, key)
+	ret0, _ := ret[0].(*entities.APIKey)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// InsertAPIKey indicates an expected call of InsertAPIKey.
+func (mr *MockAPIKeyDatabaseGatewayMockRecorder) InsertAPIKey(ctx, key any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "InsertAPIKey", reflect.TypeOf((*MockAPIKeyDatabaseGateway)(nil).InsertAPIKey), ctx, key)
+}
+
+// ListAPIKeys mocks base method.
+func (m *MockAPIKeyDatabaseGateway) ListAPIKeys(ctx context.Context) ([]*entities.APIKey, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListAPIKeys", ctx)
+	ret0, _ := ret[0].([]*entities.APIKey)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListAPIKeys indicates an expected call of ListAPIKeys.
+func (mr *MockAPIKeyDatabaseGatewayMockRecorder) ListAPIKeys(ctx any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListAPIKeys", reflect.TypeOf((*MockAPIKeyDatabaseGateway)(nil).ListAPIKeys), ctx)
+}
+
+// TouchAPIKey mocks base method.
+func (m *MockAPIKeyDatabaseGateway) TouchAPIKey(ctx context.Context, id int64, usedAt time.Time) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "TouchAPIKey", ctx, id, usedAt)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// TouchAPIKey indicates an expected call of TouchAPIKey.
+func (mr *MockAPIKeyDatabaseGatewayMockRecorder) TouchAPIKey(ctx, id, usedAt any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "TouchAPIKey", reflect.TypeOf((*MockAPIKeyDatabaseGateway)(nil).TouchAPIKey), ctx, id, usedAt)
+}
+
+// MockPasswordHasher is a mock of PasswordHasher interface.
+type MockPasswordHasher struct {
+	ctrl     *gomock.Controller
+	recorder *MockPasswordHasherMockRecorder
+}
+
+// MockPasswordHasherMockRecorder is the mock recorder for MockPasswordHasher.
+type MockPasswordHasherMockRecorder struct {
+	mock *MockPasswordHasher
+}
+
+// NewMockPasswordHasher creates a new mock instance.
+func NewMockPasswordHasher(ctrl *gomock.Controller) *MockPasswordHasher {
+	mock := &MockPasswordHasher{ctrl: ctrl}
+	mock.recorder = &MockPasswordHasherMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockPasswordHasher) EXPECT() *MockPasswordHasherMockRecorder {
+	return m.recorder
+}
+
+// Compare mocks base method.
+func (m *MockPasswordHasher) Compare(hash, password string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Compare", hash, password)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Compare indicates an expected call of Compare.
+func (mr *MockPasswordHasherMockRecorder) Compare(hash, password any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Compare", reflect.TypeOf((*MockPasswordHasher)(nil).Compare), hash, password)
+}
+
+// Hash mocks base method.
+func (m *MockPasswordHasher) Hash(password string) (string, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Hash", password)
+	ret0, _ := ret[0].(string)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Hash indicates an expected call of Hash.
+func (mr *MockPasswordHasherMockRecorder) Hash(password any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Hash", reflect.TypeOf((*MockPasswordHasher)(nil).Hash), password)
+}
+
+// MockTokenService is a mock of TokenService interface.
+type MockTokenService struct {
+	ctrl     *gomock.Controller
+	recorder *MockTokenServiceMockRecorder
+}
+
+// MockTokenServiceMockRecorder is the mock recorder for MockTokenService.
+type MockTokenServiceMockRecorder struct {
+	mock *MockTokenService
+}
+
+// NewMockTokenService creates a new mock instance.
+func NewMockTokenService(ctrl *gomock.Controller) *MockTokenService {
+	mock := &MockTokenService{ctrl: ctrl}
+	mock.recorder = &MockTokenServiceMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockTokenService) EXPECT() *MockTokenServiceMockRecorder {
+	return m.recorder
+}
+
+// HashRefreshToken mocks base method.
+func (m *MockTokenService) HashRefreshToken(token string) string {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "HashRefreshToken", token)
+	ret0, _ := ret[0].(string)
+	return ret0
+}
+
+// HashRefreshToken indicates an expected call of HashRefreshToken.
+func (mr *MockTokenServiceMockRecorder) HashRefreshToken(token any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "HashRefreshToken", reflect.TypeOf((*MockTokenService)(nil).HashRefreshToken), token)
+}
+
+// IssueAccessToken mocks base method.
+func (m *MockTokenService) IssueAccessToken(ctx context.Context, user *entities.User) (ports.AccessToken, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "IssueAccessToken", ctx, user)
+	ret0, _ := ret[0].(ports.AccessToken)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// IssueAccessToken indicates an expected call of IssueAccessToken.
+func (mr *MockTokenServiceMockRecorder) IssueAccessToken(ctx, user any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "IssueAccessToken", reflect.TypeOf((*MockTokenService)(nil).IssueAccessToken), ctx, user)
+}
+
+// IssueRefreshToken mocks base method.
+func (m *MockTokenService) IssueRefreshToken(ctx context.Context) (ports.RefreshToken, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "IssueRefreshToken", ctx)
+	ret0, _ := ret[0].(ports.RefreshToken)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// IssueRefreshToken indicates an expected call of IssueRefreshToken.
+func (mr *MockTokenServiceMockRecorder) IssueRefreshToken(ctx any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "IssueRefreshToken", reflect.TypeOf((*MockTokenService)(nil).IssueRefreshToken), ctx)
+}
+
+// ParseAccessToken mocks base method.
+func (m *MockTokenService) ParseAccessToken(ctx context.Context, token string) (domain.Caller, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ParseAccessToken", ctx, token)
+	ret0, _ := ret[0].(domain.Caller)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ParseAccessToken indicates an expected call of ParseAccessToken.
+func (mr *MockTokenServiceMockRecorder) ParseAccessToken(ctx, token any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ParseAccessToken", reflect.TypeOf((*MockTokenService)(nil).ParseAccessToken), ctx, token)
+}
+
+// MockAPIKeyService is a mock of APIKeyService interface.
+type MockAPIKeyService struct {
+	ctrl     *gomock.Controller
+	recorder *MockAPIKeyServiceMockRecorder
+}
+
+// MockAPIKeyServiceMockRecorder is the mock recorder for MockAPIKeyService.
+type MockAPIKeyServiceMockRecorder struct {
+	mock *MockAPIKeyService
+}
+
+// NewMockAPIKeyService creates a new mock instance.
+func NewMockAPIKeyService(ctrl *gomock.Controller) *MockAPIKeyService {
+	mock := &MockAPIKeyService{ctrl: ctrl}
+	mock.recorder = &MockAPIKeyServiceMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockAPIKeyService) EXPECT() *MockAPIKeyServiceMockRecorder {
+	return m.recorder
+}
+
+// IssueAPIKey mocks base method.
+func (m *MockAPIKeyService) IssueAPIKey(ctx context.Context) (ports.APIKey, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "IssueAPIKey", ctx)
+	ret0, _ := ret[0].(ports.APIKey)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// IssueAPIKey indicates an expected call of IssueAPIKey.
+func (mr *MockAPIKeyServiceMockRecorder) IssueAPIKey(ctx any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "IssueAPIKey", reflect.TypeOf((*MockAPIKeyService)(nil).IssueAPIKey), ctx)
+}
+
+// ParseAPIKey mocks base method.
+func (m *MockAPIKeyService) ParseAPIKey(key string) (string, string, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ParseAPIKey", key)
+	ret0, _ := ret[0].(string)
+	ret1, _ := ret[1].(string)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// ParseAPIKey indicates an expected call of ParseAPIKey.
+func (mr *MockAPIKeyServiceMockRecorder) ParseAPIKey(key any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ParseAPIKey", reflect.TypeOf((*MockAPIKeyService)(nil).ParseAPIKey), key)
+}
@@ -0,0 +1,56 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: ./internal/domain/ports/config_gateways.go
+//
+// Generated by this command:
+//
+//	mockgen -source=./internal/domain/ports/config_gateways.go -destination=./internal/domain/ports/mocks/config_gateways_mock.go -package=mock
+//
+
+// Package mock is a generated GoMock package.
+package mock
+
+import (
+	context "context"
+	reflect "reflect"
+
+	entities "github.com/lyracampos/go-clean-architecture/internal/domain/entities"
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockConfigStatusGateway is a mock of ConfigStatusGateway interface.
+type MockConfigStatusGateway struct {
+	ctrl     *gomock.Controller
+	recorder *MockConfigStatusGatewayMockRecorder
+}
+
+// MockConfigStatusGatewayMockRecorder is the mock recorder for MockConfigStatusGateway.
+type MockConfigStatusGatewayMockRecorder struct {
+	mock *MockConfigStatusGateway
+}
+
+// NewMockConfigStatusGateway creates a new mock instance.
+func NewMockConfigStatusGateway(ctrl *gomock.Controller) *MockConfigStatusGateway {
+	mock := &MockConfigStatusGateway{ctrl: ctrl}
+	mock.recorder = &MockConfigStatusGatewayMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockConfigStatusGateway) EXPECT() *MockConfigStatusGatewayMockRecorder {
+	return m.recorder
+}
+
+// ConfigStatus mocks base method.
+func (m *MockConfigStatusGateway) ConfigStatus(ctx context.Context) (*entities.ConfigStatus, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ConfigStatus", ctx)
+	ret0, _ := ret[0].(*entities.ConfigStatus)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ConfigStatus indicates an expected call of ConfigStatus.
+func (mr *MockConfigStatusGatewayMockRecorder) ConfigStatus(ctx any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ConfigStatus", reflect.TypeOf((*MockConfigStatusGateway)(nil).ConfigStatus), ctx)
+}
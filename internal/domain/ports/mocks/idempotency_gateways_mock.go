@@ -0,0 +1,101 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: ./internal/domain/ports/idempotency_gateways.go
+//
+// Generated by this command:
+//
+//	mockgen -source=./internal/domain/ports/idempotency_gateways.go -destination=./internal/domain/ports/mocks/idempotency_gateways_mock.go -package=mock
+//
+
+// Package mock is a generated GoMock package.
+package mock
+
+import (
+	context "context"
+	reflect "reflect"
+	time "time"
+
+	entities "github.com/lyracampos/go-clean-architecture/internal/domain/entities"
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockIdempotencyDatabaseGateway is a mock of IdempotencyDatabaseGateway interface.
+type MockIdempotencyDatabaseGateway struct {
+	ctrl     *gomock.Controller
+	recorder *MockIdempotencyDatabaseGatewayMockRecorder
+}
+
+// MockIdempotencyDatabaseGatewayMockRecorder is the mock recorder for MockIdempotencyDatabaseGateway.
+type MockIdempotencyDatabaseGatewayMockRecorder struct {
+	mock *MockIdempotencyDatabaseGateway
+}
+
+// NewMockIdempotencyDatabaseGateway creates a new mock instance.
+func NewMockIdempotencyDatabaseGateway(ctrl *gomock.Controller) *MockIdempotencyDatabaseGateway {
+	mock := &MockIdempotencyDatabaseGateway{ctrl: ctrl}
+	mock.recorder = &MockIdempotencyDatabaseGatewayMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockIdempotencyDatabaseGateway) EXPECT() *MockIdempotencyDatabaseGatewayMockRecorder {
+	return m.recorder
+}
+
+// CompleteIdempotencyKey mocks base method.
+func (m *MockIdempotencyDatabaseGateway) CompleteIdempotencyKey(ctx context.Context, record *entities.IdempotencyRecord) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CompleteIdempotencyKey", ctx, record)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// CompleteIdempotencyKey indicates an expected call of CompleteIdempotencyKey.
+func (mr *MockIdempotencyDatabaseGatewayMockRecorder) CompleteIdempotencyKey(ctx, record any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CompleteIdempotencyKey", reflect.TypeOf((*MockIdempotencyDatabaseGateway)(nil).CompleteIdempotencyKey), ctx, record)
+}
+
+// PurgeIdempotencyKeys mocks base method.
+func (m *MockIdempotencyDatabaseGateway) PurgeIdempotencyKeys(ctx context.Context, expiredBefore time.Time) (int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "PurgeIdempotencyKeys", ctx, expiredBefore)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// PurgeIdempotencyKeys indicates an expected call of PurgeIdempotencyKeys.
+func (mr *MockIdempotencyDatabaseGatewayMockRecorder) PurgeIdempotencyKeys(ctx, expiredBefore any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "PurgeIdempotencyKeys", reflect.TypeOf((*MockIdempotencyDatabaseGateway)(nil).PurgeIdempotencyKeys), ctx, expiredBefore)
+}
+
+// ReleaseIdempotencyKey mocks base method.
+func (m *MockIdempotencyDatabaseGateway) ReleaseIdempotencyKey(ctx context.Context, scope, key string, reservedAt time.Time) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ReleaseIdempotencyKey", ctx, scope, key, reservedAt)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// ReleaseIdempotencyKey indicates an expected call of ReleaseIdempotencyKey.
+func (mr *MockIdempotencyDatabaseGatewayMockRecorder) ReleaseIdempotencyKey(ctx, scope, key, reservedAt any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ReleaseIdempotencyKey", reflect.TypeOf((*MockIdempotencyDatabaseGateway)(nil).ReleaseIdempotencyKey), ctx, scope, key, reservedAt)
+}
+
+// ReserveIdempotencyKey mocks base method.
+func (m *MockIdempotencyDatabaseGateway) ReserveIdempotencyKey(ctx context.Context, record *entities.IdempotencyRecord) (*entities.IdempotencyRecord, bool, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ReserveIdempotencyKey", ctx, record)
+	ret0, _ := ret[0].(*entities.IdempotencyRecord)
+	ret1, _ := ret[1].(bool)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// ReserveIdempotencyKey indicates an expected call of ReserveIdempotencyKey.
+func (mr *MockIdempotencyDatabaseGatewayMockRecorder) ReserveIdempotencyKey(ctx, record any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ReserveIdempotencyKey", reflect.TypeOf((*MockIdempotencyDatabaseGateway)(nil).ReserveIdempotencyKey), ctx, record)
+}
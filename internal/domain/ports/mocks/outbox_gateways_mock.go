@@ -0,0 +1,156 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: ./internal/domain/ports/outbox_gateways.go
+//
+// Generated by this command:
+//
+//	mockgen -source=./internal/domain/ports/outbox_gateways.go -destination=./internal/domain/ports/mocks/outbox_gateways_mock.go -package=mock
+//
+
+// Package mock is a generated GoMock package.
+package mock
+
+import (
+	context "context"
+	reflect "reflect"
+	time "time"
+
+	domain "github.com/lyracampos/go-clean-architecture/internal/domain"
+	ports "github.com/lyracampos/go-clean-architecture/internal/domain/ports"
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockOutboxDatabaseGateway is a mock of OutboxDatabaseGateway interface.
+type MockOutboxDatabaseGateway struct {
+	ctrl     *gomock.Controller
+	recorder *MockOutboxDatabaseGatewayMockRecorder
+}
+
+// MockOutboxDatabaseGatewayMockRecorder is the mock recorder for MockOutboxDatabaseGateway.
+type MockOutboxDatabaseGatewayMockRecorder struct {
+	mock *MockOutboxDatabaseGateway
+}
+
+// NewMockOutboxDatabaseGateway creates a new mock instance.
+func NewMockOutboxDatabaseGateway(ctrl *gomock.Controller) *MockOutboxDatabaseGateway {
+	mock := &MockOutboxDatabaseGateway{ctrl: ctrl}
+	mock.recorder = &MockOutboxDatabaseGatewayMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockOutboxDatabaseGateway) EXPECT() *MockOutboxDatabaseGatewayMockRecorder {
+	return m.recorder
+}
+
+// AppendEvents mocks base method.
+func (m *MockOutboxDatabaseGateway) AppendEvents(ctx context.Context, events ...domain.Event) error {
+	m.ctrl.T.Helper()
+	varargs := []any{ctx}
+	for _, a := range events {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "AppendEvents", varargs...)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// AppendEvents indicates an expected call of AppendEvents.
+func (mr *MockOutboxDatabaseGatewayMockRecorder) AppendEvents(ctx any, events ...any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]any{ctx}, events...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AppendEvents", reflect.TypeOf((*MockOutboxDatabaseGateway)(nil).AppendEvents), varargs...)
+}
+
+// ClaimEvents mocks base method.
+func (m *MockOutboxDatabaseGateway) ClaimEvents(ctx context.Context, limit int, lease time.Duration) ([]ports.ClaimedEvent, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ClaimEvents", ctx, limit, lease)
+	ret0, _ := ret[0].([]ports.ClaimedEvent)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ClaimEvents indicates an expected call of ClaimEvents.
+func (mr *MockOutboxDatabaseGatewayMockRecorder) ClaimEvents(ctx, limit, lease any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ClaimEvents", reflect.TypeOf((*MockOutboxDatabaseGateway)(nil).ClaimEvents), ctx, limit, lease)
+}
+
+// MarkEventDead mocks base method.
+func (m *MockOutboxDatabaseGateway) MarkEventDead(ctx context.Context, id, lastError string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "MarkEventDead", ctx, id, lastError)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// MarkEventDead indicates an expected call of MarkEventDead.
+func (mr *MockOutboxDatabaseGatewayMockRecorder) MarkEventDead(ctx, id, lastError any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "MarkEventDead", reflect.TypeOf((*MockOutboxDatabaseGateway)(nil).MarkEventDead), ctx, id, lastError)
+}
+
+// MarkEventDelivered mocks base method.
+func (m *MockOutboxDatabaseGateway) MarkEventDelivered(ctx context.Context, id string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "MarkEventDelivered", ctx, id)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// MarkEventDelivered indicates an expected call of MarkEventDelivered.
+func (mr *MockOutboxDatabaseGatewayMockRecorder) MarkEventDelivered(ctx, id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "MarkEventDelivered", reflect.TypeOf((*MockOutboxDatabaseGateway)(nil).MarkEventDelivered), ctx, id)
+}
+
+// MarkEventFailed mocks base method.
+func (m *MockOutboxDatabaseGateway) MarkEventFailed(ctx context.Context, id, lastError string, retryIn time.Duration) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "MarkEventFailed", ctx, id, lastError, retryIn)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// MarkEventFailed indicates an expected call of MarkEventFailed.
+func (mr *MockOutboxDatabaseGatewayMockRecorder) MarkEventFailed(ctx, id, lastError, retryIn any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "MarkEventFailed", reflect.TypeOf((*MockOutboxDatabaseGateway)(nil).MarkEventFailed), ctx, id, lastError, retryIn)
+}
+
+// MockEventPublisher is a mock of EventPublisher interface.
+type MockEventPublisher struct {
+	ctrl     *gomock.Controller
+	recorder *MockEventPublisherMockRecorder
+}
+
+// MockEventPublisherMockRecorder is the mock recorder for MockEventPublisher.
+type MockEventPublisherMockRecorder struct {
+	mock *MockEventPublisher
+}
+
+// NewMockEventPublisher creates a new mock instance.
+func NewMockEventPublisher(ctrl *gomock.Controller) *MockEventPublisher {
+	mock := &MockEventPublisher{ctrl: ctrl}
+	mock.recorder = &MockEventPublisherMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockEventPublisher) EXPECT() *MockEventPublisherMockRecorder {
+	return m.recorder
+}
+
+// Publish mocks base method.
+func (m *MockEventPublisher) Publish(ctx context.Context, event domain.Event) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Publish", ctx, event)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Publish indicates an expected call of Publish.
+func (mr *MockEventPublisherMockRecorder) Publish(ctx, event any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Publish", reflect.TypeOf((*MockEventPublisher)(nil).Publish), ctx, event)
+}
@@ -12,6 +12,7 @@ package mock
 import (
 	context "context"
 	reflect "reflect"
+	time "time"
 
 	entities "github.com/lyracampos/go-clean-architecture/internal/domain/entities"
 	ports "github.com/lyracampos/go-clean-architecture/internal/domain/ports"
@@ -41,19 +42,48 @@ func (m *MockUserDatabaseGateway) EXPECT() *MockUserDatabaseGatewayMockRecorder
 	return m.recorder
 }
 
+// CountUsers mocks base method.
+func (m *MockUserDatabaseGateway) CountUsers(ctx context.Context, filter ports.ListUserFilter) (int, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CountUsers", ctx, filter)
+	ret0, _ := ret[0].(int)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CountUsers indicates an expected call of CountUsers.
+func (mr *MockUserDatabaseGatewayMockRecorder) CountUsers(ctx, filter any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CountUsers", reflect.TypeOf((*MockUserDatabaseGateway)(nil).CountUsers), ctx, filter)
+}
+
+// DeleteUser mocks base method.
+func (m *MockUserDatabaseGateway) DeleteUser(ctx context.Context, id int64, versions []int64) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteUser", ctx, id, versions)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteUser indicates an expected call of DeleteUser.
+func (mr *MockUserDatabaseGatewayMockRecorder) DeleteUser(ctx, id, versions any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteUser", reflect.TypeOf((*MockUserDatabaseGateway)(nil).DeleteUser), ctx, id, versions)
+}
+
 // GetUser mocks base method.
-func (m *MockUserDatabaseGateway) GetUser(ctx context.Context, id int64) (*entities.User, error) {
+func (m *MockUserDatabaseGateway) GetUser(ctx context.Context, filter ports.GetUserFilter) (*entities.User, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "GetUser", ctx, id)
+	ret := m.ctrl.Call(m, "GetUser", ctx, filter)
 	ret0, _ := ret[0].(*entities.User)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
 // GetUser indicates an expected call of GetUser.
-func (mr *MockUserDatabaseGatewayMockRecorder) GetUser(ctx, id any) *gomock.Call {
+func (mr *MockUserDatabaseGatewayMockRecorder) GetUser(ctx, filter any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetUser", reflect.TypeOf((*MockUserDatabaseGateway)(nil).GetUser), ctx, id)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetUser", reflect.TypeOf((*MockUserDatabaseGateway)(nil).GetUser), ctx, filter)
 }
 
 // InsertUser mocks base method.
@@ -85,3 +115,48 @@ func (mr *MockUserDatabaseGatewayMockRecorder) ListUser(ctx, filter any) *gomock
 	mr.mock.ctrl.T.Helper()
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListUser", reflect.TypeOf((*MockUserDatabaseGateway)(nil).ListUser), ctx, filter)
 }
+
+// PurgeUsers mocks base method.
+func (m *MockUserDatabaseGateway) PurgeUsers(ctx context.Context, deletedBefore time.Time) (int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "PurgeUsers", ctx, deletedBefore)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// PurgeUsers indicates an expected call of PurgeUsers.
+func (mr *MockUserDatabaseGatewayMockRecorder) PurgeUsers(ctx, deletedBefore any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "PurgeUsers", reflect.TypeOf((*MockUserDatabaseGateway)(nil).PurgeUsers), ctx, deletedBefore)
+}
+
+// RestoreUser mocks base method.
+func (m *MockUserDatabaseGateway) RestoreUser(ctx context.Context, id int64, versions []int64) (*entities.User, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RestoreUser", ctx, id, versions)
+	ret0, _ := ret[0].(*entities.User)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// RestoreUser indicates an expected call of RestoreUser.
+func (mr *MockUserDatabaseGatewayMockRecorder) RestoreUser(ctx, id, versions any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RestoreUser", reflect.TypeOf((*MockUserDatabaseGateway)(nil).RestoreUser), ctx, id, versions)
+}
+
+// UpdateUser mocks base method.
+func (m *MockUserDatabaseGateway) UpdateUser(ctx context.Context, user *entities.User) (*entities.User, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateUser", ctx, user)
+	ret0, _ := ret[0].(*entities.User)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// UpdateUser indicates an expected call of UpdateUser.
+func (mr *MockUserDatabaseGatewayMockRecorder) UpdateUser(ctx, user any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateUser", reflect.TypeOf((*MockUserDatabaseGateway)(nil).UpdateUser), ctx, user)
+}
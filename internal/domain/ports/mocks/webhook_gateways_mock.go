@@ -0,0 +1,213 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: ./internal/domain/ports/webhook_gateways.go
+//
+// Generated by this command:
+//
+//	mockgen -source=./internal/domain/ports/webhook_gateways.go -destination=./internal/domain/ports/mocks/webhook_gateways_mock.go -package=mock
+//
+
+// Package mock is a generated GoMock package.
+package mock
+
+import (
+	context "context"
+	reflect "reflect"
+	time "time"
+
+	entities "github.com/lyracampos/go-clean-architecture/internal/domain/entities"
+	ports "github.com/lyracampos/go-clean-architecture/internal/domain/ports"
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockWebhookDatabaseGateway is a mock of WebhookDatabaseGateway interface.
+type MockWebhookDatabaseGateway struct {
+	ctrl     *gomock.Controller
+	recorder *MockWebhookDatabaseGatewayMockRecorder
+}
+
+// MockWebhookDatabaseGatewayMockRecorder is the mock recorder for MockWebhookDatabaseGateway.
+type MockWebhookDatabaseGatewayMockRecorder struct {
+	mock *MockWebhookDatabaseGateway
+}
+
+// NewMockWebhookDatabaseGateway creates a new mock instance.
+func NewMockWebhookDatabaseGateway(ctrl *gomock.Controller) *MockWebhookDatabaseGateway {
+	mock := &MockWebhookDatabaseGateway{ctrl: ctrl}
+	mock.recorder = &MockWebhookDatabaseGatewayMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockWebhookDatabaseGateway) EXPECT() *MockWebhookDatabaseGatewayMockRecorder {
+	return m.recorder
+}
+
+// ClaimWebhookDeliveries mocks base method.
+func (m *MockWebhookDatabaseGateway) ClaimWebhookDeliveries(ctx context.Context, limit int, lease time.Duration) ([]*entities.WebhookDelivery, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ClaimWebhookDeliveries", ctx, limit, lease)
+	ret0, _ := ret[0].([]*entities.WebhookDelivery)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ClaimWebhookDeliveries indicates an expected call of ClaimWebhookDeliveries.
+func (mr *MockWebhookDatabaseGatewayMockRecorder) ClaimWebhookDeliveries(ctx, limit, lease any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ClaimWebhookDeliveries", reflect.TypeOf((*MockWebhookDatabaseGateway)(nil).ClaimWebhookDeliveries), ctx, limit, lease)
+}
+
+// DeleteWebhook mocks base method.
+func (m *MockWebhookDatabaseGateway) DeleteWebhook(ctx context.Context, id int64) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteWebhook", ctx, id)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteWebhook indicates an expected call of DeleteWebhook.
+func (mr *MockWebhookDatabaseGatewayMockRecorder) DeleteWebhook(ctx, id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteWebhook", reflect.TypeOf((*MockWebhookDatabaseGateway)(nil).DeleteWebhook), ctx, id)
+}
+
+// EnqueueWebhookDeliveries mocks base method.
+func (m *MockWebhookDatabaseGateway) EnqueueWebhookDeliveries(ctx context.Context, eventID, eventType string, payload []byte) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "EnqueueWebhookDeliveries", ctx, eventID, eventType, payload)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// EnqueueWebhookDeliveries indicates an expected call of EnqueueWebhookDeliveries.
+func (mr *MockWebhookDatabaseGatewayMockRecorder) EnqueueWebhookDeliveries(ctx, eventID, eventType, payload any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "EnqueueWebhookDeliveries", reflect.TypeOf((*MockWebhookDatabaseGateway)(nil).EnqueueWebhookDeliveries), ctx, eventID, eventType, payload)
+}
+
+// GetWebhook mocks base method.
+func (m *MockWebhookDatabaseGateway) GetWebhook(ctx context.Context, id int64) (*entities.Webhook, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetWebhook", ctx, id)
+	ret0, _ := ret[0].(*entities.Webhook)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetWebhook indicates an expected call of GetWebhook.
+func (mr *MockWebhookDatabaseGatewayMockRecorder) GetWebhook(ctx, id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetWebhook", reflect.TypeOf((*MockWebhookDatabaseGateway)(nil).GetWebhook), ctx, id)
+}
+
+// InsertWebhook mocks base method.
+func (m *MockWebhookDatabaseGateway) InsertWebhook(ctx context.Context, webhook *entities.Webhook) (*entities.Webhook, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "InsertWebhook", ctx, webhook)
+	ret0, _ := ret[0].(*entities.Webhook)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// InsertWebhook indicates an expected call of InsertWebhook.
+func (mr *MockWebhookDatabaseGatewayMockRecorder) InsertWebhook(ctx, webhook any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "InsertWebhook", reflect.TypeOf((*MockWebhookDatabaseGateway)(nil).InsertWebhook), ctx, webhook)
+}
+
+// ListWebhookDeliveryAttempts mocks base method.
+func (m *MockWebhookDatabaseGateway) ListWebhookDeliveryAttempts(ctx context.Context, webhookID int64, limit int) ([]*entities.WebhookDeliveryAttempt, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListWebhookDeliveryAttempts", ctx, webhookID, limit)
+	ret0, _ := ret[0].([]*entities.WebhookDeliveryAttempt)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListWebhookDeliveryAttempts indicates an expected call of ListWebhookDeliveryAttempts.
+func (mr *MockWebhookDatabaseGatewayMockRecorder) ListWebhookDeliveryAttempts(ctx, webhookID, limit any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListWebhookDeliveryAttempts", reflect.TypeOf((*MockWebhookDatabaseGateway)(nil).ListWebhookDeliveryAttempts), ctx, webhookID, limit)
+}
+
+// ListWebhooks mocks base method.
+func (m *MockWebhookDatabaseGateway) ListWebhooks(ctx context.Context) ([]*entities.Webhook, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListWebhooks", ctx)
+	ret0, _ := ret[0].([]*entities.Webhook)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListWebhooks indicates an expected call of ListWebhooks.
+func (mr *MockWebhookDatabaseGatewayMockRecorder) ListWebhooks(ctx any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListWebhooks", reflect.TypeOf((*MockWebhookDatabaseGateway)(nil).ListWebhooks), ctx)
+}
+
+// RecordWebhookDeliveryAttempt mocks base method.
+func (m *MockWebhookDatabaseGateway) RecordWebhookDeliveryAttempt(ctx context.Context, outcome ports.WebhookDeliveryOutcome) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RecordWebhookDeliveryAttempt", ctx, outcome)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// RecordWebhookDeliveryAttempt indicates an expected call of RecordWebhookDeliveryAttempt.
+func (mr *MockWebhookDatabaseGatewayMockRecorder) RecordWebhookDeliveryAttempt(ctx, outcome any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RecordWebhookDeliveryAttempt", reflect.TypeOf((*MockWebhookDatabaseGateway)(nil).RecordWebhookDeliveryAttempt), ctx, outcome)
+}
+
+// UpdateWebhook mocks base method.
+func (m *MockWebhookDatabaseGateway) UpdateWebhook(ctx context.Context, webhook *entities.Webhook) (*entities.Webhook, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateWebhook", ctx, webhook)
+	ret0, _ := ret[0].(*entities.Webhook)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// UpdateWebhook indicates an expected call of UpdateWebhook.
+func (mr *MockWebhookDatabaseGatewayMockRecorder) UpdateWebhook(ctx, webhook any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateWebhook", reflect.TypeOf((*MockWebhookDatabaseGateway)(nil).UpdateWebhook), ctx, webhook)
+}
+
+// MockWebhookSender is a mock of WebhookSender interface.
+type MockWebhookSender struct {
+	ctrl     *gomock.Controller
+	recorder *MockWebhookSenderMockRecorder
+}
+
+// MockWebhookSenderMockRecorder is the mock recorder for MockWebhookSender.
+type MockWebhookSenderMockRecorder struct {
+	mock *MockWebhookSender
+}
+
+// NewMockWebhookSender creates a new mock instance.
+func NewMockWebhookSender(ctrl *gomock.Controller) *MockWebhookSender {
+	mock := &MockWebhookSender{ctrl: ctrl}
+	mock.recorder = &MockWebhookSenderMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockWebhookSender) EXPECT() *MockWebhookSenderMockRecorder {
+	return m.recorder
+}
+
+// Send mocks base method.
+func (m *MockWebhookSender) Send(ctx context.Context, delivery *entities.WebhookDelivery) (int, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Send", ctx, delivery)
+	ret0, _ := ret[0].(int)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Send indicates an expected call of Send.
+func (mr *MockWebhookSenderMockRecorder) Send(ctx, delivery any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Send", reflect.TypeOf((*MockWebhookSender)(nil).Send), ctx, delivery)
+}
@@ -0,0 +1,34 @@
+package ports
+
+import (
+	"context"
+	"time"
+
+	"github.com/lyracampos/go-clean-architecture/internal/domain"
+)
+
+// OutboxDatabaseGateway stores domain events until they are relayed to other systems.
+type OutboxDatabaseGateway interface {
+	AppendEvents(ctx context.Context, events ...domain.Event) error
+	// ClaimEvents leases up to limit events due for delivery, skipping the ones other workers hold.
+	// A leased event is offered again once the lease expires, in case its worker died.
+	ClaimEvents(ctx context.Context, limit int, lease time.Duration) ([]ClaimedEvent, error)
+	MarkEventDelivered(ctx context.Context, id string) error
+	// MarkEventFailed records the failure and schedules the next attempt after the delay.
+	MarkEventFailed(ctx context.Context, id, lastError string, retryIn time.Duration) error
+	// MarkEventDead gives up the delivery, the event is kept for inspection.
+	MarkEventDead(ctx context.Context, id, lastError string) error
+}
+
+// ClaimedEvent is an event leased for delivery.
+type ClaimedEvent struct {
+	Event domain.Event
+	// Attempts counts the deliveries tried so far, including the current one.
+	Attempts int
+}
+
+// EventPublisher delivers events to other systems. Errors wrapping
+// domain.ErrEventRejected are permanent, any other error is retried.
+type EventPublisher interface {
+	Publish(ctx context.Context, event domain.Event) error
+}
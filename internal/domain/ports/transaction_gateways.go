@@ -0,0 +1,9 @@
+package ports
+
+import "context"
+
+// Transactor runs fn in a single database transaction, committed when fn succeeds and rolled back
+// otherwise. The gateways called with the context given to fn take part in the transaction.
+type Transactor interface {
+	RunInTx(ctx context.Context, fn func(ctx context.Context) error) error
+}
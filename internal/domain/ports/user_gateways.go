@@ -2,19 +2,66 @@ package ports
 
 import (
 	"context"
+	"time"
 
 	"github.com/lyracampos/go-clean-architecture/internal/domain/entities"
 )
 
+// UserDatabaseGateway stores the users. Every change to an user also appends
+// its domain events to the outbox, in the same transaction as the change.
 type UserDatabaseGateway interface {
 	ListUser(ctx context.Context, filter ListUserFilter) ([]*entities.User, error)
-	GetUser(ctx context.Context, id int64) (*entities.User, error)
+	CountUsers(ctx context.Context, filter ListUserFilter) (int, error)
+	GetUser(ctx context.Context, filter GetUserFilter) (*entities.User, error)
 	InsertUser(ctx context.Context, user *entities.User) (*entities.User, error)
+	UpdateUser(ctx context.Context, user *entities.User) (*entities.User, error)
+	// DeleteUser and RestoreUser change the user only at one of the versions informed, unless they hold entities.AnyVersion.
+	DeleteUser(ctx context.Context, id int64, versions []int64) error
+	RestoreUser(ctx context.Context, id int64, versions []int64) (*entities.User, error)
+	PurgeUsers(ctx context.Context, deletedBefore time.Time) (int64, error)
 }
 
+const (
+	UserSortCreatedAt = "created_at"
+	UserSortFirstName = "first_name"
+	UserSortLastName  = "last_name"
+	UserSortEmail     = "email"
+	UserSortRelevance = "relevance"
+)
+
 type ListUserFilter struct {
-	FirstName string
-	LastName  string
-	Email     string
-	Role      string
+	// ID restricts the list to a single user when set.
+	ID             int64
+	FirstName      string
+	LastName       string
+	Email          string
+	Role           string
+	IncludeDeleted bool
+	// Query is a free text searched across names and email
+	Query string
+
+	// Limit caps the number of users returned, zero means no limit.
+	Limit int
+	Sort  UserSort
+	// After is the keyset position to continue from, nil starts from the first user.
+	After *UserCursor
+}
+
+// UserSort defines the field users are ordered by, ties are broken by ID in the same direction.
+type UserSort struct {
+	Field      string
+	Descending bool
+}
+
+// UserCursor is the keyset position of the last user of a page.
+type UserCursor struct {
+	Value string
+	ID    int64
+}
+
+// GetUserFilter finds an user by ID, or by email when the ID is zero.
+type GetUserFilter struct {
+	ID             int64
+	Email          string
+	IncludeDeleted bool
 }
@@ -0,0 +1,41 @@
+package ports
+
+import (
+	"context"
+	"time"
+
+	"github.com/lyracampos/go-clean-architecture/internal/domain/entities"
+)
+
+type WebhookDatabaseGateway interface {
+	InsertWebhook(ctx context.Context, webhook *entities.Webhook) (*entities.Webhook, error)
+	ListWebhooks(ctx context.Context) ([]*entities.Webhook, error)
+	GetWebhook(ctx context.Context, id int64) (*entities.Webhook, error)
+	UpdateWebhook(ctx context.Context, webhook *entities.Webhook) (*entities.Webhook, error)
+	DeleteWebhook(ctx context.Context, id int64) error
+	// ListWebhookDeliveryAttempts returns the latest attempts first.
+	ListWebhookDeliveryAttempts(ctx context.Context, webhookID int64, limit int) ([]*entities.WebhookDeliveryAttempt, error)
+	// EnqueueWebhookDeliveries schedules the payload for every enabled webhook subscribed to the event type,
+	// enqueuing the same event twice for a webhook has no effect.
+	EnqueueWebhookDeliveries(ctx context.Context, eventID, eventType string, payload []byte) error
+	// ClaimWebhookDeliveries leases up to limit deliveries due for enabled webhooks,
+	// skipping the ones other workers hold.
+	ClaimWebhookDeliveries(ctx context.Context, limit int, lease time.Duration) ([]*entities.WebhookDelivery, error)
+	// RecordWebhookDeliveryAttempt logs the attempt and updates both the delivery and the webhook failure count atomically.
+	RecordWebhookDeliveryAttempt(ctx context.Context, outcome WebhookDeliveryOutcome) error
+}
+
+// WebhookDeliveryOutcome is what to store after an attempt.
+type WebhookDeliveryOutcome struct {
+	Attempt *entities.WebhookDeliveryAttempt
+	// RetryIn schedules the next attempt of a failed delivery, zero gives it up
+	RetryIn time.Duration
+	// DisableAfter disables the webhook once it failed that many deliveries in a row
+	DisableAfter int
+}
+
+type WebhookSender interface {
+	// Send posts the signed payload of the delivery, failing unless the receiver
+	// answers with a 2xx status. The status code is zero when there was no response.
+	Send(ctx context.Context, delivery *entities.WebhookDelivery) (int, error)
+}
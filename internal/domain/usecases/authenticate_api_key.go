@@ -0,0 +1,99 @@
+package usecases
+
+import (
+	"context"
+	"crypto/subtle"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/lyracampos/go-clean-architecture/internal/domain"
+	"github.com/lyracampos/go-clean-architecture/internal/domain/ports"
+)
+
+// apiKeyLastUsedResolution limits how often the last use of a key is written,
+// so that busy callers don't turn every request into a database write.
+const apiKeyLastUsedResolution = time.Minute
+
+var _ AuthenticateAPIKeyUseCase = (*authenticateAPIKeyUseCase)(nil)
+
+type AuthenticateAPIKeyUseCase interface {
+	Execute(ctx context.Context, input AuthenticateAPIKeyInput) (domain.Caller, error)
+}
+
+type AuthenticateAPIKeyInput struct {
+	Key string
+}
+
+type authenticateAPIKeyUseCase struct {
+	userDatabase   ports.UserDatabaseGateway
+	apiKeyDatabase ports.APIKeyDatabaseGateway
+	apiKeyService  ports.APIKeyService
+	now            func() time.Time
+}
+
+func NewAuthenticateAPIKeyUseCase(
+	userDatabase ports.UserDatabaseGateway,
+	apiKeyDatabase ports.APIKeyDatabaseGateway,
+	apiKeyService ports.APIKeyService,
+) *authenticateAPIKeyUseCase {
+	return &authenticateAPIKeyUseCase{
+		userDatabase:   userDatabase,
+		apiKeyDatabase: apiKeyDatabase,
+		apiKeyService:  apiKeyService,
+		now:            time.Now,
+	}
+}
+
+// Execute returns the caller the key acts for: its creator, restricted to the key scopes.
+// The creator's current role is used, so demoting or deleting the creator limits its keys too.
+func (u *authenticateAPIKeyUseCase) Execute(ctx context.Context, input AuthenticateAPIKeyInput) (domain.Caller, error) {
+	prefix, hash, err := u.apiKeyService.ParseAPIKey(input.Key)
+	if err != nil {
+		return domain.Caller{}, domain.ErrInvalidAPIKey
+	}
+
+	apiKey, err := u.apiKeyDatabase.GetAPIKey(ctx, prefix)
+	if errors.Is(err, domain.ErrAPIKeyDoesNotExist) {
+		return domain.Caller{}, domain.ErrInvalidAPIKey
+	}
+
+	if err != nil {
+		return domain.Caller{}, err
+	}
+
+	if subtle.ConstantTimeCompare([]byte(apiKey.KeyHash), []byte(hash)) != 1 {
+		return domain.Caller{}, domain.ErrInvalidAPIKey
+	}
+
+	now := u.now()
+	if apiKey.ExpiresAt != nil && !now.Before(*apiKey.ExpiresAt) {
+		return domain.Caller{}, domain.ErrInvalidAPIKey
+	}
+
+	user, err := u.userDatabase.GetUser(ctx, ports.GetUserFilter{ID: apiKey.UserID})
+	if errors.Is(err, domain.ErrUserDoesNotExist) {
+		return domain.Caller{}, domain.ErrInvalidAPIKey
+	}
+
+	if err != nil {
+		return domain.Caller{}, err
+	}
+
+	if apiKey.LastUsedAt == nil || now.Sub(*apiKey.LastUsedAt) >= apiKeyLastUsedResolution {
+		if err := u.apiKeyDatabase.TouchAPIKey(ctx, apiKey.ID, now); err != nil {
+			return domain.Caller{}, fmt.Errorf("failed to update api key last use into database: %w", err)
+		}
+	}
+
+	scopes := make([]domain.Permission, 0, len(apiKey.Scopes))
+	for _, scope := range apiKey.Scopes {
+		scopes = append(scopes, domain.Permission(scope))
+	}
+
+	return domain.Caller{
+		UserID: user.ID,
+		Role:   user.Role,
+		Scopes: scopes,
+	}, nil
+}
@@ -0,0 +1,193 @@
+package usecases
+
+import (
+	"context"
+	"errors"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/lyracampos/go-clean-architecture/internal/domain"
+	"github.com/lyracampos/go-clean-architecture/internal/domain/entities"
+	"github.com/lyracampos/go-clean-architecture/internal/domain/ports"
+	mock "github.com/lyracampos/go-clean-architecture/internal/domain/ports/mocks"
+	"go.uber.org/mock/gomock"
+)
+
+func TestAuthenticateAPIKeyExecute(t *testing.T) {
+	ctx := context.Background()
+	now := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	recentUse := now.Add(-10 * time.Second)
+
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	type args struct {
+		ctx   context.Context
+		input *AuthenticateAPIKeyInput
+	}
+	tests := []struct {
+		name       string
+		args       args
+		beforeTest func(userDatabase *mock.MockUserDatabaseGateway, apiKeyDatabase *mock.MockAPIKeyDatabaseGateway, apiKeyService *mock.MockAPIKeyService)
+		want       domain.Caller
+		wantErr    bool
+		err        error
+	}{
+		{
+			name: "success authenticating api key as its creator restricted to its scopes",
+			args: args{
+				ctx:   ctx,
+				input: &AuthenticateAPIKeyInput{Key: "gca_prefix_secret"},
+			},
+			beforeTest: func(userDatabase *mock.MockUserDatabaseGateway, apiKeyDatabase *mock.MockAPIKeyDatabaseGateway, apiKeyService *mock.MockAPIKeyService) {
+				apiKeyService.EXPECT().ParseAPIKey("gca_prefix_secret").Return("prefix", "keyHash", nil)
+				apiKeyDatabase.EXPECT().GetAPIKey(gomock.Any(), "prefix").Return(storedAPIKey(nil, nil), nil)
+				userDatabase.EXPECT().GetUser(gomock.Any(), ports.GetUserFilter{ID: 1}).Return(defaultGetUserResult())
+				apiKeyDatabase.EXPECT().TouchAPIKey(gomock.Any(), int64(5), now).Return(nil)
+			},
+			want: domain.Caller{UserID: 1, Role: "admin", Scopes: []domain.Permission{domain.PermissionUsersRead}},
+		},
+		{
+			name: "success authenticating api key used recently without recording the use again",
+			args: args{
+				ctx:   ctx,
+				input: &AuthenticateAPIKeyInput{Key: "gca_prefix_secret"},
+			},
+			beforeTest: func(userDatabase *mock.MockUserDatabaseGateway, apiKeyDatabase *mock.MockAPIKeyDatabaseGateway, apiKeyService *mock.MockAPIKeyService) {
+				apiKeyService.EXPECT().ParseAPIKey("gca_prefix_secret").Return("prefix", "keyHash", nil)
+				apiKeyDatabase.EXPECT().GetAPIKey(gomock.Any(), "prefix").Return(storedAPIKey(nil, &recentUse), nil)
+				userDatabase.EXPECT().GetUser(gomock.Any(), ports.GetUserFilter{ID: 1}).Return(defaultGetUserResult())
+			},
+			want: domain.Caller{UserID: 1, Role: "admin", Scopes: []domain.Permission{domain.PermissionUsersRead}},
+		},
+		{
+			name: "fail authenticating malformed api key",
+			args: args{
+				ctx:   ctx,
+				input: &AuthenticateAPIKeyInput{Key: "malformed"},
+			},
+			beforeTest: func(userDatabase *mock.MockUserDatabaseGateway, apiKeyDatabase *mock.MockAPIKeyDatabaseGateway, apiKeyService *mock.MockAPIKeyService) {
+				apiKeyService.EXPECT().ParseAPIKey("malformed").Return("", "", domain.ErrInvalidAPIKey)
+			},
+			wantErr: true,
+			err:     domain.ErrInvalidAPIKey,
+		},
+		{
+			name: "fail authenticating unknown api key",
+			args: args{
+				ctx:   ctx,
+				input: &AuthenticateAPIKeyInput{Key: "gca_prefix_secret"},
+			},
+			beforeTest: func(userDatabase *mock.MockUserDatabaseGateway, apiKeyDatabase *mock.MockAPIKeyDatabaseGateway, apiKeyService *mock.MockAPIKeyService) {
+				apiKeyService.EXPECT().ParseAPIKey("gca_prefix_secret").Return("prefix", "keyHash", nil)
+				apiKeyDatabase.EXPECT().GetAPIKey(gomock.Any(), "prefix").Return(nil, domain.ErrAPIKeyDoesNotExist)
+			},
+			wantErr: true,
+			err:     domain.ErrInvalidAPIKey,
+		},
+		{
+			name: "fail authenticating api key with a wrong secret",
+			args: args{
+				ctx:   ctx,
+				input: &AuthenticateAPIKeyInput{Key: "gca_prefix_wrong"},
+			},
+			beforeTest: func(userDatabase *mock.MockUserDatabaseGateway, apiKeyDatabase *mock.MockAPIKeyDatabaseGateway, apiKeyService *mock.MockAPIKeyService) {
+				apiKeyService.EXPECT().ParseAPIKey("gca_prefix_wrong").Return("prefix", "wrongHash", nil)
+				apiKeyDatabase.EXPECT().GetAPIKey(gomock.Any(), "prefix").Return(storedAPIKey(nil, nil), nil)
+			},
+			wantErr: true,
+			err:     domain.ErrInvalidAPIKey,
+		},
+		{
+			name: "fail authenticating expired api key",
+			args: args{
+				ctx:   ctx,
+				input: &AuthenticateAPIKeyInput{Key: "gca_prefix_secret"},
+			},
+			beforeTest: func(userDatabase *mock.MockUserDatabaseGateway, apiKeyDatabase *mock.MockAPIKeyDatabaseGateway, apiKeyService *mock.MockAPIKeyService) {
+				apiKeyService.EXPECT().ParseAPIKey("gca_prefix_secret").Return("prefix", "keyHash", nil)
+				apiKeyDatabase.EXPECT().GetAPIKey(gomock.Any(), "prefix").Return(storedAPIKey(&now, nil), nil)
+			},
+			wantErr: true,
+			err:     domain.ErrInvalidAPIKey,
+		},
+		{
+			name: "fail authenticating api key when its creator was deleted",
+			args: args{
+				ctx:   ctx,
+				input: &AuthenticateAPIKeyInput{Key: "gca_prefix_secret"},
+			},
+			beforeTest: func(userDatabase *mock.MockUserDatabaseGateway, apiKeyDatabase *mock.MockAPIKeyDatabaseGateway, apiKeyService *mock.MockAPIKeyService) {
+				apiKeyService.EXPECT().ParseAPIKey("gca_prefix_secret").Return("prefix", "keyHash", nil)
+				apiKeyDatabase.EXPECT().GetAPIKey(gomock.Any(), "prefix").Return(storedAPIKey(nil, nil), nil)
+				userDatabase.EXPECT().GetUser(gomock.Any(), ports.GetUserFilter{ID: 1}).Return(getUserNotFoundResult())
+			},
+			wantErr: true,
+			err:     domain.ErrInvalidAPIKey,
+		},
+		{
+			name: "fail authenticating api key when recording the use fails",
+			args: args{
+				ctx:   ctx,
+				input: &AuthenticateAPIKeyInput{Key: "gca_prefix_secret"},
+			},
+			beforeTest: func(userDatabase *mock.MockUserDatabaseGateway, apiKeyDatabase *mock.MockAPIKeyDatabaseGateway, apiKeyService *mock.MockAPIKeyService) {
+				apiKeyService.EXPECT().ParseAPIKey("gca_prefix_secret").Return("prefix", "keyHash", nil)
+				apiKeyDatabase.EXPECT().GetAPIKey(gomock.Any(), "prefix").Return(storedAPIKey(nil, nil), nil)
+				userDatabase.EXPECT().GetUser(gomock.Any(), ports.GetUserFilter{ID: 1}).Return(defaultGetUserResult())
+				apiKeyDatabase.EXPECT().TouchAPIKey(gomock.Any(), int64(5), now).Return(domain.ErrDeadlock)
+			},
+			wantErr: true,
+			err:     errors.New("failed to update api key last use into database: deadlock detected"),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockUserDatabase := mock.NewMockUserDatabaseGateway(ctrl)
+			mockAPIKeyDatabase := mock.NewMockAPIKeyDatabaseGateway(ctrl)
+			mockAPIKeyService := mock.NewMockAPIKeyService(ctrl)
+
+			usecase := &authenticateAPIKeyUseCase{
+				userDatabase:   mockUserDatabase,
+				apiKeyDatabase: mockAPIKeyDatabase,
+				apiKeyService:  mockAPIKeyService,
+				now:            func() time.Time { return now },
+			}
+
+			if tt.beforeTest != nil {
+				tt.beforeTest(mockUserDatabase, mockAPIKeyDatabase, mockAPIKeyService)
+			}
+
+			caller, err := usecase.Execute(tt.args.ctx, *tt.args.input)
+
+			if (err != nil) != tt.wantErr {
+				t.Errorf("authenticateAPIKey.Execute() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if !reflect.DeepEqual(caller, tt.want) {
+				t.Errorf("authenticateAPIKey.Execute() = %v, want %v", caller, tt.want)
+			}
+			if tt.wantErr {
+				if err.Error() != tt.err.Error() {
+					t.Errorf("authenticateAPIKey.Execute() error = %v, wantErr %v", err, tt.err)
+					return
+				}
+			}
+		})
+	}
+}
+
+func storedAPIKey(expiresAt, lastUsedAt *time.Time) *entities.APIKey {
+	return &entities.APIKey{
+		ID:         5,
+		UserID:     1,
+		Name:       "batch",
+		Prefix:     "prefix",
+		KeyHash:    "keyHash",
+		Scopes:     []string{"users:read"},
+		ExpiresAt:  expiresAt,
+		LastUsedAt: lastUsedAt,
+	}
+}
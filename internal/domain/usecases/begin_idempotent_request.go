@@ -0,0 +1,110 @@
+package usecases
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/lyracampos/go-clean-architecture/internal/domain"
+	"github.com/lyracampos/go-clean-architecture/internal/domain/entities"
+	"github.com/lyracampos/go-clean-architecture/internal/domain/ports"
+)
+
+var _ BeginIdempotentRequestUseCase = (*beginIdempotentRequestUseCase)(nil)
+
+type BeginIdempotentRequestUseCase interface {
+	Execute(ctx context.Context, input BeginIdempotentRequestInput) (BeginIdempotentRequestOutput, error)
+}
+
+type BeginIdempotentRequestInput struct {
+	Key string `validate:"required,max=255"`
+	// Fingerprint identifies the request, such as a hash of its method, path and body
+	Fingerprint string `validate:"required"`
+}
+
+type BeginIdempotentRequestOutput struct {
+	// Replayed is true when the request already completed, its stored response must be returned
+	Replayed   bool
+	StatusCode int
+	Header     map[string][]string
+	Body       []byte
+	// ReservedAt identifies the reservation of the key by the request, it must be given back to complete it
+	ReservedAt time.Time
+}
+
+type beginIdempotentRequestUseCase struct {
+	idempotencyDatabase ports.IdempotencyDatabaseGateway
+	// ttl is for how long the responses are replayed
+	ttl time.Duration
+	// lease is for how long a request keeps its key reserved before it is considered abandoned
+	lease     time.Duration
+	validator domain.Validator
+	now       func() time.Time
+}
+
+func NewBeginIdempotentRequestUseCase(
+	idempotencyDatabase ports.IdempotencyDatabaseGateway,
+	ttl time.Duration,
+	lease time.Duration,
+	validator domain.Validator,
+) *beginIdempotentRequestUseCase {
+	return &beginIdempotentRequestUseCase{
+		idempotencyDatabase: idempotencyDatabase,
+		ttl:                 ttl,
+		lease:               lease,
+		validator:           validator,
+		now:                 time.Now,
+	}
+}
+
+// Execute reserves the key for the request, or returns the response stored for it when the
+// same request was already made. Reusing the key for another request fails with ErrIdempotencyKeyReused.
+func (u *beginIdempotentRequestUseCase) Execute(ctx context.Context, input BeginIdempotentRequestInput) (BeginIdempotentRequestOutput, error) {
+	err := u.validator.Validate(input)
+	if err != nil {
+		return BeginIdempotentRequestOutput{}, fmt.Errorf("input is invalid: %w", err)
+	}
+
+	now := u.now()
+
+	record, reserved, err := u.idempotencyDatabase.ReserveIdempotencyKey(ctx, &entities.IdempotencyRecord{
+		Scope:       idempotencyScope(ctx),
+		Key:         input.Key,
+		Fingerprint: input.Fingerprint,
+		CreatedAt:   now,
+		ExpiresAt:   now.Add(u.ttl),
+		LockedUntil: now.Add(u.lease),
+	})
+	if err != nil {
+		return BeginIdempotentRequestOutput{}, fmt.Errorf("failed to reserve idempotency key into database: %w", err)
+	}
+
+	if reserved {
+		return BeginIdempotentRequestOutput{ReservedAt: record.CreatedAt}, nil
+	}
+
+	if record.Fingerprint != input.Fingerprint {
+		return BeginIdempotentRequestOutput{}, domain.ErrIdempotencyKeyReused
+	}
+
+	if !record.Completed {
+		return BeginIdempotentRequestOutput{}, domain.ErrIdempotencyKeyInProgress
+	}
+
+	return BeginIdempotentRequestOutput{
+		Replayed:   true,
+		StatusCode: record.StatusCode,
+		Header:     record.Header,
+		Body:       record.Body,
+	}, nil
+}
+
+// idempotencyScope keeps the keys of each caller apart, so that no caller can replay the response of another.
+func idempotencyScope(ctx context.Context) string {
+	caller, ok := domain.CallerFromContext(ctx)
+	if !ok {
+		return "anonymous"
+	}
+
+	return fmt.Sprintf("user:%d", caller.UserID)
+}
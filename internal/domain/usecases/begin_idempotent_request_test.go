@@ -0,0 +1,180 @@
+package usecases
+
+import (
+	"context"
+	"errors"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/lyracampos/go-clean-architecture/internal/domain"
+	"github.com/lyracampos/go-clean-architecture/internal/domain/entities"
+	mock "github.com/lyracampos/go-clean-architecture/internal/domain/ports/mocks"
+	"go.uber.org/mock/gomock"
+)
+
+func TestBeginIdempotentRequestExecute(t *testing.T) {
+	ctx := adminContext()
+	now := time.Date(2024, 2, 1, 0, 0, 0, 0, time.UTC)
+	input := &BeginIdempotentRequestInput{Key: "key", Fingerprint: "fingerprint"}
+	reservation := &entities.IdempotencyRecord{
+		Scope:       "user:99",
+		Key:         "key",
+		Fingerprint: "fingerprint",
+		CreatedAt:   now,
+		ExpiresAt:   now.Add(24 * time.Hour),
+		LockedUntil: now.Add(time.Minute),
+	}
+
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	type args struct {
+		ctx   context.Context
+		input *BeginIdempotentRequestInput
+	}
+	tests := []struct {
+		name       string
+		args       args
+		beforeTest func(idempotencyDatabase *mock.MockIdempotencyDatabaseGateway)
+		want       BeginIdempotentRequestOutput
+		wantErr    bool
+		err        error
+	}{
+		{
+			name: "success reserving a new key",
+			args: args{
+				ctx:   ctx,
+				input: input,
+			},
+			beforeTest: func(idempotencyDatabase *mock.MockIdempotencyDatabaseGateway) {
+				idempotencyDatabase.EXPECT().ReserveIdempotencyKey(gomock.Any(), reservation).Return(reservation, true, nil)
+			},
+			want: BeginIdempotentRequestOutput{ReservedAt: now},
+		},
+		{
+			name: "success reserving a key of an anonymous caller",
+			args: args{
+				ctx:   context.Background(),
+				input: input,
+			},
+			beforeTest: func(idempotencyDatabase *mock.MockIdempotencyDatabaseGateway) {
+				anonymous := *reservation
+				anonymous.Scope = "anonymous"
+				idempotencyDatabase.EXPECT().ReserveIdempotencyKey(gomock.Any(), &anonymous).Return(&anonymous, true, nil)
+			},
+			want: BeginIdempotentRequestOutput{ReservedAt: now},
+		},
+		{
+			name: "success replaying the response of a completed request",
+			args: args{
+				ctx:   ctx,
+				input: input,
+			},
+			beforeTest: func(idempotencyDatabase *mock.MockIdempotencyDatabaseGateway) {
+				idempotencyDatabase.EXPECT().ReserveIdempotencyKey(gomock.Any(), reservation).Return(&entities.IdempotencyRecord{
+					Scope:       "user:99",
+					Key:         "key",
+					Fingerprint: "fingerprint",
+					Completed:   true,
+					StatusCode:  201,
+					Header:      map[string][]string{"Content-Type": {"application/json"}},
+					Body:        []byte(`{"ID":1}`),
+				}, false, nil)
+			},
+			want: BeginIdempotentRequestOutput{
+				Replayed:   true,
+				StatusCode: 201,
+				Header:     map[string][]string{"Content-Type": {"application/json"}},
+				Body:       []byte(`{"ID":1}`),
+			},
+		},
+		{
+			name: "fail reusing a key with another request",
+			args: args{
+				ctx:   ctx,
+				input: input,
+			},
+			beforeTest: func(idempotencyDatabase *mock.MockIdempotencyDatabaseGateway) {
+				idempotencyDatabase.EXPECT().ReserveIdempotencyKey(gomock.Any(), reservation).Return(&entities.IdempotencyRecord{
+					Key:         "key",
+					Fingerprint: "another fingerprint",
+					Completed:   true,
+				}, false, nil)
+			},
+			wantErr: true,
+			err:     domain.ErrIdempotencyKeyReused,
+		},
+		{
+			name: "fail reusing a key while its request is in progress",
+			args: args{
+				ctx:   ctx,
+				input: input,
+			},
+			beforeTest: func(idempotencyDatabase *mock.MockIdempotencyDatabaseGateway) {
+				idempotencyDatabase.EXPECT().ReserveIdempotencyKey(gomock.Any(), reservation).Return(&entities.IdempotencyRecord{
+					Key:         "key",
+					Fingerprint: "fingerprint",
+				}, false, nil)
+			},
+			wantErr: true,
+			err:     domain.ErrIdempotencyKeyInProgress,
+		},
+		{
+			name: "fail reserving an empty key",
+			args: args{
+				ctx:   ctx,
+				input: &BeginIdempotentRequestInput{Fingerprint: "fingerprint"},
+			},
+			beforeTest: nil,
+			wantErr:    true,
+			err:        errors.New("input is invalid: the field 'Key' should not be empty; "),
+		},
+		{
+			name: "fail reserving a key when database fails",
+			args: args{
+				ctx:   ctx,
+				input: input,
+			},
+			beforeTest: func(idempotencyDatabase *mock.MockIdempotencyDatabaseGateway) {
+				idempotencyDatabase.EXPECT().ReserveIdempotencyKey(gomock.Any(), reservation).Return(nil, false, domain.ErrDeadlock)
+			},
+			wantErr: true,
+			err:     errors.New("failed to reserve idempotency key into database: deadlock detected"),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockIdempotencyDatabase := mock.NewMockIdempotencyDatabaseGateway(ctrl)
+
+			usecase := &beginIdempotentRequestUseCase{
+				idempotencyDatabase: mockIdempotencyDatabase,
+				ttl:                 24 * time.Hour,
+				lease:               time.Minute,
+				validator:           domain.NewValidatorService(),
+				now:                 func() time.Time { return now },
+			}
+
+			if tt.beforeTest != nil {
+				tt.beforeTest(mockIdempotencyDatabase)
+			}
+
+			began, err := usecase.Execute(tt.args.ctx, *tt.args.input)
+
+			if (err != nil) != tt.wantErr {
+				t.Errorf("beginIdempotentRequest.Execute() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if !reflect.DeepEqual(began, tt.want) {
+				t.Errorf("beginIdempotentRequest.Execute() = %v, want %v", began, tt.want)
+			}
+			if tt.wantErr {
+				if err.Error() != tt.err.Error() {
+					t.Errorf("beginIdempotentRequest.Execute() error = %v, wantErr %v", err, tt.err)
+					return
+				}
+			}
+		})
+	}
+}
@@ -0,0 +1,80 @@
+package usecases
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/lyracampos/go-clean-architecture/internal/domain"
+	"github.com/lyracampos/go-clean-architecture/internal/domain/entities"
+	"github.com/lyracampos/go-clean-architecture/internal/domain/ports"
+)
+
+const (
+	// serverErrorStatus is the first status code of server errors.
+	serverErrorStatus = 500
+	// clientClosedRequestStatus answers the requests whose client went away before they completed.
+	clientClosedRequestStatus = 499
+)
+
+var _ CompleteIdempotentRequestUseCase = (*completeIdempotentRequestUseCase)(nil)
+
+type CompleteIdempotentRequestUseCase interface {
+	Execute(ctx context.Context, input CompleteIdempotentRequestInput) error
+}
+
+type CompleteIdempotentRequestInput struct {
+	Key        string `validate:"required,max=255"`
+	StatusCode int    `validate:"required"`
+	Header     map[string][]string
+	Body       []byte
+	// ReservedAt is the reservation returned by BeginIdempotentRequestUseCase, so that a request
+	// that outlived its lease can't overwrite the response of the retry that reserved the key again
+	ReservedAt time.Time `validate:"required"`
+}
+
+type completeIdempotentRequestUseCase struct {
+	idempotencyDatabase ports.IdempotencyDatabaseGateway
+	validator           domain.Validator
+}
+
+func NewCompleteIdempotentRequestUseCase(idempotencyDatabase ports.IdempotencyDatabaseGateway, validator domain.Validator) *completeIdempotentRequestUseCase {
+	return &completeIdempotentRequestUseCase{
+		idempotencyDatabase: idempotencyDatabase,
+		validator:           validator,
+	}
+}
+
+// Execute stores the response of the request reserved with the key. Server errors and requests
+// the client gave up on are not stored but release the key instead, as retrying the request may succeed.
+func (u *completeIdempotentRequestUseCase) Execute(ctx context.Context, input CompleteIdempotentRequestInput) error {
+	err := u.validator.Validate(input)
+	if err != nil {
+		return fmt.Errorf("input is invalid: %w", err)
+	}
+
+	scope := idempotencyScope(ctx)
+
+	if input.StatusCode >= serverErrorStatus || input.StatusCode == clientClosedRequestStatus {
+		if err := u.idempotencyDatabase.ReleaseIdempotencyKey(ctx, scope, input.Key, input.ReservedAt); err != nil {
+			return fmt.Errorf("failed to release idempotency key from database: %w", err)
+		}
+
+		return nil
+	}
+
+	err = u.idempotencyDatabase.CompleteIdempotencyKey(ctx, &entities.IdempotencyRecord{
+		Scope:      scope,
+		Key:        input.Key,
+		Completed:  true,
+		StatusCode: input.StatusCode,
+		CreatedAt:  input.ReservedAt,
+		Header:     input.Header,
+		Body:       input.Body,
+	})
+	if err != nil {
+		return fmt.Errorf("failed to complete idempotency key into database: %w", err)
+	}
+
+	return nil
+}
@@ -0,0 +1,166 @@
+package usecases
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/lyracampos/go-clean-architecture/internal/domain"
+	"github.com/lyracampos/go-clean-architecture/internal/domain/entities"
+	mock "github.com/lyracampos/go-clean-architecture/internal/domain/ports/mocks"
+	"go.uber.org/mock/gomock"
+)
+
+func TestCompleteIdempotentRequestExecute(t *testing.T) {
+	ctx := adminContext()
+	reservedAt := time.Date(2024, 2, 1, 0, 0, 0, 0, time.UTC)
+
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	type args struct {
+		ctx   context.Context
+		input *CompleteIdempotentRequestInput
+	}
+	tests := []struct {
+		name       string
+		args       args
+		beforeTest func(idempotencyDatabase *mock.MockIdempotencyDatabaseGateway)
+		wantErr    bool
+		err        error
+	}{
+		{
+			name: "success storing the response",
+			args: args{
+				ctx:   ctx,
+				input: &CompleteIdempotentRequestInput{Key: "key", StatusCode: 201, Body: []byte(`{"ID":1}`), ReservedAt: reservedAt},
+			},
+			beforeTest: func(idempotencyDatabase *mock.MockIdempotencyDatabaseGateway) {
+				idempotencyDatabase.EXPECT().CompleteIdempotencyKey(gomock.Any(), &entities.IdempotencyRecord{
+					Scope:      "user:99",
+					Key:        "key",
+					Completed:  true,
+					StatusCode: 201,
+					Body:       []byte(`{"ID":1}`),
+					CreatedAt:  reservedAt,
+				}).Return(nil)
+			},
+		},
+		{
+			name: "success storing a client error response",
+			args: args{
+				ctx:   ctx,
+				input: &CompleteIdempotentRequestInput{Key: "key", StatusCode: 409, ReservedAt: reservedAt},
+			},
+			beforeTest: func(idempotencyDatabase *mock.MockIdempotencyDatabaseGateway) {
+				idempotencyDatabase.EXPECT().CompleteIdempotencyKey(gomock.Any(), gomock.Any()).Return(nil)
+			},
+		},
+		{
+			name: "success releasing the key of a server error response",
+			args: args{
+				ctx:   ctx,
+				input: &CompleteIdempotentRequestInput{Key: "key", StatusCode: 503, ReservedAt: reservedAt},
+			},
+			beforeTest: func(idempotencyDatabase *mock.MockIdempotencyDatabaseGateway) {
+				idempotencyDatabase.EXPECT().ReleaseIdempotencyKey(gomock.Any(), "user:99", "key", reservedAt).Return(nil)
+			},
+		},
+		{
+			name: "success releasing the key of a request the client gave up on",
+			args: args{
+				ctx:   ctx,
+				input: &CompleteIdempotentRequestInput{Key: "key", StatusCode: 499, ReservedAt: reservedAt},
+			},
+			beforeTest: func(idempotencyDatabase *mock.MockIdempotencyDatabaseGateway) {
+				idempotencyDatabase.EXPECT().ReleaseIdempotencyKey(gomock.Any(), "user:99", "key", reservedAt).Return(nil)
+			},
+		},
+		{
+			name: "fail storing the response without status code",
+			args: args{
+				ctx:   ctx,
+				input: &CompleteIdempotentRequestInput{Key: "key", ReservedAt: reservedAt},
+			},
+			beforeTest: nil,
+			wantErr:    true,
+			err:        errors.New("input is invalid: the field 'StatusCode' should not be empty; "),
+		},
+		{
+			name: "fail storing the response without reservation",
+			args: args{
+				ctx:   ctx,
+				input: &CompleteIdempotentRequestInput{Key: "key", StatusCode: 201},
+			},
+			beforeTest: nil,
+			wantErr:    true,
+			err:        errors.New("input is invalid: the field 'ReservedAt' should not be empty; "),
+		},
+		{
+			name: "fail storing the response of a reservation replaced meanwhile",
+			args: args{
+				ctx:   ctx,
+				input: &CompleteIdempotentRequestInput{Key: "key", StatusCode: 201, ReservedAt: reservedAt},
+			},
+			beforeTest: func(idempotencyDatabase *mock.MockIdempotencyDatabaseGateway) {
+				idempotencyDatabase.EXPECT().CompleteIdempotencyKey(gomock.Any(), gomock.Any()).Return(domain.ErrIdempotencyKeyDoesNotExist)
+			},
+			wantErr: true,
+			err:     fmt.Errorf("failed to complete idempotency key into database: %w", domain.ErrIdempotencyKeyDoesNotExist),
+		},
+		{
+			name: "fail storing the response when database fails",
+			args: args{
+				ctx:   ctx,
+				input: &CompleteIdempotentRequestInput{Key: "key", StatusCode: 201, ReservedAt: reservedAt},
+			},
+			beforeTest: func(idempotencyDatabase *mock.MockIdempotencyDatabaseGateway) {
+				idempotencyDatabase.EXPECT().CompleteIdempotencyKey(gomock.Any(), gomock.Any()).Return(domain.ErrDeadlock)
+			},
+			wantErr: true,
+			err:     errors.New("failed to complete idempotency key into database: deadlock detected"),
+		},
+		{
+			name: "fail releasing the key when database fails",
+			args: args{
+				ctx:   ctx,
+				input: &CompleteIdempotentRequestInput{Key: "key", StatusCode: 500, ReservedAt: reservedAt},
+			},
+			beforeTest: func(idempotencyDatabase *mock.MockIdempotencyDatabaseGateway) {
+				idempotencyDatabase.EXPECT().ReleaseIdempotencyKey(gomock.Any(), "user:99", "key", reservedAt).Return(domain.ErrDeadlock)
+			},
+			wantErr: true,
+			err:     errors.New("failed to release idempotency key from database: deadlock detected"),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockIdempotencyDatabase := mock.NewMockIdempotencyDatabaseGateway(ctrl)
+
+			usecase := &completeIdempotentRequestUseCase{
+				idempotencyDatabase: mockIdempotencyDatabase,
+				validator:           domain.NewValidatorService(),
+			}
+
+			if tt.beforeTest != nil {
+				tt.beforeTest(mockIdempotencyDatabase)
+			}
+
+			err := usecase.Execute(tt.args.ctx, *tt.args.input)
+
+			if (err != nil) != tt.wantErr {
+				t.Errorf("completeIdempotentRequest.Execute() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if tt.wantErr {
+				if err.Error() != tt.err.Error() {
+					t.Errorf("completeIdempotentRequest.Execute() error = %v, wantErr %v", err, tt.err)
+					return
+				}
+			}
+		})
+	}
+}
@@ -0,0 +1,102 @@
+package usecases
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/lyracampos/go-clean-architecture/internal/domain"
+	"github.com/lyracampos/go-clean-architecture/internal/domain/entities"
+	"github.com/lyracampos/go-clean-architecture/internal/domain/ports"
+)
+
+var _ CreateAPIKeyUseCase = (*createAPIKeyUseCase)(nil)
+
+type CreateAPIKeyUseCase interface {
+	Execute(ctx context.Context, input CreateAPIKeyInput) (CreateAPIKeyOutput, error)
+}
+
+// swagger:model
+type CreateAPIKeyInput struct {
+	// name describing who uses the key
+	//
+	// required: true
+	Name string `validate:"required,max=100"`
+	// permissions granted to the key, within the ones of its creator
+	//
+	// required: true
+	Scopes []string `validate:"required,min=1,dive,oneof=users:read users:create users:write users:delete users:change_role"`
+	// when the key stops being accepted, it never expires when empty
+	ExpiresAt *time.Time `validate:"omitempty,gt"`
+}
+
+type CreateAPIKeyOutput struct {
+	ID     int64
+	Name   string
+	Prefix string
+	// Key is only returned once, it can't be retrieved later
+	Key       string
+	Scopes    []string
+	ExpiresAt *time.Time
+	CreatedAt time.Time
+}
+
+type createAPIKeyUseCase struct {
+	apiKeyDatabase ports.APIKeyDatabaseGateway
+	apiKeyService  ports.APIKeyService
+	validator      domain.Validator
+}
+
+func NewCreateAPIKeyUseCase(
+	apiKeyDatabase ports.APIKeyDatabaseGateway,
+	apiKeyService ports.APIKeyService,
+	validator domain.Validator,
+) *createAPIKeyUseCase {
+	return &createAPIKeyUseCase{
+		apiKeyDatabase: apiKeyDatabase,
+		apiKeyService:  apiKeyService,
+		validator:      validator,
+	}
+}
+
+// Execute creates a key on behalf of the caller. A key can't be granted
+// a permission its creator doesn't have.
+func (u *createAPIKeyUseCase) Execute(ctx context.Context, input CreateAPIKeyInput) (CreateAPIKeyOutput, error) {
+	caller, _, err := domain.RequirePermission(ctx, domain.PermissionAPIKeysManage)
+	if err != nil {
+		return CreateAPIKeyOutput{}, err
+	}
+
+	err = u.validator.Validate(input)
+	if err != nil {
+		return CreateAPIKeyOutput{}, fmt.Errorf("input is invalid: %w", err)
+	}
+
+	for _, scope := range input.Scopes {
+		if caller.Scope(domain.Permission(scope)) == 0 {
+			return CreateAPIKeyOutput{}, domain.ErrForbidden
+		}
+	}
+
+	issuedKey, err := u.apiKeyService.IssueAPIKey(ctx)
+	if err != nil {
+		return CreateAPIKeyOutput{}, fmt.Errorf("failed to issue api key: %w", err)
+	}
+
+	apiKey := entities.NewAPIKey(caller.UserID, input.Name, issuedKey.Prefix, issuedKey.Hash, input.Scopes, input.ExpiresAt)
+
+	apiKey, err = u.apiKeyDatabase.InsertAPIKey(ctx, apiKey)
+	if err != nil {
+		return CreateAPIKeyOutput{}, fmt.Errorf("failed to create api key into database: %w", err)
+	}
+
+	return CreateAPIKeyOutput{
+		ID:        apiKey.ID,
+		Name:      apiKey.Name,
+		Prefix:    apiKey.Prefix,
+		Key:       issuedKey.Key,
+		Scopes:    apiKey.Scopes,
+		ExpiresAt: apiKey.ExpiresAt,
+		CreatedAt: apiKey.CreatedAt,
+	}, nil
+}
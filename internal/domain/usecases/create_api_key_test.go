@@ -0,0 +1,179 @@
+package usecases
+
+import (
+	"context"
+	"errors"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/lyracampos/go-clean-architecture/internal/domain"
+	"github.com/lyracampos/go-clean-architecture/internal/domain/entities"
+	"github.com/lyracampos/go-clean-architecture/internal/domain/ports"
+	mock "github.com/lyracampos/go-clean-architecture/internal/domain/ports/mocks"
+	"go.uber.org/mock/gomock"
+)
+
+func TestCreateAPIKeyExecute(t *testing.T) {
+	ctx := adminContext()
+	createdAt := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	pastExpiry := time.Now().Add(-time.Hour)
+
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	type args struct {
+		ctx   context.Context
+		input *CreateAPIKeyInput
+	}
+	tests := []struct {
+		name       string
+		args       args
+		beforeTest func(apiKeyDatabase *mock.MockAPIKeyDatabaseGateway, apiKeyService *mock.MockAPIKeyService)
+		want       CreateAPIKeyOutput
+		wantErr    bool
+		err        error
+	}{
+		{
+			name: "success creating api key",
+			args: args{
+				ctx:   ctx,
+				input: &CreateAPIKeyInput{Name: "batch", Scopes: []string{"users:read"}},
+			},
+			beforeTest: func(apiKeyDatabase *mock.MockAPIKeyDatabaseGateway, apiKeyService *mock.MockAPIKeyService) {
+				apiKeyService.EXPECT().IssueAPIKey(gomock.Any()).Return(defaultIssuedAPIKey(), nil)
+				apiKeyDatabase.EXPECT().InsertAPIKey(gomock.Any(), &entities.APIKey{
+					UserID:  99,
+					Name:    "batch",
+					Prefix:  "prefix",
+					KeyHash: "keyHash",
+					Scopes:  []string{"users:read"},
+				}).Return(&entities.APIKey{
+					ID:        1,
+					UserID:    99,
+					Name:      "batch",
+					Prefix:    "prefix",
+					KeyHash:   "keyHash",
+					Scopes:    []string{"users:read"},
+					CreatedAt: createdAt,
+				}, nil)
+			},
+			want: CreateAPIKeyOutput{
+				ID:        1,
+				Name:      "batch",
+				Prefix:    "prefix",
+				Key:       "gca_prefix_secret",
+				Scopes:    []string{"users:read"},
+				CreatedAt: createdAt,
+			},
+		},
+		{
+			name: "fail creating api key with empty name",
+			args: args{
+				ctx:   ctx,
+				input: &CreateAPIKeyInput{Scopes: []string{"users:read"}},
+			},
+			beforeTest: nil,
+			wantErr:    true,
+			err:        errors.New("input is invalid: the field 'Name' should not be empty; "),
+		},
+		{
+			name: "fail creating api key with unknown scope",
+			args: args{
+				ctx:   ctx,
+				input: &CreateAPIKeyInput{Name: "batch", Scopes: []string{"api_keys:manage"}},
+			},
+			beforeTest: nil,
+			wantErr:    true,
+			err:        errors.New("input is invalid: the field 'Scopes[0]' is not valid, expected one of [users:read users:create users:write users:delete users:change_role]; "),
+		},
+		{
+			name: "fail creating api key already expired",
+			args: args{
+				ctx:   ctx,
+				input: &CreateAPIKeyInput{Name: "batch", Scopes: []string{"users:read"}, ExpiresAt: &pastExpiry},
+			},
+			beforeTest: nil,
+			wantErr:    true,
+			err:        errors.New("input is invalid: the field 'ExpiresAt' is invalid; "),
+		},
+		{
+			name: "fail creating api key as contributor",
+			args: args{
+				ctx:   contributorContext(1),
+				input: &CreateAPIKeyInput{Name: "batch", Scopes: []string{"users:read"}},
+			},
+			beforeTest: nil,
+			wantErr:    true,
+			err:        domain.ErrForbidden,
+		},
+		{
+			name: "fail creating api key with a scope the caller doesn't have",
+			args: args{
+				ctx: domain.WithCaller(context.Background(), domain.Caller{
+					UserID: 99,
+					Role:   domain.RoleAdmin,
+					Scopes: []domain.Permission{domain.PermissionAPIKeysManage},
+				}),
+				input: &CreateAPIKeyInput{Name: "batch", Scopes: []string{"users:delete"}},
+			},
+			beforeTest: nil,
+			wantErr:    true,
+			err:        domain.ErrForbidden,
+		},
+		{
+			name: "fail creating api key when insert fails",
+			args: args{
+				ctx:   ctx,
+				input: &CreateAPIKeyInput{Name: "batch", Scopes: []string{"users:read"}},
+			},
+			beforeTest: func(apiKeyDatabase *mock.MockAPIKeyDatabaseGateway, apiKeyService *mock.MockAPIKeyService) {
+				apiKeyService.EXPECT().IssueAPIKey(gomock.Any()).Return(defaultIssuedAPIKey(), nil)
+				apiKeyDatabase.EXPECT().InsertAPIKey(gomock.Any(), gomock.Any()).Return(nil, domain.ErrUniqueViolation)
+			},
+			wantErr: true,
+			err:     errors.New("failed to create api key into database: unique constraint violated"),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockAPIKeyDatabase := mock.NewMockAPIKeyDatabaseGateway(ctrl)
+			mockAPIKeyService := mock.NewMockAPIKeyService(ctrl)
+
+			usecase := &createAPIKeyUseCase{
+				apiKeyDatabase: mockAPIKeyDatabase,
+				apiKeyService:  mockAPIKeyService,
+				validator:      domain.NewValidatorService(),
+			}
+
+			if tt.beforeTest != nil {
+				tt.beforeTest(mockAPIKeyDatabase, mockAPIKeyService)
+			}
+
+			createdAPIKey, err := usecase.Execute(tt.args.ctx, *tt.args.input)
+
+			if (err != nil) != tt.wantErr {
+				t.Errorf("createAPIKey.Execute() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if !reflect.DeepEqual(createdAPIKey, tt.want) {
+				t.Errorf("createAPIKey.Execute() = %v, want %v", createdAPIKey, tt.want)
+			}
+			if tt.wantErr {
+				if err.Error() != tt.err.Error() {
+					t.Errorf("createAPIKey.Execute() error = %v, wantErr %v", err, tt.err)
+					return
+				}
+			}
+		})
+	}
+}
+
+func defaultIssuedAPIKey() ports.APIKey {
+	return ports.APIKey{
+		Key:    "gca_prefix_secret",
+		Prefix: "prefix",
+		Hash:   "keyHash",
+	}
+}
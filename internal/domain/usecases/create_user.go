@@ -78,6 +78,7 @@ type CreateUserOutput struct {
 	Email     string
 	Role      string
 	CreatedAt time.Time
+	Version   int64
 }
 
 type createUserUseCase struct {
@@ -93,6 +94,10 @@ func NewCreateUserUseCase(userDatabase ports.UserDatabaseGateway, validator doma
 }
 
 func (u *createUserUseCase) Execute(ctx context.Context, input CreateUserInput) (CreateUserOutput, error) {
+	if _, _, err := domain.RequirePermission(ctx, domain.PermissionUsersCreate); err != nil {
+		return CreateUserOutput{}, err
+	}
+
 	err := u.validator.Validate(input)
 	if err != nil {
 		return CreateUserOutput{}, fmt.Errorf("input is invalid: %w", err)
@@ -120,5 +125,6 @@ func (u *createUserUseCase) fromEntityToOutput(user *entities.User) CreateUserOu
 		Email:     user.Email,
 		Role:      user.Role,
 		CreatedAt: user.CreatedAt,
+		Version:   user.Version,
 	}
 }
@@ -14,7 +14,7 @@ import (
 )
 
 func TestCreateUserExecute(t *testing.T) {
-	ctx := context.Background()
+	ctx := adminContext()
 
 	ctrl := gomock.NewController(t)
 	defer ctrl.Finish()
@@ -117,6 +117,16 @@ func TestCreateUserExecute(t *testing.T) {
 			wantErr:    true,
 			err:        errors.New("input is invalid: the field 'Role' is not valid, expected one of [admin contributor]; "),
 		},
+		{
+			name: "fail creating new user as contributor",
+			args: args{
+				ctx:   contributorContext(1),
+				input: defaultCreateUserInput(),
+			},
+			beforeTest: nil,
+			wantErr:    true,
+			err:        domain.ErrForbidden,
+		},
 	}
 
 	for _, tt := range tests {
@@ -0,0 +1,62 @@
+package usecases
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/lyracampos/go-clean-architecture/internal/domain"
+	"github.com/lyracampos/go-clean-architecture/internal/domain/entities"
+	"github.com/lyracampos/go-clean-architecture/internal/domain/ports"
+)
+
+var _ CreateWebhookUseCase = (*createWebhookUseCase)(nil)
+
+type CreateWebhookUseCase interface {
+	Execute(ctx context.Context, input CreateWebhookInput) (WebhookOutput, error)
+}
+
+// swagger:model
+type CreateWebhookInput struct {
+	// URL the events are posted to
+	//
+	// required: true
+	URL string `validate:"required,http_url"`
+	// event types the webhook is subscribed to [user.created, user.updated, user.deleted, user.role_changed]
+	//
+	// required: true
+	EventTypes []string `validate:"required,min=1,dive,oneof=user.created user.updated user.deleted user.role_changed"`
+	// secret the X-Signature header is computed with, it is never returned
+	//
+	// required: true
+	Secret string `validate:"required,min=16,max=256"`
+}
+
+type createWebhookUseCase struct {
+	webhookDatabase ports.WebhookDatabaseGateway
+	validator       domain.Validator
+}
+
+func NewCreateWebhookUseCase(webhookDatabase ports.WebhookDatabaseGateway, validator domain.Validator) *createWebhookUseCase {
+	return &createWebhookUseCase{
+		webhookDatabase: webhookDatabase,
+		validator:       validator,
+	}
+}
+
+func (u *createWebhookUseCase) Execute(ctx context.Context, input CreateWebhookInput) (WebhookOutput, error) {
+	if _, _, err := domain.RequirePermission(ctx, domain.PermissionWebhooksManage); err != nil {
+		return WebhookOutput{}, err
+	}
+
+	err := u.validator.Validate(input)
+	if err != nil {
+		return WebhookOutput{}, fmt.Errorf("input is invalid: %w", err)
+	}
+
+	webhook, err := u.webhookDatabase.InsertWebhook(ctx, entities.NewWebhook(input.URL, input.EventTypes, input.Secret))
+	if err != nil {
+		return WebhookOutput{}, fmt.Errorf("failed to create webhook into database: %w", err)
+	}
+
+	return newWebhookOutput(webhook), nil
+}
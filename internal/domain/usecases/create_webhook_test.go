@@ -0,0 +1,135 @@
+package usecases
+
+import (
+	"context"
+	"errors"
+	"reflect"
+	"testing"
+
+	"github.com/lyracampos/go-clean-architecture/internal/domain"
+	"github.com/lyracampos/go-clean-architecture/internal/domain/entities"
+	mock "github.com/lyracampos/go-clean-architecture/internal/domain/ports/mocks"
+	"go.uber.org/mock/gomock"
+)
+
+func TestCreateWebhookExecute(t *testing.T) {
+	ctx := adminContext()
+
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	type args struct {
+		ctx   context.Context
+		input *CreateWebhookInput
+	}
+	tests := []struct {
+		name       string
+		args       args
+		beforeTest func(webhookDatabase *mock.MockWebhookDatabaseGateway)
+		want       WebhookOutput
+		wantErr    bool
+		err        error
+	}{
+		{
+			name: "success creating enabled webhook",
+			args: args{
+				ctx:   ctx,
+				input: defaultCreateWebhookInput(),
+			},
+			beforeTest: func(webhookDatabase *mock.MockWebhookDatabaseGateway) {
+				webhookDatabase.EXPECT().InsertWebhook(gomock.Any(), entities.NewWebhook("https://example.com/hooks", []string{"user.created"}, "0123456789abcdef")).
+					Return(defaultWebhook(), nil)
+			},
+			want: defaultWebhookOutput(),
+		},
+		{
+			name: "fail creating webhook with invalid url",
+			args: args{
+				ctx:   ctx,
+				input: &CreateWebhookInput{URL: "example.com", EventTypes: []string{"user.created"}, Secret: "0123456789abcdef"},
+			},
+			beforeTest: nil,
+			wantErr:    true,
+			err:        errors.New("input is invalid: the field 'URL' is invalid; "),
+		},
+		{
+			name: "fail creating webhook without event types",
+			args: args{
+				ctx:   ctx,
+				input: &CreateWebhookInput{URL: "https://example.com/hooks", Secret: "0123456789abcdef"},
+			},
+			beforeTest: nil,
+			wantErr:    true,
+			err:        errors.New("input is invalid: the field 'EventTypes' should not be empty; "),
+		},
+		{
+			name: "fail creating webhook with unknown event type",
+			args: args{
+				ctx:   ctx,
+				input: &CreateWebhookInput{URL: "https://example.com/hooks", EventTypes: []string{"user.exploded"}, Secret: "0123456789abcdef"},
+			},
+			beforeTest: nil,
+			wantErr:    true,
+			err:        errors.New("input is invalid: the field 'EventTypes[0]' is not valid, expected one of [user.created user.updated user.deleted user.role_changed]; "),
+		},
+		{
+			name: "fail creating webhook with short secret",
+			args: args{
+				ctx:   ctx,
+				input: &CreateWebhookInput{URL: "https://example.com/hooks", EventTypes: []string{"user.created"}, Secret: "secret"},
+			},
+			beforeTest: nil,
+			wantErr:    true,
+			err:        errors.New("input is invalid: the field 'Secret' min size is 16; "),
+		},
+		{
+			name: "fail creating webhook as contributor",
+			args: args{
+				ctx:   contributorContext(1),
+				input: defaultCreateWebhookInput(),
+			},
+			beforeTest: nil,
+			wantErr:    true,
+			err:        domain.ErrForbidden,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockWebhookDatabase := mock.NewMockWebhookDatabaseGateway(ctrl)
+
+			usecase := &createWebhookUseCase{
+				webhookDatabase: mockWebhookDatabase,
+				validator:       domain.NewValidatorService(),
+			}
+
+			if tt.beforeTest != nil {
+				tt.beforeTest(mockWebhookDatabase)
+			}
+
+			webhook, err := usecase.Execute(tt.args.ctx, *tt.args.input)
+
+			if (err != nil) != tt.wantErr {
+				t.Errorf("createWebhook.Execute() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if !reflect.DeepEqual(webhook, tt.want) {
+				t.Errorf("createWebhook.Execute() = %v, want %v", webhook, tt.want)
+			}
+			if tt.wantErr {
+				if err.Error() != tt.err.Error() {
+					t.Errorf("createWebhook.Execute() error = %v, wantErr %v", err, tt.err)
+					return
+				}
+			}
+		})
+	}
+}
+
+func defaultCreateWebhookInput() *CreateWebhookInput {
+	return &CreateWebhookInput{
+		URL:        "https://example.com/hooks",
+		EventTypes: []string{"user.created"},
+		Secret:     "0123456789abcdef",
+	}
+}
@@ -0,0 +1,42 @@
+package usecases
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/lyracampos/go-clean-architecture/internal/domain"
+	"github.com/lyracampos/go-clean-architecture/internal/domain/ports"
+)
+
+var _ DeleteAPIKeyUseCase = (*deleteAPIKeyUseCase)(nil)
+
+type DeleteAPIKeyUseCase interface {
+	Execute(ctx context.Context, input DeleteAPIKeyInput) error
+}
+
+type DeleteAPIKeyInput struct {
+	ID int64
+}
+
+type deleteAPIKeyUseCase struct {
+	apiKeyDatabase ports.APIKeyDatabaseGateway
+}
+
+func NewDeleteAPIKeyUseCase(apiKeyDatabase ports.APIKeyDatabaseGateway) *deleteAPIKeyUseCase {
+	return &deleteAPIKeyUseCase{
+		apiKeyDatabase: apiKeyDatabase,
+	}
+}
+
+// Execute revokes the key, requests using it are rejected right away.
+func (u *deleteAPIKeyUseCase) Execute(ctx context.Context, input DeleteAPIKeyInput) error {
+	if _, _, err := domain.RequirePermission(ctx, domain.PermissionAPIKeysManage); err != nil {
+		return err
+	}
+
+	if err := u.apiKeyDatabase.DeleteAPIKey(ctx, input.ID); err != nil {
+		return fmt.Errorf("failed to delete api key from database: %w", err)
+	}
+
+	return nil
+}
@@ -0,0 +1,90 @@
+package usecases
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/lyracampos/go-clean-architecture/internal/domain"
+	mock "github.com/lyracampos/go-clean-architecture/internal/domain/ports/mocks"
+	"go.uber.org/mock/gomock"
+)
+
+func TestDeleteAPIKeyExecute(t *testing.T) {
+	ctx := adminContext()
+
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	type args struct {
+		ctx   context.Context
+		input *DeleteAPIKeyInput
+	}
+	tests := []struct {
+		name       string
+		args       args
+		beforeTest func(apiKeyDatabase *mock.MockAPIKeyDatabaseGateway)
+		wantErr    bool
+		err        error
+	}{
+		{
+			name: "success deleting api key",
+			args: args{
+				ctx:   ctx,
+				input: &DeleteAPIKeyInput{ID: 1},
+			},
+			beforeTest: func(apiKeyDatabase *mock.MockAPIKeyDatabaseGateway) {
+				apiKeyDatabase.EXPECT().DeleteAPIKey(gomock.Any(), int64(1)).Return(nil)
+			},
+		},
+		{
+			name: "fail deleting api key when id not found",
+			args: args{
+				ctx:   ctx,
+				input: &DeleteAPIKeyInput{ID: 1},
+			},
+			beforeTest: func(apiKeyDatabase *mock.MockAPIKeyDatabaseGateway) {
+				apiKeyDatabase.EXPECT().DeleteAPIKey(gomock.Any(), int64(1)).Return(domain.ErrAPIKeyDoesNotExist)
+			},
+			wantErr: true,
+			err:     errors.New("failed to delete api key from database: api key does not exist"),
+		},
+		{
+			name: "fail deleting api key as contributor",
+			args: args{
+				ctx:   contributorContext(1),
+				input: &DeleteAPIKeyInput{ID: 1},
+			},
+			beforeTest: nil,
+			wantErr:    true,
+			err:        domain.ErrForbidden,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockAPIKeyDatabase := mock.NewMockAPIKeyDatabaseGateway(ctrl)
+
+			usecase := &deleteAPIKeyUseCase{
+				apiKeyDatabase: mockAPIKeyDatabase,
+			}
+
+			if tt.beforeTest != nil {
+				tt.beforeTest(mockAPIKeyDatabase)
+			}
+
+			err := usecase.Execute(tt.args.ctx, *tt.args.input)
+
+			if (err != nil) != tt.wantErr {
+				t.Errorf("deleteAPIKey.Execute() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if tt.wantErr {
+				if err.Error() != tt.err.Error() {
+					t.Errorf("deleteAPIKey.Execute() error = %v, wantErr %v", err, tt.err)
+					return
+				}
+			}
+		})
+	}
+}
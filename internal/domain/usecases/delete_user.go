@@ -0,0 +1,43 @@
+package usecases
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/lyracampos/go-clean-architecture/internal/domain"
+	"github.com/lyracampos/go-clean-architecture/internal/domain/ports"
+)
+
+var _ DeleteUserUseCase = (*deleteUserUseCase)(nil)
+
+type DeleteUserUseCase interface {
+	Execute(ctx context.Context, input DeleteUserInput) error
+}
+
+type DeleteUserInput struct {
+	ID int64
+	// Versions are the user versions the deletion may apply to, entities.AnyVersion skips the check.
+	Versions []int64
+}
+
+type deleteUserUseCase struct {
+	userDatabaseGateway ports.UserDatabaseGateway
+}
+
+func NewDeleteUserUseCase(userDatabaseGateway ports.UserDatabaseGateway) *deleteUserUseCase {
+	return &deleteUserUseCase{
+		userDatabaseGateway: userDatabaseGateway,
+	}
+}
+
+func (u *deleteUserUseCase) Execute(ctx context.Context, input DeleteUserInput) error {
+	if err := domain.Authorize(ctx, domain.PermissionUsersDelete, input.ID); err != nil {
+		return err
+	}
+
+	if err := u.userDatabaseGateway.DeleteUser(ctx, input.ID, input.Versions); err != nil {
+		return fmt.Errorf("failed to delete user from database: %w", err)
+	}
+
+	return nil
+}
@@ -0,0 +1,103 @@
+package usecases
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/lyracampos/go-clean-architecture/internal/domain"
+	mock "github.com/lyracampos/go-clean-architecture/internal/domain/ports/mocks"
+	"go.uber.org/mock/gomock"
+)
+
+func TestDeleteUserExecute(t *testing.T) {
+	ctx := adminContext()
+
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	type args struct {
+		ctx   context.Context
+		input *DeleteUserInput
+	}
+	tests := []struct {
+		name       string
+		args       args
+		beforeTest func(userDatabase *mock.MockUserDatabaseGateway)
+		wantErr    bool
+		err        error
+	}{
+		{
+			name: "success deleting user",
+			args: args{
+				ctx:   ctx,
+				input: &DeleteUserInput{ID: 1, Versions: []int64{2}},
+			},
+			beforeTest: func(userDatabase *mock.MockUserDatabaseGateway) {
+				userDatabase.EXPECT().DeleteUser(gomock.Any(), int64(1), []int64{2}).Return(nil)
+			},
+			wantErr: false,
+		},
+		{
+			name: "fail deleting user when id not found",
+			args: args{
+				ctx:   ctx,
+				input: &DeleteUserInput{ID: 1, Versions: []int64{2}},
+			},
+			beforeTest: func(userDatabase *mock.MockUserDatabaseGateway) {
+				userDatabase.EXPECT().DeleteUser(gomock.Any(), int64(1), []int64{2}).Return(domain.ErrUserDoesNotExist)
+			},
+			wantErr: true,
+			err:     errors.New("failed to delete user from database: user does not exist"),
+		},
+		{
+			name: "fail deleting user when version is stale",
+			args: args{
+				ctx:   ctx,
+				input: &DeleteUserInput{ID: 1, Versions: []int64{2}},
+			},
+			beforeTest: func(userDatabase *mock.MockUserDatabaseGateway) {
+				userDatabase.EXPECT().DeleteUser(gomock.Any(), int64(1), []int64{2}).Return(domain.ErrVersionConflict)
+			},
+			wantErr: true,
+			err:     errors.New("failed to delete user from database: user was changed by another request"),
+		},
+		{
+			name: "fail deleting user as contributor",
+			args: args{
+				ctx:   contributorContext(1),
+				input: &DeleteUserInput{ID: 1, Versions: []int64{2}},
+			},
+			beforeTest: nil,
+			wantErr:    true,
+			err:        domain.ErrForbidden,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockUserDatabase := mock.NewMockUserDatabaseGateway(ctrl)
+
+			usecase := &deleteUserUseCase{
+				userDatabaseGateway: mockUserDatabase,
+			}
+
+			if tt.beforeTest != nil {
+				tt.beforeTest(mockUserDatabase)
+			}
+
+			err := usecase.Execute(tt.args.ctx, *tt.args.input)
+
+			if (err != nil) != tt.wantErr {
+				t.Errorf("deleteUser.Execute() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if tt.wantErr {
+				if err.Error() != tt.err.Error() {
+					t.Errorf("deleteUser.Execute() error = %v, wantErr %v", err, tt.err)
+					return
+				}
+			}
+		})
+	}
+}
@@ -0,0 +1,42 @@
+package usecases
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/lyracampos/go-clean-architecture/internal/domain"
+	"github.com/lyracampos/go-clean-architecture/internal/domain/ports"
+)
+
+var _ DeleteWebhookUseCase = (*deleteWebhookUseCase)(nil)
+
+type DeleteWebhookUseCase interface {
+	Execute(ctx context.Context, input DeleteWebhookInput) error
+}
+
+type DeleteWebhookInput struct {
+	ID int64
+}
+
+type deleteWebhookUseCase struct {
+	webhookDatabase ports.WebhookDatabaseGateway
+}
+
+func NewDeleteWebhookUseCase(webhookDatabase ports.WebhookDatabaseGateway) *deleteWebhookUseCase {
+	return &deleteWebhookUseCase{
+		webhookDatabase: webhookDatabase,
+	}
+}
+
+// Execute deletes the webhook along with its pending deliveries and delivery log.
+func (u *deleteWebhookUseCase) Execute(ctx context.Context, input DeleteWebhookInput) error {
+	if _, _, err := domain.RequirePermission(ctx, domain.PermissionWebhooksManage); err != nil {
+		return err
+	}
+
+	if err := u.webhookDatabase.DeleteWebhook(ctx, input.ID); err != nil {
+		return fmt.Errorf("failed to delete webhook from database: %w", err)
+	}
+
+	return nil
+}
@@ -0,0 +1,90 @@
+package usecases
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/lyracampos/go-clean-architecture/internal/domain"
+	mock "github.com/lyracampos/go-clean-architecture/internal/domain/ports/mocks"
+	"go.uber.org/mock/gomock"
+)
+
+func TestDeleteWebhookExecute(t *testing.T) {
+	ctx := adminContext()
+
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	type args struct {
+		ctx   context.Context
+		input *DeleteWebhookInput
+	}
+	tests := []struct {
+		name       string
+		args       args
+		beforeTest func(webhookDatabase *mock.MockWebhookDatabaseGateway)
+		wantErr    bool
+		err        error
+	}{
+		{
+			name: "success deleting webhook",
+			args: args{
+				ctx:   ctx,
+				input: &DeleteWebhookInput{ID: 1},
+			},
+			beforeTest: func(webhookDatabase *mock.MockWebhookDatabaseGateway) {
+				webhookDatabase.EXPECT().DeleteWebhook(gomock.Any(), int64(1)).Return(nil)
+			},
+		},
+		{
+			name: "fail deleting webhook when id not found",
+			args: args{
+				ctx:   ctx,
+				input: &DeleteWebhookInput{ID: 1},
+			},
+			beforeTest: func(webhookDatabase *mock.MockWebhookDatabaseGateway) {
+				webhookDatabase.EXPECT().DeleteWebhook(gomock.Any(), int64(1)).Return(domain.ErrWebhookDoesNotExist)
+			},
+			wantErr: true,
+			err:     errors.New("failed to delete webhook from database: webhook does not exist"),
+		},
+		{
+			name: "fail deleting webhook as contributor",
+			args: args{
+				ctx:   contributorContext(1),
+				input: &DeleteWebhookInput{ID: 1},
+			},
+			beforeTest: nil,
+			wantErr:    true,
+			err:        domain.ErrForbidden,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockWebhookDatabase := mock.NewMockWebhookDatabaseGateway(ctrl)
+
+			usecase := &deleteWebhookUseCase{
+				webhookDatabase: mockWebhookDatabase,
+			}
+
+			if tt.beforeTest != nil {
+				tt.beforeTest(mockWebhookDatabase)
+			}
+
+			err := usecase.Execute(tt.args.ctx, *tt.args.input)
+
+			if (err != nil) != tt.wantErr {
+				t.Errorf("deleteWebhook.Execute() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if tt.wantErr {
+				if err.Error() != tt.err.Error() {
+					t.Errorf("deleteWebhook.Execute() error = %v, wantErr %v", err, tt.err)
+					return
+				}
+			}
+		})
+	}
+}
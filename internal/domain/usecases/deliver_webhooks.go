@@ -0,0 +1,125 @@
+package usecases
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/lyracampos/go-clean-architecture/internal/domain"
+	"github.com/lyracampos/go-clean-architecture/internal/domain/entities"
+	"github.com/lyracampos/go-clean-architecture/internal/domain/ports"
+)
+
+var _ DeliverWebhooksUseCase = (*deliverWebhooksUseCase)(nil)
+
+type DeliverWebhooksUseCase interface {
+	Execute(ctx context.Context, input DeliverWebhooksInput) (DeliverWebhooksOutput, error)
+}
+
+type DeliverWebhooksInput struct {
+	// BatchSize is the maximum number of deliveries posted at once
+	BatchSize int `validate:"min=1,max=1000"`
+	// Lease is how long the claimed deliveries are hidden from other workers
+	Lease time.Duration `validate:"required"`
+}
+
+type DeliverWebhooksOutput struct {
+	Claimed   int
+	Delivered int
+	Retried   int
+	Failed    int
+}
+
+type deliverWebhooksUseCase struct {
+	webhookDatabase ports.WebhookDatabaseGateway
+	webhookSender   ports.WebhookSender
+	retryPolicy     RetryPolicy
+	// disableAfter is the number of failed deliveries in a row that disables a webhook
+	disableAfter int
+	validator    domain.Validator
+	now          func() time.Time
+}
+
+func NewDeliverWebhooksUseCase(
+	webhookDatabase ports.WebhookDatabaseGateway,
+	webhookSender ports.WebhookSender,
+	retryPolicy RetryPolicy,
+	disableAfter int,
+	validator domain.Validator,
+) *deliverWebhooksUseCase {
+	return &deliverWebhooksUseCase{
+		webhookDatabase: webhookDatabase,
+		webhookSender:   webhookSender,
+		retryPolicy:     retryPolicy,
+		disableAfter:    disableAfter,
+		validator:       validator,
+		now:             time.Now,
+	}
+}
+
+// Execute posts a batch of pending deliveries and logs every attempt. Failed deliveries
+// are retried with exponential backoff until the retry policy gives them up.
+func (u *deliverWebhooksUseCase) Execute(ctx context.Context, input DeliverWebhooksInput) (DeliverWebhooksOutput, error) {
+	err := u.validator.Validate(input)
+	if err != nil {
+		return DeliverWebhooksOutput{}, fmt.Errorf("input is invalid: %w", err)
+	}
+
+	deliveries, err := u.webhookDatabase.ClaimWebhookDeliveries(ctx, input.BatchSize, input.Lease)
+	if err != nil {
+		return DeliverWebhooksOutput{}, fmt.Errorf("failed to claim webhook deliveries from database: %w", err)
+	}
+
+	output := DeliverWebhooksOutput{Claimed: len(deliveries)}
+
+	for _, delivery := range deliveries {
+		outcome := u.deliver(ctx, delivery)
+
+		switch {
+		case outcome.Attempt.Succeeded:
+			output.Delivered++
+		case outcome.RetryIn > 0:
+			output.Retried++
+		default:
+			output.Failed++
+		}
+
+		if err := u.webhookDatabase.RecordWebhookDeliveryAttempt(ctx, outcome); err != nil {
+			return output, fmt.Errorf("failed to record webhook delivery %d into database: %w", delivery.ID, err)
+		}
+	}
+
+	return output, nil
+}
+
+func (u *deliverWebhooksUseCase) deliver(ctx context.Context, delivery *entities.WebhookDelivery) ports.WebhookDeliveryOutcome {
+	attemptedAt := u.now()
+	statusCode, err := u.webhookSender.Send(ctx, delivery)
+
+	attempt := &entities.WebhookDeliveryAttempt{
+		DeliveryID:  delivery.ID,
+		WebhookID:   delivery.WebhookID,
+		EventID:     delivery.EventID,
+		EventType:   delivery.EventType,
+		Attempt:     delivery.Attempts,
+		StatusCode:  statusCode,
+		Duration:    u.now().Sub(attemptedAt),
+		Succeeded:   err == nil,
+		AttemptedAt: attemptedAt,
+	}
+
+	outcome := ports.WebhookDeliveryOutcome{
+		Attempt:      attempt,
+		DisableAfter: u.disableAfter,
+	}
+
+	if err != nil {
+		attempt.Error = err.Error()
+
+		if !u.retryPolicy.Exhausted(delivery.Attempts) {
+			outcome.RetryIn = u.retryPolicy.Delay(delivery.Attempts)
+		}
+	}
+
+	return outcome
+}
@@ -0,0 +1,180 @@
+package usecases
+
+import (
+	"context"
+	"errors"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/lyracampos/go-clean-architecture/internal/domain"
+	"github.com/lyracampos/go-clean-architecture/internal/domain/entities"
+	"github.com/lyracampos/go-clean-architecture/internal/domain/ports"
+	mock "github.com/lyracampos/go-clean-architecture/internal/domain/ports/mocks"
+	"go.uber.org/mock/gomock"
+)
+
+func TestDeliverWebhooksExecute(t *testing.T) {
+	ctx := context.Background()
+	now := time.Date(2024, 2, 1, 0, 0, 0, 0, time.UTC)
+	input := &DeliverWebhooksInput{BatchSize: 10, Lease: 30 * time.Second}
+
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	delivery := func(attempts int) *entities.WebhookDelivery {
+		return &entities.WebhookDelivery{
+			ID:        1,
+			WebhookID: 2,
+			URL:       "https://example.com/hooks",
+			Secret:    "0123456789abcdef",
+			EventID:   "event",
+			EventType: "user.created",
+			Payload:   []byte(`{"id":"event"}`),
+			Attempts:  attempts,
+		}
+	}
+	outcome := func(attempt int, statusCode int, failure string, retryIn time.Duration) ports.WebhookDeliveryOutcome {
+		return ports.WebhookDeliveryOutcome{
+			Attempt: &entities.WebhookDeliveryAttempt{
+				DeliveryID:  1,
+				WebhookID:   2,
+				EventID:     "event",
+				EventType:   "user.created",
+				Attempt:     attempt,
+				StatusCode:  statusCode,
+				Error:       failure,
+				Succeeded:   failure == "",
+				AttemptedAt: now,
+			},
+			RetryIn:      retryIn,
+			DisableAfter: 10,
+		}
+	}
+
+	type args struct {
+		ctx   context.Context
+		input *DeliverWebhooksInput
+	}
+	tests := []struct {
+		name       string
+		args       args
+		beforeTest func(webhookDatabase *mock.MockWebhookDatabaseGateway, webhookSender *mock.MockWebhookSender)
+		want       DeliverWebhooksOutput
+		wantErr    bool
+		err        error
+	}{
+		{
+			name: "success delivering webhook",
+			args: args{
+				ctx:   ctx,
+				input: input,
+			},
+			beforeTest: func(webhookDatabase *mock.MockWebhookDatabaseGateway, webhookSender *mock.MockWebhookSender) {
+				webhookDatabase.EXPECT().ClaimWebhookDeliveries(gomock.Any(), 10, 30*time.Second).Return([]*entities.WebhookDelivery{delivery(1)}, nil)
+				webhookSender.EXPECT().Send(gomock.Any(), delivery(1)).Return(204, nil)
+				webhookDatabase.EXPECT().RecordWebhookDeliveryAttempt(gomock.Any(), outcome(1, 204, "", 0)).Return(nil)
+			},
+			want: DeliverWebhooksOutput{Claimed: 1, Delivered: 1},
+		},
+		{
+			name: "success scheduling a retry with backoff when the receiver fails",
+			args: args{
+				ctx:   ctx,
+				input: input,
+			},
+			beforeTest: func(webhookDatabase *mock.MockWebhookDatabaseGateway, webhookSender *mock.MockWebhookSender) {
+				webhookDatabase.EXPECT().ClaimWebhookDeliveries(gomock.Any(), 10, 30*time.Second).Return([]*entities.WebhookDelivery{delivery(3)}, nil)
+				webhookSender.EXPECT().Send(gomock.Any(), delivery(3)).Return(500, errors.New("webhook answered with status 500"))
+				webhookDatabase.EXPECT().RecordWebhookDeliveryAttempt(gomock.Any(), outcome(3, 500, "webhook answered with status 500", 4*time.Second)).Return(nil)
+			},
+			want: DeliverWebhooksOutput{Claimed: 1, Retried: 1},
+		},
+		{
+			name: "success giving up a delivery out of attempts",
+			args: args{
+				ctx:   ctx,
+				input: input,
+			},
+			beforeTest: func(webhookDatabase *mock.MockWebhookDatabaseGateway, webhookSender *mock.MockWebhookSender) {
+				webhookDatabase.EXPECT().ClaimWebhookDeliveries(gomock.Any(), 10, 30*time.Second).Return([]*entities.WebhookDelivery{delivery(5)}, nil)
+				webhookSender.EXPECT().Send(gomock.Any(), delivery(5)).Return(0, errors.New("connection refused"))
+				webhookDatabase.EXPECT().RecordWebhookDeliveryAttempt(gomock.Any(), outcome(5, 0, "connection refused", 0)).Return(nil)
+			},
+			want: DeliverWebhooksOutput{Claimed: 1, Failed: 1},
+		},
+		{
+			name: "fail delivering webhooks with invalid batch size",
+			args: args{
+				ctx:   ctx,
+				input: &DeliverWebhooksInput{BatchSize: 0, Lease: 30 * time.Second},
+			},
+			beforeTest: nil,
+			wantErr:    true,
+			err:        errors.New("input is invalid: the field 'BatchSize' min size is 1; "),
+		},
+		{
+			name: "fail delivering webhooks when claiming fails",
+			args: args{
+				ctx:   ctx,
+				input: input,
+			},
+			beforeTest: func(webhookDatabase *mock.MockWebhookDatabaseGateway, webhookSender *mock.MockWebhookSender) {
+				webhookDatabase.EXPECT().ClaimWebhookDeliveries(gomock.Any(), 10, 30*time.Second).Return(nil, domain.ErrDeadlock)
+			},
+			wantErr: true,
+			err:     errors.New("failed to claim webhook deliveries from database: deadlock detected"),
+		},
+		{
+			name: "fail delivering webhooks when recording fails",
+			args: args{
+				ctx:   ctx,
+				input: input,
+			},
+			beforeTest: func(webhookDatabase *mock.MockWebhookDatabaseGateway, webhookSender *mock.MockWebhookSender) {
+				webhookDatabase.EXPECT().ClaimWebhookDeliveries(gomock.Any(), 10, 30*time.Second).Return([]*entities.WebhookDelivery{delivery(1)}, nil)
+				webhookSender.EXPECT().Send(gomock.Any(), delivery(1)).Return(200, nil)
+				webhookDatabase.EXPECT().RecordWebhookDeliveryAttempt(gomock.Any(), gomock.Any()).Return(domain.ErrWebhookDeliveryDoesNotExist)
+			},
+			want:    DeliverWebhooksOutput{Claimed: 1, Delivered: 1},
+			wantErr: true,
+			err:     errors.New("failed to record webhook delivery 1 into database: webhook delivery does not exist"),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockWebhookDatabase := mock.NewMockWebhookDatabaseGateway(ctrl)
+			mockWebhookSender := mock.NewMockWebhookSender(ctrl)
+
+			usecase := &deliverWebhooksUseCase{
+				webhookDatabase: mockWebhookDatabase,
+				webhookSender:   mockWebhookSender,
+				retryPolicy:     RetryPolicy{MaxAttempts: 5, BaseDelay: time.Second, MaxDelay: time.Minute},
+				disableAfter:    10,
+				validator:       domain.NewValidatorService(),
+				now:             func() time.Time { return now },
+			}
+
+			if tt.beforeTest != nil {
+				tt.beforeTest(mockWebhookDatabase, mockWebhookSender)
+			}
+
+			delivered, err := usecase.Execute(tt.args.ctx, *tt.args.input)
+
+			if (err != nil) != tt.wantErr {
+				t.Errorf("deliverWebhooks.Execute() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if !reflect.DeepEqual(delivered, tt.want) {
+				t.Errorf("deliverWebhooks.Execute() = %v, want %v", delivered, tt.want)
+			}
+			if tt.wantErr {
+				if err.Error() != tt.err.Error() {
+					t.Errorf("deliverWebhooks.Execute() error = %v, wantErr %v", err, tt.err)
+					return
+				}
+			}
+		})
+	}
+}
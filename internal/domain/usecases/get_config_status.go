@@ -0,0 +1,52 @@
+package usecases
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/lyracampos/go-clean-architecture/internal/domain"
+	"github.com/lyracampos/go-clean-architecture/internal/domain/ports"
+)
+
+var _ GetConfigStatusUseCase = (*getConfigStatusUseCase)(nil)
+
+type GetConfigStatusUseCase interface {
+	Execute(ctx context.Context) (GetConfigStatusOutput, error)
+}
+
+// GetConfigStatusOutput tells which config generation is applied and why the last reload failed, if it did.
+type GetConfigStatusOutput struct {
+	Generation  uint64
+	ReloadedAt  *time.Time
+	LastError   string
+	LastErrorAt *time.Time
+}
+
+type getConfigStatusUseCase struct {
+	configStatus ports.ConfigStatusGateway
+}
+
+func NewGetConfigStatusUseCase(configStatus ports.ConfigStatusGateway) *getConfigStatusUseCase {
+	return &getConfigStatusUseCase{
+		configStatus: configStatus,
+	}
+}
+
+func (u *getConfigStatusUseCase) Execute(ctx context.Context) (GetConfigStatusOutput, error) {
+	if _, _, err := domain.RequirePermission(ctx, domain.PermissionConfigRead); err != nil {
+		return GetConfigStatusOutput{}, err
+	}
+
+	status, err := u.configStatus.ConfigStatus(ctx)
+	if err != nil {
+		return GetConfigStatusOutput{}, fmt.Errorf("failed to get config status: %w", err)
+	}
+
+	return GetConfigStatusOutput{
+		Generation:  status.Generation,
+		ReloadedAt:  status.ReloadedAt,
+		LastError:   status.LastError,
+		LastErrorAt: status.LastErrorAt,
+	}, nil
+}
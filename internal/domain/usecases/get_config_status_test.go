@@ -0,0 +1,97 @@
+package usecases
+
+import (
+	"context"
+	"errors"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/lyracampos/go-clean-architecture/internal/domain"
+	"github.com/lyracampos/go-clean-architecture/internal/domain/entities"
+	mock "github.com/lyracampos/go-clean-architecture/internal/domain/ports/mocks"
+	"go.uber.org/mock/gomock"
+)
+
+func TestGetConfigStatusExecute(t *testing.T) {
+	ctx := adminContext()
+	reloadedAt := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	lastErrorAt := reloadedAt.Add(time.Hour)
+
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	tests := []struct {
+		name       string
+		ctx        context.Context
+		beforeTest func(configStatus *mock.MockConfigStatusGateway)
+		want       GetConfigStatusOutput
+		wantErr    bool
+		err        error
+	}{
+		{
+			name: "success getting config status",
+			ctx:  ctx,
+			beforeTest: func(configStatus *mock.MockConfigStatusGateway) {
+				configStatus.EXPECT().ConfigStatus(gomock.Any()).Return(&entities.ConfigStatus{
+					Generation:  3,
+					ReloadedAt:  &reloadedAt,
+					LastError:   "invalid config:\nlog.level: must be one of [info debug warn error], got \"verbose\"",
+					LastErrorAt: &lastErrorAt,
+				}, nil)
+			},
+			want: GetConfigStatusOutput{
+				Generation:  3,
+				ReloadedAt:  &reloadedAt,
+				LastError:   "invalid config:\nlog.level: must be one of [info debug warn error], got \"verbose\"",
+				LastErrorAt: &lastErrorAt,
+			},
+		},
+		{
+			name:       "fail getting config status as contributor",
+			ctx:        contributorContext(1),
+			beforeTest: nil,
+			wantErr:    true,
+			err:        domain.ErrForbidden,
+		},
+		{
+			name: "fail getting config status when gateway fails",
+			ctx:  ctx,
+			beforeTest: func(configStatus *mock.MockConfigStatusGateway) {
+				configStatus.EXPECT().ConfigStatus(gomock.Any()).Return(nil, errors.New("watcher stopped"))
+			},
+			wantErr: true,
+			err:     errors.New("failed to get config status: watcher stopped"),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockConfigStatus := mock.NewMockConfigStatusGateway(ctrl)
+
+			usecase := &getConfigStatusUseCase{
+				configStatus: mockConfigStatus,
+			}
+
+			if tt.beforeTest != nil {
+				tt.beforeTest(mockConfigStatus)
+			}
+
+			status, err := usecase.Execute(tt.ctx)
+
+			if (err != nil) != tt.wantErr {
+				t.Errorf("getConfigStatus.Execute() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if !reflect.DeepEqual(status, tt.want) {
+				t.Errorf("getConfigStatus.Execute() = %v, want %v", status, tt.want)
+			}
+			if tt.wantErr {
+				if err.Error() != tt.err.Error() {
+					t.Errorf("getConfigStatus.Execute() error = %v, wantErr %v", err, tt.err)
+					return
+				}
+			}
+		})
+	}
+}
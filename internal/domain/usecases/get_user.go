@@ -4,6 +4,7 @@ import (
 	"context"
 	"time"
 
+	"github.com/lyracampos/go-clean-architecture/internal/domain"
 	"github.com/lyracampos/go-clean-architecture/internal/domain/entities"
 	"github.com/lyracampos/go-clean-architecture/internal/domain/ports"
 )
@@ -15,7 +16,8 @@ type GetUserUseCase interface {
 }
 
 type GetUserInput struct {
-	ID int64
+	ID             int64
+	IncludeDeleted bool
 }
 
 type GetUserOutput struct {
@@ -26,6 +28,8 @@ type GetUserOutput struct {
 	Role      string
 	CreatedAt time.Time
 	UpdatedAt time.Time
+	DeletedAt *time.Time
+	Version   int64
 }
 
 type getUserUseCase struct {
@@ -39,7 +43,21 @@ func NewGetUserUseCase(userDatabaseGateway ports.UserDatabaseGateway) *getUserUs
 }
 
 func (u *getUserUseCase) Execute(ctx context.Context, input GetUserInput) (GetUserOutput, error) {
-	user, err := u.userDatabaseGateway.GetUser(ctx, input.ID)
+	if err := domain.Authorize(ctx, domain.PermissionUsersRead, input.ID); err != nil {
+		return GetUserOutput{}, err
+	}
+
+	// deleted users are only visible to whom can delete them
+	if input.IncludeDeleted {
+		if err := domain.Authorize(ctx, domain.PermissionUsersDelete, input.ID); err != nil {
+			return GetUserOutput{}, err
+		}
+	}
+
+	user, err := u.userDatabaseGateway.GetUser(ctx, ports.GetUserFilter{
+		ID:             input.ID,
+		IncludeDeleted: input.IncludeDeleted,
+	})
 	if err != nil {
 		return GetUserOutput{}, err
 	}
@@ -56,5 +74,7 @@ func (u *getUserUseCase) fromEntityToOutput(user *entities.User) GetUserOutput {
 		Role:      user.Role,
 		CreatedAt: user.CreatedAt,
 		UpdatedAt: user.UpdatedAt,
+		DeletedAt: user.DeletedAt,
+		Version:   user.Version,
 	}
 }
@@ -13,7 +13,7 @@ import (
 )
 
 func TestGetUserExecute(t *testing.T) {
-	ctx := context.Background()
+	ctx := adminContext()
 
 	ctrl := gomock.NewController(t)
 	defer ctrl.Finish()
@@ -57,6 +57,48 @@ func TestGetUserExecute(t *testing.T) {
 			wantErr: true,
 			err:     domain.ErrUserDoesNotExist,
 		},
+		{
+			name: "success getting own user as contributor",
+			args: args{
+				ctx:   contributorContext(1),
+				input: &GetUserInput{ID: 1},
+			},
+			beforeTest: func(userDatabase *mock.MockUserDatabaseGateway) {
+				userDatabase.EXPECT().GetUser(gomock.Any(), gomock.Any()).Return(defaultGetUserResult())
+			},
+			want:    defaultGetUserOutput(),
+			wantErr: false,
+		},
+		{
+			name: "fail getting another user as contributor",
+			args: args{
+				ctx:   contributorContext(2),
+				input: &GetUserInput{ID: 1},
+			},
+			beforeTest: nil,
+			wantErr:    true,
+			err:        domain.ErrForbidden,
+		},
+		{
+			name: "fail getting deleted user as contributor",
+			args: args{
+				ctx:   contributorContext(1),
+				input: &GetUserInput{ID: 1, IncludeDeleted: true},
+			},
+			beforeTest: nil,
+			wantErr:    true,
+			err:        domain.ErrForbidden,
+		},
+		{
+			name: "fail getting user without caller",
+			args: args{
+				ctx:   context.Background(),
+				input: &GetUserInput{ID: 1},
+			},
+			beforeTest: nil,
+			wantErr:    true,
+			err:        domain.ErrUnauthenticated,
+		},
 	}
 
 	for _, tt := range tests {
@@ -113,3 +155,12 @@ func defaultGetUserOutput() GetUserOutput {
 		Role:      "admin",
 	}
 }
+
+// adminContext returns a context authenticated as an admin other than the users in the tests.
+func adminContext() context.Context {
+	return domain.WithCaller(context.Background(), domain.Caller{UserID: 99, Role: domain.RoleAdmin})
+}
+
+func contributorContext(userID int64) context.Context {
+	return domain.WithCaller(context.Background(), domain.Caller{UserID: userID, Role: domain.RoleContributor})
+}
@@ -0,0 +1,69 @@
+package usecases
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/lyracampos/go-clean-architecture/internal/domain"
+	"github.com/lyracampos/go-clean-architecture/internal/domain/entities"
+	"github.com/lyracampos/go-clean-architecture/internal/domain/ports"
+)
+
+var _ GetWebhookUseCase = (*getWebhookUseCase)(nil)
+
+type GetWebhookUseCase interface {
+	Execute(ctx context.Context, input GetWebhookInput) (WebhookOutput, error)
+}
+
+type GetWebhookInput struct {
+	ID int64
+}
+
+// WebhookOutput describes a webhook without its secret.
+type WebhookOutput struct {
+	ID                  int64
+	URL                 string
+	EventTypes          []string
+	Enabled             bool
+	ConsecutiveFailures int
+	CreatedAt           time.Time
+	UpdatedAt           time.Time
+	DisabledAt          *time.Time
+}
+
+type getWebhookUseCase struct {
+	webhookDatabase ports.WebhookDatabaseGateway
+}
+
+func NewGetWebhookUseCase(webhookDatabase ports.WebhookDatabaseGateway) *getWebhookUseCase {
+	return &getWebhookUseCase{
+		webhookDatabase: webhookDatabase,
+	}
+}
+
+func (u *getWebhookUseCase) Execute(ctx context.Context, input GetWebhookInput) (WebhookOutput, error) {
+	if _, _, err := domain.RequirePermission(ctx, domain.PermissionWebhooksManage); err != nil {
+		return WebhookOutput{}, err
+	}
+
+	webhook, err := u.webhookDatabase.GetWebhook(ctx, input.ID)
+	if err != nil {
+		return WebhookOutput{}, fmt.Errorf("failed to get webhook from database: %w", err)
+	}
+
+	return newWebhookOutput(webhook), nil
+}
+
+func newWebhookOutput(webhook *entities.Webhook) WebhookOutput {
+	return WebhookOutput{
+		ID:                  webhook.ID,
+		URL:                 webhook.URL,
+		EventTypes:          webhook.EventTypes,
+		Enabled:             webhook.Enabled,
+		ConsecutiveFailures: webhook.ConsecutiveFailures,
+		CreatedAt:           webhook.CreatedAt,
+		UpdatedAt:           webhook.UpdatedAt,
+		DisabledAt:          webhook.DisabledAt,
+	}
+}
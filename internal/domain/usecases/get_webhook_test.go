@@ -0,0 +1,123 @@
+package usecases
+
+import (
+	"context"
+	"errors"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/lyracampos/go-clean-architecture/internal/domain"
+	"github.com/lyracampos/go-clean-architecture/internal/domain/entities"
+	mock "github.com/lyracampos/go-clean-architecture/internal/domain/ports/mocks"
+	"go.uber.org/mock/gomock"
+)
+
+func TestGetWebhookExecute(t *testing.T) {
+	ctx := adminContext()
+
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	type args struct {
+		ctx   context.Context
+		input *GetWebhookInput
+	}
+	tests := []struct {
+		name       string
+		args       args
+		beforeTest func(webhookDatabase *mock.MockWebhookDatabaseGateway)
+		want       WebhookOutput
+		wantErr    bool
+		err        error
+	}{
+		{
+			name: "success getting webhook without its secret",
+			args: args{
+				ctx:   ctx,
+				input: &GetWebhookInput{ID: 1},
+			},
+			beforeTest: func(webhookDatabase *mock.MockWebhookDatabaseGateway) {
+				webhookDatabase.EXPECT().GetWebhook(gomock.Any(), int64(1)).Return(defaultWebhook(), nil)
+			},
+			want: defaultWebhookOutput(),
+		},
+		{
+			name: "fail getting webhook when id not found",
+			args: args{
+				ctx:   ctx,
+				input: &GetWebhookInput{ID: 1},
+			},
+			beforeTest: func(webhookDatabase *mock.MockWebhookDatabaseGateway) {
+				webhookDatabase.EXPECT().GetWebhook(gomock.Any(), int64(1)).Return(nil, domain.ErrWebhookDoesNotExist)
+			},
+			wantErr: true,
+			err:     errors.New("failed to get webhook from database: webhook does not exist"),
+		},
+		{
+			name: "fail getting webhook as contributor",
+			args: args{
+				ctx:   contributorContext(1),
+				input: &GetWebhookInput{ID: 1},
+			},
+			beforeTest: nil,
+			wantErr:    true,
+			err:        domain.ErrForbidden,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockWebhookDatabase := mock.NewMockWebhookDatabaseGateway(ctrl)
+
+			usecase := &getWebhookUseCase{
+				webhookDatabase: mockWebhookDatabase,
+			}
+
+			if tt.beforeTest != nil {
+				tt.beforeTest(mockWebhookDatabase)
+			}
+
+			webhook, err := usecase.Execute(tt.args.ctx, *tt.args.input)
+
+			if (err != nil) != tt.wantErr {
+				t.Errorf("getWebhook.Execute() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if !reflect.DeepEqual(webhook, tt.want) {
+				t.Errorf("getWebhook.Execute() = %v, want %v", webhook, tt.want)
+			}
+			if tt.wantErr {
+				if err.Error() != tt.err.Error() {
+					t.Errorf("getWebhook.Execute() error = %v, wantErr %v", err, tt.err)
+					return
+				}
+			}
+		})
+	}
+}
+
+var webhookCreatedAt = time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+
+func defaultWebhook() *entities.Webhook {
+	return &entities.Webhook{
+		ID:         1,
+		URL:        "https://example.com/hooks",
+		EventTypes: []string{"user.created"},
+		Secret:     "0123456789abcdef",
+		Enabled:    true,
+		CreatedAt:  webhookCreatedAt,
+		UpdatedAt:  webhookCreatedAt,
+	}
+}
+
+func defaultWebhookOutput() WebhookOutput {
+	return WebhookOutput{
+		ID:         1,
+		URL:        "https://example.com/hooks",
+		EventTypes: []string{"user.created"},
+		Enabled:    true,
+		CreatedAt:  webhookCreatedAt,
+		UpdatedAt:  webhookCreatedAt,
+	}
+}
@@ -0,0 +1,69 @@
+package usecases
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/lyracampos/go-clean-architecture/internal/domain"
+	"github.com/lyracampos/go-clean-architecture/internal/domain/ports"
+)
+
+var _ ListAPIKeysUseCase = (*listAPIKeysUseCase)(nil)
+
+type ListAPIKeysUseCase interface {
+	Execute(ctx context.Context) (ListAPIKeysOutput, error)
+}
+
+type ListAPIKeysOutput struct {
+	APIKeys []APIKeyOutput
+}
+
+// APIKeyOutput describes a key without the key itself, which is only shown on creation.
+type APIKeyOutput struct {
+	ID         int64
+	UserID     int64
+	Name       string
+	Prefix     string
+	Scopes     []string
+	ExpiresAt  *time.Time
+	CreatedAt  time.Time
+	LastUsedAt *time.Time
+}
+
+type listAPIKeysUseCase struct {
+	apiKeyDatabase ports.APIKeyDatabaseGateway
+}
+
+func NewListAPIKeysUseCase(apiKeyDatabase ports.APIKeyDatabaseGateway) *listAPIKeysUseCase {
+	return &listAPIKeysUseCase{
+		apiKeyDatabase: apiKeyDatabase,
+	}
+}
+
+func (u *listAPIKeysUseCase) Execute(ctx context.Context) (ListAPIKeysOutput, error) {
+	if _, _, err := domain.RequirePermission(ctx, domain.PermissionAPIKeysManage); err != nil {
+		return ListAPIKeysOutput{}, err
+	}
+
+	apiKeys, err := u.apiKeyDatabase.ListAPIKeys(ctx)
+	if err != nil {
+		return ListAPIKeysOutput{}, fmt.Errorf("failed to list api keys from database: %w", err)
+	}
+
+	output := ListAPIKeysOutput{APIKeys: make([]APIKeyOutput, 0, len(apiKeys))}
+	for _, apiKey := range apiKeys {
+		output.APIKeys = append(output.APIKeys, APIKeyOutput{
+			ID:         apiKey.ID,
+			UserID:     apiKey.UserID,
+			Name:       apiKey.Name,
+			Prefix:     apiKey.Prefix,
+			Scopes:     apiKey.Scopes,
+			ExpiresAt:  apiKey.ExpiresAt,
+			CreatedAt:  apiKey.CreatedAt,
+			LastUsedAt: apiKey.LastUsedAt,
+		})
+	}
+
+	return output, nil
+}
@@ -0,0 +1,112 @@
+package usecases
+
+import (
+	"context"
+	"errors"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/lyracampos/go-clean-architecture/internal/domain"
+	"github.com/lyracampos/go-clean-architecture/internal/domain/entities"
+	mock "github.com/lyracampos/go-clean-architecture/internal/domain/ports/mocks"
+	"go.uber.org/mock/gomock"
+)
+
+func TestListAPIKeysExecute(t *testing.T) {
+	ctx := adminContext()
+	createdAt := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	lastUsedAt := createdAt.Add(time.Hour)
+
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	tests := []struct {
+		name       string
+		ctx        context.Context
+		beforeTest func(apiKeyDatabase *mock.MockAPIKeyDatabaseGateway)
+		want       ListAPIKeysOutput
+		wantErr    bool
+		err        error
+	}{
+		{
+			name: "success listing api keys without their hashes",
+			ctx:  ctx,
+			beforeTest: func(apiKeyDatabase *mock.MockAPIKeyDatabaseGateway) {
+				apiKeyDatabase.EXPECT().ListAPIKeys(gomock.Any()).Return([]*entities.APIKey{{
+					ID:         1,
+					UserID:     99,
+					Name:       "batch",
+					Prefix:     "prefix",
+					KeyHash:    "keyHash",
+					Scopes:     []string{"users:read"},
+					CreatedAt:  createdAt,
+					LastUsedAt: &lastUsedAt,
+				}}, nil)
+			},
+			want: ListAPIKeysOutput{APIKeys: []APIKeyOutput{{
+				ID:         1,
+				UserID:     99,
+				Name:       "batch",
+				Prefix:     "prefix",
+				Scopes:     []string{"users:read"},
+				CreatedAt:  createdAt,
+				LastUsedAt: &lastUsedAt,
+			}}},
+		},
+		{
+			name: "success listing no api keys",
+			ctx:  ctx,
+			beforeTest: func(apiKeyDatabase *mock.MockAPIKeyDatabaseGateway) {
+				apiKeyDatabase.EXPECT().ListAPIKeys(gomock.Any()).Return([]*entities.APIKey{}, nil)
+			},
+			want: ListAPIKeysOutput{APIKeys: []APIKeyOutput{}},
+		},
+		{
+			name:       "fail listing api keys as contributor",
+			ctx:        contributorContext(1),
+			beforeTest: nil,
+			wantErr:    true,
+			err:        domain.ErrForbidden,
+		},
+		{
+			name: "fail listing api keys when query fails",
+			ctx:  ctx,
+			beforeTest: func(apiKeyDatabase *mock.MockAPIKeyDatabaseGateway) {
+				apiKeyDatabase.EXPECT().ListAPIKeys(gomock.Any()).Return(nil, domain.ErrDeadlock)
+			},
+			wantErr: true,
+			err:     errors.New("failed to list api keys from database: deadlock detected"),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockAPIKeyDatabase := mock.NewMockAPIKeyDatabaseGateway(ctrl)
+
+			usecase := &listAPIKeysUseCase{
+				apiKeyDatabase: mockAPIKeyDatabase,
+			}
+
+			if tt.beforeTest != nil {
+				tt.beforeTest(mockAPIKeyDatabase)
+			}
+
+			apiKeys, err := usecase.Execute(tt.ctx)
+
+			if (err != nil) != tt.wantErr {
+				t.Errorf("listAPIKeys.Execute() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if !reflect.DeepEqual(apiKeys, tt.want) {
+				t.Errorf("listAPIKeys.Execute() = %v, want %v", apiKeys, tt.want)
+			}
+			if tt.wantErr {
+				if err.Error() != tt.err.Error() {
+					t.Errorf("listAPIKeys.Execute() error = %v, wantErr %v", err, tt.err)
+					return
+				}
+			}
+		})
+	}
+}
@@ -3,11 +3,18 @@ package usecases
 import (
 	"context"
 	"fmt"
+	"strings"
 
+	"github.com/lyracampos/go-clean-architecture/internal/domain"
 	"github.com/lyracampos/go-clean-architecture/internal/domain/entities"
 	"github.com/lyracampos/go-clean-architecture/internal/domain/ports"
 )
 
+const (
+	defaultListUserLimit = 50
+	defaultListUserSort  = ports.UserSortCreatedAt
+)
+
 var _ ListUserUseCase = (*listUserUseCase)(nil)
 
 type ListUserUseCase interface {
@@ -15,40 +22,133 @@ type ListUserUseCase interface {
 }
 
 type ListUserInput struct {
-	FirstName string
-	LastName  string
-	Email     string
-	Role      string
+	FirstName      string
+	LastName       string
+	Email          string
+	Role           string
+	IncludeDeleted bool
+	// Query is a free text searched across names and email, results are ranked by similarity
+	Query string `validate:"required_if=Sort relevance"`
+
+	// Limit is the page size, defaults to 50
+	Limit int `validate:"omitempty,min=1,max=100"`
+	// Cursor is the NextCursor returned by the previous page
+	Cursor string
+	// Sort is the field users are ordered by, prefixed with '-' for descending order.
+	// Defaults to relevance when searching by Query and to created_at otherwise.
+	Sort string `validate:"omitempty,oneof=created_at -created_at first_name -first_name last_name -last_name email -email relevance"`
 }
 
 type ListUserOutput struct {
 	Users []*entities.User
 	Total int
+	// NextCursor fetches the following page, empty on the last one
+	NextCursor string
 }
 
 type listUserUseCase struct {
 	userDatabaseGateway ports.UserDatabaseGateway
+	validator           domain.Validator
 }
 
-func NewListUserUseCase(userDatabaseGateway ports.UserDatabaseGateway) *listUserUseCase {
+func NewListUserUseCase(userDatabaseGateway ports.UserDatabaseGateway, validator domain.Validator) *listUserUseCase {
 	return &listUserUseCase{
 		userDatabaseGateway: userDatabaseGateway,
+		validator:           validator,
 	}
 }
 
 func (u *listUserUseCase) Execute(ctx context.Context, input ListUserInput) (ListUserOutput, error) {
-	users, err := u.userDatabaseGateway.ListUser(ctx, ports.ListUserFilter{
-		FirstName: input.FirstName,
-		LastName:  input.LastName,
-		Email:     input.Email,
-		Role:      input.Role,
-	})
+	caller, scope, err := domain.RequirePermission(ctx, domain.PermissionUsersRead)
+	if err != nil {
+		return ListUserOutput{}, err
+	}
+
+	// deleted users are only visible to whom can delete them
+	if input.IncludeDeleted {
+		if _, _, err := domain.RequirePermission(ctx, domain.PermissionUsersDelete); err != nil {
+			return ListUserOutput{}, err
+		}
+	}
+
+	err = u.validator.Validate(input)
+	if err != nil {
+		return ListUserOutput{}, fmt.Errorf("input is invalid: %w", err)
+	}
+
+	limit := input.Limit
+	if limit == 0 {
+		limit = defaultListUserLimit
+	}
+
+	sort := u.parseSort(input.Sort, input.Query)
+
+	after, err := decodeUserCursor(input.Cursor, sort, input.Query)
+	if err != nil {
+		return ListUserOutput{}, err
+	}
+
+	filter := ports.ListUserFilter{
+		FirstName:      input.FirstName,
+		LastName:       input.LastName,
+		Email:          input.Email,
+		Role:           input.Role,
+		IncludeDeleted: input.IncludeDeleted,
+		Query:          input.Query,
+		// one extra user tells whether there is a next page
+		Limit: limit + 1,
+		Sort:  sort,
+		After: after,
+	}
+
+	// callers allowed to read only their own user see just themselves
+	if scope == domain.ScopeOwn {
+		filter.ID = caller.UserID
+	}
+
+	users, err := u.userDatabaseGateway.ListUser(ctx, filter)
 	if err != nil {
 		return ListUserOutput{}, fmt.Errorf("failed to list user from database: %w", err)
 	}
 
+	total, err := u.userDatabaseGateway.CountUsers(ctx, filter)
+	if err != nil {
+		return ListUserOutput{}, fmt.Errorf("failed to count users from database: %w", err)
+	}
+
+	var nextCursor string
+	if len(users) > limit {
+		users = users[:limit]
+
+		nextCursor, err = encodeUserCursor(sort, input.Query, users[len(users)-1])
+		if err != nil {
+			return ListUserOutput{}, err
+		}
+	}
+
 	return ListUserOutput{
-		Users: users,
-		Total: len(users),
+		Users:      users,
+		Total:      total,
+		NextCursor: nextCursor,
 	}, nil
 }
+
+func (u *listUserUseCase) parseSort(sort, query string) ports.UserSort {
+	if sort == "" && query != "" {
+		sort = ports.UserSortRelevance
+	}
+
+	if sort == "" {
+		sort = defaultListUserSort
+	}
+
+	// the most similar users come first
+	if sort == ports.UserSortRelevance {
+		return ports.UserSort{Field: sort, Descending: true}
+	}
+
+	return ports.UserSort{
+		Field:      strings.TrimPrefix(sort, "-"),
+		Descending: strings.HasPrefix(sort, "-"),
+	}
+}
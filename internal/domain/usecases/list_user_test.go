@@ -2,16 +2,20 @@ package usecases
 
 import (
 	"context"
+	"errors"
 	"reflect"
 	"testing"
+	"time"
 
+	"github.com/lyracampos/go-clean-architecture/internal/domain"
 	"github.com/lyracampos/go-clean-architecture/internal/domain/entities"
+	"github.com/lyracampos/go-clean-architecture/internal/domain/ports"
 	mock "github.com/lyracampos/go-clean-architecture/internal/domain/ports/mocks"
 	"go.uber.org/mock/gomock"
 )
 
 func TestListUserExecute(t *testing.T) {
-	ctx := context.Background()
+	ctx := adminContext()
 
 	ctrl := gomock.NewController(t)
 	defer ctrl.Finish()
@@ -37,10 +41,184 @@ func TestListUserExecute(t *testing.T) {
 			},
 			beforeTest: func(userDatabase *mock.MockUserDatabaseGateway) {
 				userDatabase.EXPECT().ListUser(gomock.Any(), gomock.Any()).Return(defaultListUserResult())
+				userDatabase.EXPECT().CountUsers(gomock.Any(), gomock.Any()).Return(2, nil)
 			},
 			want:    defaultListUsersOutput(),
 			wantErr: false,
 		},
+		{
+			name: "success listing first page returning the next cursor",
+			args: args{
+				ctx:   ctx,
+				input: &ListUserInput{Limit: 1, Sort: "-email"},
+			},
+			beforeTest: func(userDatabase *mock.MockUserDatabaseGateway) {
+				userDatabase.EXPECT().ListUser(gomock.Any(), ports.ListUserFilter{
+					Limit: 2,
+					Sort:  ports.UserSort{Field: ports.UserSortEmail, Descending: true},
+				}).Return(defaultListUserResult())
+				userDatabase.EXPECT().CountUsers(gomock.Any(), gomock.Any()).Return(5, nil)
+			},
+			want: ListUserOutput{
+				Users:      []*entities.User{defaultListUsersOutput().Users[0]},
+				Total:      5,
+				NextCursor: mustEncodeUserCursor(ports.UserSort{Field: ports.UserSortEmail, Descending: true}, "", defaultListUsersOutput().Users[0]),
+			},
+			wantErr: false,
+		},
+		{
+			name: "success listing next page from cursor",
+			args: args{
+				ctx: ctx,
+				input: &ListUserInput{
+					Limit:  1,
+					Sort:   "created_at",
+					Cursor: mustEncodeUserCursor(ports.UserSort{Field: ports.UserSortCreatedAt}, "", &entities.User{ID: 1, CreatedAt: time.Date(2024, time.March, 10, 12, 0, 0, 0, time.UTC)}),
+				},
+			},
+			beforeTest: func(userDatabase *mock.MockUserDatabaseGateway) {
+				userDatabase.EXPECT().ListUser(gomock.Any(), ports.ListUserFilter{
+					Limit: 2,
+					Sort:  ports.UserSort{Field: ports.UserSortCreatedAt},
+					After: &ports.UserCursor{Value: "2024-03-10T12:00:00Z", ID: 1},
+				}).Return([]*entities.User{defaultListUsersOutput().Users[1]}, nil)
+				userDatabase.EXPECT().CountUsers(gomock.Any(), gomock.Any()).Return(2, nil)
+			},
+			want: ListUserOutput{
+				Users: []*entities.User{defaultListUsersOutput().Users[1]},
+				Total: 2,
+			},
+			wantErr: false,
+		},
+		{
+			name: "fail listing users with cursor from another sort",
+			args: args{
+				ctx: ctx,
+				input: &ListUserInput{
+					Sort:   "last_name",
+					Cursor: mustEncodeUserCursor(ports.UserSort{Field: ports.UserSortEmail}, "", defaultListUsersOutput().Users[0]),
+				},
+			},
+			beforeTest: nil,
+			wantErr:    true,
+			err:        domain.ErrInvalidCursor,
+		},
+		{
+			name: "fail listing users with malformed cursor",
+			args: args{
+				ctx:   ctx,
+				input: &ListUserInput{Cursor: "not a cursor"},
+			},
+			beforeTest: nil,
+			wantErr:    true,
+			err:        domain.ErrInvalidCursor,
+		},
+		{
+			name: "fail listing users with invalid sort",
+			args: args{
+				ctx:   ctx,
+				input: &ListUserInput{Sort: "role"},
+			},
+			beforeTest: nil,
+			wantErr:    true,
+			err:        errors.New("input is invalid: the field 'Sort' is not valid, expected one of [created_at -created_at first_name -first_name last_name -last_name email -email relevance]; "),
+		},
+		{
+			name: "success searching users ranked by relevance",
+			args: args{
+				ctx:   ctx,
+				input: &ListUserInput{Query: "lyr", Limit: 1},
+			},
+			beforeTest: func(userDatabase *mock.MockUserDatabaseGateway) {
+				userDatabase.EXPECT().ListUser(gomock.Any(), ports.ListUserFilter{
+					Query: "lyr",
+					Limit: 2,
+					Sort:  ports.UserSort{Field: ports.UserSortRelevance, Descending: true},
+				}).Return(defaultListUserResult())
+				userDatabase.EXPECT().CountUsers(gomock.Any(), gomock.Any()).Return(2, nil)
+			},
+			want: ListUserOutput{
+				Users:      []*entities.User{defaultListUsersOutput().Users[0]},
+				Total:      2,
+				NextCursor: mustEncodeUserCursor(ports.UserSort{Field: ports.UserSortRelevance, Descending: true}, "lyr", defaultListUsersOutput().Users[0]),
+			},
+			wantErr: false,
+		},
+		{
+			name: "fail searching users with cursor from another query",
+			args: args{
+				ctx: ctx,
+				input: &ListUserInput{
+					Query:  "campos",
+					Cursor: mustEncodeUserCursor(ports.UserSort{Field: ports.UserSortRelevance, Descending: true}, "lyr", defaultListUsersOutput().Users[0]),
+				},
+			},
+			beforeTest: nil,
+			wantErr:    true,
+			err:        domain.ErrInvalidCursor,
+		},
+		{
+			name: "fail sorting users by relevance without query",
+			args: args{
+				ctx:   ctx,
+				input: &ListUserInput{Sort: "relevance"},
+			},
+			beforeTest: nil,
+			wantErr:    true,
+			err:        errors.New("input is invalid: the field 'Query' should not be empty; "),
+		},
+		{
+			name: "fail listing users with limit above maximum",
+			args: args{
+				ctx:   ctx,
+				input: &ListUserInput{Limit: 101},
+			},
+			beforeTest: nil,
+			wantErr:    true,
+			err:        errors.New("input is invalid: the field 'Limit' max size is 100; "),
+		},
+		{
+			name: "success listing only own user as contributor",
+			args: args{
+				ctx:   contributorContext(1),
+				input: &ListUserInput{},
+			},
+			beforeTest: func(userDatabase *mock.MockUserDatabaseGateway) {
+				filter := ports.ListUserFilter{
+					ID:    1,
+					Limit: 51,
+					Sort:  ports.UserSort{Field: ports.UserSortCreatedAt},
+				}
+				userDatabase.EXPECT().ListUser(gomock.Any(), filter).Return(defaultListUserResult())
+				userDatabase.EXPECT().CountUsers(gomock.Any(), filter).Return(2, nil)
+			},
+			want:    defaultListUsersOutput(),
+			wantErr: false,
+		},
+		{
+			name: "fail listing deleted users as contributor",
+			args: args{
+				ctx:   contributorContext(1),
+				input: &ListUserInput{IncludeDeleted: true},
+			},
+			beforeTest: nil,
+			wantErr:    true,
+			err:        domain.ErrForbidden,
+		},
+		{
+			name: "fail listing users with an api key without the read scope",
+			args: args{
+				ctx: domain.WithCaller(context.Background(), domain.Caller{
+					UserID: 99,
+					Role:   domain.RoleAdmin,
+					Scopes: []domain.Permission{domain.PermissionUsersWrite},
+				}),
+				input: &ListUserInput{},
+			},
+			beforeTest: nil,
+			wantErr:    true,
+			err:        domain.ErrForbidden,
+		},
 	}
 
 	for _, tt := range tests {
@@ -49,6 +227,7 @@ func TestListUserExecute(t *testing.T) {
 
 			usecase := &listUserUseCase{
 				userDatabaseGateway: mockUserDatabase,
+				validator:           domain.NewValidatorService(),
 			}
 
 			if tt.beforeTest != nil {
@@ -114,3 +293,12 @@ func defaultListUsersOutput() ListUserOutput {
 	}
 	return output
 }
+
+func mustEncodeUserCursor(sort ports.UserSort, query string, user *entities.User) string {
+	cursor, err := encodeUserCursor(sort, query, user)
+	if err != nil {
+		panic(err)
+	}
+
+	return cursor
+}
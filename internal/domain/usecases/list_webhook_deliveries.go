@@ -0,0 +1,97 @@
+package usecases
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/lyracampos/go-clean-architecture/internal/domain"
+	"github.com/lyracampos/go-clean-architecture/internal/domain/ports"
+)
+
+const defaultListWebhookDeliveriesLimit = 50
+
+var _ ListWebhookDeliveriesUseCase = (*listWebhookDeliveriesUseCase)(nil)
+
+type ListWebhookDeliveriesUseCase interface {
+	Execute(ctx context.Context, input ListWebhookDeliveriesInput) (ListWebhookDeliveriesOutput, error)
+}
+
+type ListWebhookDeliveriesInput struct {
+	WebhookID int64
+	// Limit is the number of attempts returned, defaults to 50
+	Limit int `validate:"omitempty,min=1,max=100"`
+}
+
+type ListWebhookDeliveriesOutput struct {
+	Deliveries []WebhookDeliveryAttemptOutput
+}
+
+// WebhookDeliveryAttemptOutput is a single try to post an event to the webhook.
+type WebhookDeliveryAttemptOutput struct {
+	DeliveryID int64
+	EventID    string
+	EventType  string
+	Attempt    int
+	// StatusCode is zero when no response was received
+	StatusCode  int
+	Error       string
+	DurationMs  int64
+	Succeeded   bool
+	AttemptedAt time.Time
+}
+
+type listWebhookDeliveriesUseCase struct {
+	webhookDatabase ports.WebhookDatabaseGateway
+	validator       domain.Validator
+}
+
+func NewListWebhookDeliveriesUseCase(webhookDatabase ports.WebhookDatabaseGateway, validator domain.Validator) *listWebhookDeliveriesUseCase {
+	return &listWebhookDeliveriesUseCase{
+		webhookDatabase: webhookDatabase,
+		validator:       validator,
+	}
+}
+
+// Execute returns the latest delivery attempts of the webhook, most recent first.
+func (u *listWebhookDeliveriesUseCase) Execute(ctx context.Context, input ListWebhookDeliveriesInput) (ListWebhookDeliveriesOutput, error) {
+	if _, _, err := domain.RequirePermission(ctx, domain.PermissionWebhooksManage); err != nil {
+		return ListWebhookDeliveriesOutput{}, err
+	}
+
+	err := u.validator.Validate(input)
+	if err != nil {
+		return ListWebhookDeliveriesOutput{}, fmt.Errorf("input is invalid: %w", err)
+	}
+
+	if input.Limit == 0 {
+		input.Limit = defaultListWebhookDeliveriesLimit
+	}
+
+	// an unknown webhook is reported instead of an empty log
+	if _, err := u.webhookDatabase.GetWebhook(ctx, input.WebhookID); err != nil {
+		return ListWebhookDeliveriesOutput{}, fmt.Errorf("failed to get webhook from database: %w", err)
+	}
+
+	attempts, err := u.webhookDatabase.ListWebhookDeliveryAttempts(ctx, input.WebhookID, input.Limit)
+	if err != nil {
+		return ListWebhookDeliveriesOutput{}, fmt.Errorf("failed to list webhook deliveries from database: %w", err)
+	}
+
+	output := ListWebhookDeliveriesOutput{Deliveries: make([]WebhookDeliveryAttemptOutput, 0, len(attempts))}
+	for _, attempt := range attempts {
+		output.Deliveries = append(output.Deliveries, WebhookDeliveryAttemptOutput{
+			DeliveryID:  attempt.DeliveryID,
+			EventID:     attempt.EventID,
+			EventType:   attempt.EventType,
+			Attempt:     attempt.Attempt,
+			StatusCode:  attempt.StatusCode,
+			Error:       attempt.Error,
+			DurationMs:  attempt.Duration.Milliseconds(),
+			Succeeded:   attempt.Succeeded,
+			AttemptedAt: attempt.AttemptedAt,
+		})
+	}
+
+	return output, nil
+}
@@ -0,0 +1,135 @@
+package usecases
+
+import (
+	"context"
+	"errors"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/lyracampos/go-clean-architecture/internal/domain"
+	"github.com/lyracampos/go-clean-architecture/internal/domain/entities"
+	mock "github.com/lyracampos/go-clean-architecture/internal/domain/ports/mocks"
+	"go.uber.org/mock/gomock"
+)
+
+func TestListWebhookDeliveriesExecute(t *testing.T) {
+	ctx := adminContext()
+	attemptedAt := time.Date(2024, 2, 1, 0, 0, 0, 0, time.UTC)
+
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	type args struct {
+		ctx   context.Context
+		input *ListWebhookDeliveriesInput
+	}
+	tests := []struct {
+		name       string
+		args       args
+		beforeTest func(webhookDatabase *mock.MockWebhookDatabaseGateway)
+		want       ListWebhookDeliveriesOutput
+		wantErr    bool
+		err        error
+	}{
+		{
+			name: "success listing delivery attempts with default limit",
+			args: args{
+				ctx:   ctx,
+				input: &ListWebhookDeliveriesInput{WebhookID: 1},
+			},
+			beforeTest: func(webhookDatabase *mock.MockWebhookDatabaseGateway) {
+				webhookDatabase.EXPECT().GetWebhook(gomock.Any(), int64(1)).Return(defaultWebhook(), nil)
+				webhookDatabase.EXPECT().ListWebhookDeliveryAttempts(gomock.Any(), int64(1), 50).Return([]*entities.WebhookDeliveryAttempt{
+					{
+						ID:          2,
+						DeliveryID:  1,
+						WebhookID:   1,
+						EventID:     "event",
+						EventType:   "user.created",
+						Attempt:     1,
+						StatusCode:  500,
+						Error:       "webhook answered with status 500",
+						Duration:    150 * time.Millisecond,
+						AttemptedAt: attemptedAt,
+					},
+				}, nil)
+			},
+			want: ListWebhookDeliveriesOutput{Deliveries: []WebhookDeliveryAttemptOutput{
+				{
+					DeliveryID:  1,
+					EventID:     "event",
+					EventType:   "user.created",
+					Attempt:     1,
+					StatusCode:  500,
+					Error:       "webhook answered with status 500",
+					DurationMs:  150,
+					AttemptedAt: attemptedAt,
+				},
+			}},
+		},
+		{
+			name: "fail listing delivery attempts with limit too large",
+			args: args{
+				ctx:   ctx,
+				input: &ListWebhookDeliveriesInput{WebhookID: 1, Limit: 1000},
+			},
+			beforeTest: nil,
+			wantErr:    true,
+			err:        errors.New("input is invalid: the field 'Limit' max size is 100; "),
+		},
+		{
+			name: "fail listing delivery attempts when webhook not found",
+			args: args{
+				ctx:   ctx,
+				input: &ListWebhookDeliveriesInput{WebhookID: 1, Limit: 10},
+			},
+			beforeTest: func(webhookDatabase *mock.MockWebhookDatabaseGateway) {
+				webhookDatabase.EXPECT().GetWebhook(gomock.Any(), int64(1)).Return(nil, domain.ErrWebhookDoesNotExist)
+			},
+			wantErr: true,
+			err:     errors.New("failed to get webhook from database: webhook does not exist"),
+		},
+		{
+			name: "fail listing delivery attempts as contributor",
+			args: args{
+				ctx:   contributorContext(1),
+				input: &ListWebhookDeliveriesInput{WebhookID: 1},
+			},
+			beforeTest: nil,
+			wantErr:    true,
+			err:        domain.ErrForbidden,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockWebhookDatabase := mock.NewMockWebhookDatabaseGateway(ctrl)
+
+			usecase := &listWebhookDeliveriesUseCase{
+				webhookDatabase: mockWebhookDatabase,
+				validator:       domain.NewValidatorService(),
+			}
+
+			if tt.beforeTest != nil {
+				tt.beforeTest(mockWebhookDatabase)
+			}
+
+			deliveries, err := usecase.Execute(tt.args.ctx, *tt.args.input)
+
+			if (err != nil) != tt.wantErr {
+				t.Errorf("listWebhookDeliveries.Execute() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if !reflect.DeepEqual(deliveries, tt.want) {
+				t.Errorf("listWebhookDeliveries.Execute() = %v, want %v", deliveries, tt.want)
+			}
+			if tt.wantErr {
+				if err.Error() != tt.err.Error() {
+					t.Errorf("listWebhookDeliveries.Execute() error = %v, wantErr %v", err, tt.err)
+					return
+				}
+			}
+		})
+	}
+}
@@ -0,0 +1,47 @@
+package usecases
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/lyracampos/go-clean-architecture/internal/domain"
+	"github.com/lyracampos/go-clean-architecture/internal/domain/ports"
+)
+
+var _ ListWebhooksUseCase = (*listWebhooksUseCase)(nil)
+
+type ListWebhooksUseCase interface {
+	Execute(ctx context.Context) (ListWebhooksOutput, error)
+}
+
+type ListWebhooksOutput struct {
+	Webhooks []WebhookOutput
+}
+
+type listWebhooksUseCase struct {
+	webhookDatabase ports.WebhookDatabaseGateway
+}
+
+func NewListWebhooksUseCase(webhookDatabase ports.WebhookDatabaseGateway) *listWebhooksUseCase {
+	return &listWebhooksUseCase{
+		webhookDatabase: webhookDatabase,
+	}
+}
+
+func (u *listWebhooksUseCase) Execute(ctx context.Context) (ListWebhooksOutput, error) {
+	if _, _, err := domain.RequirePermission(ctx, domain.PermissionWebhooksManage); err != nil {
+		return ListWebhooksOutput{}, err
+	}
+
+	webhooks, err := u.webhookDatabase.ListWebhooks(ctx)
+	if err != nil {
+		return ListWebhooksOutput{}, fmt.Errorf("failed to list webhooks from database: %w", err)
+	}
+
+	output := ListWebhooksOutput{Webhooks: make([]WebhookOutput, 0, len(webhooks))}
+	for _, webhook := range webhooks {
+		output.Webhooks = append(output.Webhooks, newWebhookOutput(webhook))
+	}
+
+	return output, nil
+}
@@ -0,0 +1,92 @@
+package usecases
+
+import (
+	"context"
+	"errors"
+	"reflect"
+	"testing"
+
+	"github.com/lyracampos/go-clean-architecture/internal/domain"
+	"github.com/lyracampos/go-clean-architecture/internal/domain/entities"
+	mock "github.com/lyracampos/go-clean-architecture/internal/domain/ports/mocks"
+	"go.uber.org/mock/gomock"
+)
+
+func TestListWebhooksExecute(t *testing.T) {
+	ctx := adminContext()
+
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	tests := []struct {
+		name       string
+		ctx        context.Context
+		beforeTest func(webhookDatabase *mock.MockWebhookDatabaseGateway)
+		want       ListWebhooksOutput
+		wantErr    bool
+		err        error
+	}{
+		{
+			name: "success listing webhooks",
+			ctx:  ctx,
+			beforeTest: func(webhookDatabase *mock.MockWebhookDatabaseGateway) {
+				webhookDatabase.EXPECT().ListWebhooks(gomock.Any()).Return([]*entities.Webhook{defaultWebhook()}, nil)
+			},
+			want: ListWebhooksOutput{Webhooks: []WebhookOutput{defaultWebhookOutput()}},
+		},
+		{
+			name: "success listing no webhooks",
+			ctx:  ctx,
+			beforeTest: func(webhookDatabase *mock.MockWebhookDatabaseGateway) {
+				webhookDatabase.EXPECT().ListWebhooks(gomock.Any()).Return([]*entities.Webhook{}, nil)
+			},
+			want: ListWebhooksOutput{Webhooks: []WebhookOutput{}},
+		},
+		{
+			name: "fail listing webhooks when database fails",
+			ctx:  ctx,
+			beforeTest: func(webhookDatabase *mock.MockWebhookDatabaseGateway) {
+				webhookDatabase.EXPECT().ListWebhooks(gomock.Any()).Return(nil, domain.ErrDeadlock)
+			},
+			wantErr: true,
+			err:     errors.New("failed to list webhooks from database: deadlock detected"),
+		},
+		{
+			name:       "fail listing webhooks as contributor",
+			ctx:        contributorContext(1),
+			beforeTest: nil,
+			wantErr:    true,
+			err:        domain.ErrForbidden,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockWebhookDatabase := mock.NewMockWebhookDatabaseGateway(ctrl)
+
+			usecase := &listWebhooksUseCase{
+				webhookDatabase: mockWebhookDatabase,
+			}
+
+			if tt.beforeTest != nil {
+				tt.beforeTest(mockWebhookDatabase)
+			}
+
+			webhooks, err := usecase.Execute(tt.ctx)
+
+			if (err != nil) != tt.wantErr {
+				t.Errorf("listWebhooks.Execute() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if !reflect.DeepEqual(webhooks, tt.want) {
+				t.Errorf("listWebhooks.Execute() = %v, want %v", webhooks, tt.want)
+			}
+			if tt.wantErr {
+				if err.Error() != tt.err.Error() {
+					t.Errorf("listWebhooks.Execute() error = %v, wantErr %v", err, tt.err)
+					return
+				}
+			}
+		})
+	}
+}
@@ -0,0 +1,127 @@
+package usecases
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/lyracampos/go-clean-architecture/internal/domain"
+	"github.com/lyracampos/go-clean-architecture/internal/domain/entities"
+	"github.com/lyracampos/go-clean-architecture/internal/domain/ports"
+)
+
+var _ LoginUseCase = (*loginUseCase)(nil)
+
+type LoginUseCase interface {
+	Execute(ctx context.Context, input LoginInput) (LoginOutput, error)
+}
+
+// swagger:model
+type LoginInput struct {
+	// user email
+	//
+	// required: true
+	Email string `validate:"required,email"`
+	// user password
+	//
+	// required: true
+	Password string `validate:"required"`
+}
+
+type LoginOutput struct {
+	AccessToken  string
+	TokenType    string
+	ExpiresAt    time.Time
+	RefreshToken string
+}
+
+const bearerTokenType = "Bearer"
+
+type loginUseCase struct {
+	userDatabase         ports.UserDatabaseGateway
+	credentialsDatabase  ports.CredentialsDatabaseGateway
+	refreshTokenDatabase ports.RefreshTokenDatabaseGateway
+	passwordHasher       ports.PasswordHasher
+	tokenService         ports.TokenService
+	validator            domain.Validator
+}
+
+func NewLoginUseCase(
+	userDatabase ports.UserDatabaseGateway,
+	credentialsDatabase ports.CredentialsDatabaseGateway,
+	refreshTokenDatabase ports.RefreshTokenDatabaseGateway,
+	passwordHasher ports.PasswordHasher,
+	tokenService ports.TokenService,
+	validator domain.Validator,
+) *loginUseCase {
+	return &loginUseCase{
+		userDatabase:         userDatabase,
+		credentialsDatabase:  credentialsDatabase,
+		refreshTokenDatabase: refreshTokenDatabase,
+		passwordHasher:       passwordHasher,
+		tokenService:         tokenService,
+		validator:            validator,
+	}
+}
+
+// Execute answers every authentication failure with domain.ErrInvalidCredentials and
+// compares a password even when there is nothing to compare it with, so neither the
+// error nor the response time tell whether the email belongs to an user.
+func (u *loginUseCase) Execute(ctx context.Context, input LoginInput) (LoginOutput, error) {
+	err := u.validator.Validate(input)
+	if err != nil {
+		return LoginOutput{}, fmt.Errorf("input is invalid: %w", err)
+	}
+
+	user, err := u.userDatabase.GetUser(ctx, ports.GetUserFilter{Email: input.Email})
+	if errors.Is(err, domain.ErrUserDoesNotExist) {
+		return LoginOutput{}, u.rejectPassword(input.Password)
+	}
+
+	if err != nil {
+		return LoginOutput{}, err
+	}
+
+	credentials, err := u.credentialsDatabase.GetCredentials(ctx, user.ID)
+	if errors.Is(err, domain.ErrCredentialsDoNotExist) {
+		return LoginOutput{}, u.rejectPassword(input.Password)
+	}
+
+	if err != nil {
+		return LoginOutput{}, err
+	}
+
+	if err := u.passwordHasher.Compare(credentials.PasswordHash, input.Password); err != nil {
+		return LoginOutput{}, domain.ErrInvalidCredentials
+	}
+
+	accessToken, err := u.tokenService.IssueAccessToken(ctx, user)
+	if err != nil {
+		return LoginOutput{}, fmt.Errorf("failed to issue access token: %w", err)
+	}
+
+	refreshToken, err := u.tokenService.IssueRefreshToken(ctx)
+	if err != nil {
+		return LoginOutput{}, fmt.Errorf("failed to issue refresh token: %w", err)
+	}
+
+	// every login starts a new refresh token family
+	_, err = u.refreshTokenDatabase.InsertRefreshToken(ctx, entities.NewRefreshToken(user.ID, "", refreshToken.Hash, refreshToken.ExpiresAt))
+	if err != nil {
+		return LoginOutput{}, fmt.Errorf("failed to insert refresh token into database: %w", err)
+	}
+
+	return LoginOutput{
+		AccessToken:  accessToken.Token,
+		TokenType:    bearerTokenType,
+		ExpiresAt:    accessToken.ExpiresAt,
+		RefreshToken: refreshToken.Token,
+	}, nil
+}
+
+func (u *loginUseCase) rejectPassword(password string) error {
+	_ = u.passwordHasher.Compare("", password)
+
+	return domain.ErrInvalidCredentials
+}
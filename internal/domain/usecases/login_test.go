@@ -0,0 +1,206 @@
+package usecases
+
+import (
+	"context"
+	"errors"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/lyracampos/go-clean-architecture/internal/domain"
+	"github.com/lyracampos/go-clean-architecture/internal/domain/entities"
+	"github.com/lyracampos/go-clean-architecture/internal/domain/ports"
+	mock "github.com/lyracampos/go-clean-architecture/internal/domain/ports/mocks"
+	"go.uber.org/mock/gomock"
+)
+
+var refreshExpiresAt = time.Date(2024, 1, 31, 0, 0, 0, 0, time.UTC)
+
+func TestLoginExecute(t *testing.T) {
+	ctx := context.Background()
+	expiresAt := time.Date(2024, 1, 1, 0, 15, 0, 0, time.UTC)
+
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	type args struct {
+		ctx   context.Context
+		input *LoginInput
+	}
+	tests := []struct {
+		name       string
+		args       args
+		beforeTest func(userDatabase *mock.MockUserDatabaseGateway, credentialsDatabase *mock.MockCredentialsDatabaseGateway, refreshTokenDatabase *mock.MockRefreshTokenDatabaseGateway, passwordHasher *mock.MockPasswordHasher, tokenService *mock.MockTokenService)
+		want       LoginOutput
+		wantErr    bool
+		err        error
+	}{
+		{
+			name: "success logging in",
+			args: args{
+				ctx:   ctx,
+				input: defaultLoginInput(),
+			},
+			beforeTest: func(userDatabase *mock.MockUserDatabaseGateway, credentialsDatabase *mock.MockCredentialsDatabaseGateway, refreshTokenDatabase *mock.MockRefreshTokenDatabaseGateway, passwordHasher *mock.MockPasswordHasher, tokenService *mock.MockTokenService) {
+				userDatabase.EXPECT().GetUser(gomock.Any(), ports.GetUserFilter{Email: "useremail@domain.com"}).Return(defaultGetUserResult())
+				credentialsDatabase.EXPECT().GetCredentials(gomock.Any(), int64(1)).Return(defaultCredentialsResult())
+				passwordHasher.EXPECT().Compare("hash", "a long and unusual secret").Return(nil)
+				tokenService.EXPECT().IssueAccessToken(gomock.Any(), gomock.Any()).Return(ports.AccessToken{Token: "token", ExpiresAt: expiresAt}, nil)
+				tokenService.EXPECT().IssueRefreshToken(gomock.Any()).Return(defaultIssuedRefreshToken(), nil)
+				refreshTokenDatabase.EXPECT().InsertRefreshToken(gomock.Any(), &entities.RefreshToken{
+					UserID:    1,
+					TokenHash: "refreshHash",
+					ExpiresAt: refreshExpiresAt,
+				}).Return(&entities.RefreshToken{ID: 1}, nil)
+			},
+			want: LoginOutput{
+				AccessToken:  "token",
+				TokenType:    "Bearer",
+				ExpiresAt:    expiresAt,
+				RefreshToken: "refresh",
+			},
+		},
+		{
+			name: "fail logging in with invalid email",
+			args: args{
+				ctx:   ctx,
+				input: &LoginInput{Email: "invalid@domain", Password: "a long and unusual secret"},
+			},
+			beforeTest: nil,
+			wantErr:    true,
+			err:        errors.New("input is invalid: the field 'Email' is invalid; "),
+		},
+		{
+			name: "fail logging in when email not found",
+			args: args{
+				ctx:   ctx,
+				input: defaultLoginInput(),
+			},
+			beforeTest: func(userDatabase *mock.MockUserDatabaseGateway, credentialsDatabase *mock.MockCredentialsDatabaseGateway, refreshTokenDatabase *mock.MockRefreshTokenDatabaseGateway, passwordHasher *mock.MockPasswordHasher, tokenService *mock.MockTokenService) {
+				userDatabase.EXPECT().GetUser(gomock.Any(), gomock.Any()).Return(getUserNotFoundResult())
+				passwordHasher.EXPECT().Compare("", "a long and unusual secret").Return(errors.New("mismatch"))
+			},
+			wantErr: true,
+			err:     domain.ErrInvalidCredentials,
+		},
+		{
+			name: "fail logging in when user has no password",
+			args: args{
+				ctx:   ctx,
+				input: defaultLoginInput(),
+			},
+			beforeTest: func(userDatabase *mock.MockUserDatabaseGateway, credentialsDatabase *mock.MockCredentialsDatabaseGateway, refreshTokenDatabase *mock.MockRefreshTokenDatabaseGateway, passwordHasher *mock.MockPasswordHasher, tokenService *mock.MockTokenService) {
+				userDatabase.EXPECT().GetUser(gomock.Any(), gomock.Any()).Return(defaultGetUserResult())
+				credentialsDatabase.EXPECT().GetCredentials(gomock.Any(), int64(1)).Return(nil, domain.ErrCredentialsDoNotExist)
+				passwordHasher.EXPECT().Compare("", "a long and unusual secret").Return(errors.New("mismatch"))
+			},
+			wantErr: true,
+			err:     domain.ErrInvalidCredentials,
+		},
+		{
+			name: "fail logging in with wrong password",
+			args: args{
+				ctx:   ctx,
+				input: defaultLoginInput(),
+			},
+			beforeTest: func(userDatabase *mock.MockUserDatabaseGateway, credentialsDatabase *mock.MockCredentialsDatabaseGateway, refreshTokenDatabase *mock.MockRefreshTokenDatabaseGateway, passwordHasher *mock.MockPasswordHasher, tokenService *mock.MockTokenService) {
+				userDatabase.EXPECT().GetUser(gomock.Any(), gomock.Any()).Return(defaultGetUserResult())
+				credentialsDatabase.EXPECT().GetCredentials(gomock.Any(), int64(1)).Return(defaultCredentialsResult())
+				passwordHasher.EXPECT().Compare("hash", "a long and unusual secret").Return(errors.New("mismatch"))
+			},
+			wantErr: true,
+			err:     domain.ErrInvalidCredentials,
+		},
+		{
+			name: "fail logging in when token can't be issued",
+			args: args{
+				ctx:   ctx,
+				input: defaultLoginInput(),
+			},
+			beforeTest: func(userDatabase *mock.MockUserDatabaseGateway, credentialsDatabase *mock.MockCredentialsDatabaseGateway, refreshTokenDatabase *mock.MockRefreshTokenDatabaseGateway, passwordHasher *mock.MockPasswordHasher, tokenService *mock.MockTokenService) {
+				userDatabase.EXPECT().GetUser(gomock.Any(), gomock.Any()).Return(defaultGetUserResult())
+				credentialsDatabase.EXPECT().GetCredentials(gomock.Any(), int64(1)).Return(defaultCredentialsResult())
+				passwordHasher.EXPECT().Compare(gomock.Any(), gomock.Any()).Return(nil)
+				tokenService.EXPECT().IssueAccessToken(gomock.Any(), gomock.Any()).Return(ports.AccessToken{}, errors.New("signing failed"))
+			},
+			wantErr: true,
+			err:     errors.New("failed to issue access token: signing failed"),
+		},
+		{
+			name: "fail logging in when refresh token can't be stored",
+			args: args{
+				ctx:   ctx,
+				input: defaultLoginInput(),
+			},
+			beforeTest: func(userDatabase *mock.MockUserDatabaseGateway, credentialsDatabase *mock.MockCredentialsDatabaseGateway, refreshTokenDatabase *mock.MockRefreshTokenDatabaseGateway, passwordHasher *mock.MockPasswordHasher, tokenService *mock.MockTokenService) {
+				userDatabase.EXPECT().GetUser(gomock.Any(), gomock.Any()).Return(defaultGetUserResult())
+				credentialsDatabase.EXPECT().GetCredentials(gomock.Any(), int64(1)).Return(defaultCredentialsResult())
+				passwordHasher.EXPECT().Compare(gomock.Any(), gomock.Any()).Return(nil)
+				tokenService.EXPECT().IssueAccessToken(gomock.Any(), gomock.Any()).Return(ports.AccessToken{Token: "token", ExpiresAt: expiresAt}, nil)
+				tokenService.EXPECT().IssueRefreshToken(gomock.Any()).Return(defaultIssuedRefreshToken(), nil)
+				refreshTokenDatabase.EXPECT().InsertRefreshToken(gomock.Any(), gomock.Any()).Return(nil, domain.ErrForeignKeyViolation)
+			},
+			wantErr: true,
+			err:     errors.New("failed to insert refresh token into database: foreign key constraint violated"),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockUserDatabase := mock.NewMockUserDatabaseGateway(ctrl)
+			mockCredentialsDatabase := mock.NewMockCredentialsDatabaseGateway(ctrl)
+			mockRefreshTokenDatabase := mock.NewMockRefreshTokenDatabaseGateway(ctrl)
+			mockPasswordHasher := mock.NewMockPasswordHasher(ctrl)
+			mockTokenService := mock.NewMockTokenService(ctrl)
+			validator := domain.NewValidatorService()
+
+			usecase := &loginUseCase{
+				userDatabase:         mockUserDatabase,
+				credentialsDatabase:  mockCredentialsDatabase,
+				refreshTokenDatabase: mockRefreshTokenDatabase,
+				passwordHasher:       mockPasswordHasher,
+				tokenService:         mockTokenService,
+				validator:            validator,
+			}
+
+			if tt.beforeTest != nil {
+				tt.beforeTest(mockUserDatabase, mockCredentialsDatabase, mockRefreshTokenDatabase, mockPasswordHasher, mockTokenService)
+			}
+
+			loginResult, err := usecase.Execute(tt.args.ctx, *tt.args.input)
+
+			if (err != nil) != tt.wantErr {
+				t.Errorf("login.Execute() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if !reflect.DeepEqual(loginResult, tt.want) {
+				t.Errorf("login.Execute() = %v, want %v", loginResult, tt.want)
+			}
+			if tt.wantErr {
+				if err.Error() != tt.err.Error() {
+					t.Errorf("login.Execute() error = %v, wantErr %v", err, tt.err)
+					return
+				}
+			}
+		})
+	}
+}
+
+func defaultLoginInput() *LoginInput {
+	return &LoginInput{
+		Email:    "useremail@domain.com",
+		Password: "a long and unusual secret",
+	}
+}
+
+func defaultIssuedRefreshToken() ports.RefreshToken {
+	return ports.RefreshToken{
+		Token:     "refresh",
+		Hash:      "refreshHash",
+		ExpiresAt: refreshExpiresAt,
+	}
+}
+
+func defaultCredentialsResult() (*entities.Credentials, error) {
+	return &entities.Credentials{UserID: 1, PasswordHash: "hash"}, nil
+}
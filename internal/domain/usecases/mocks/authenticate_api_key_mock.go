@@ -0,0 +1,57 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: ./internal/domain/usecases/authenticate_api_key.go
+//
+// Generated by this command:
+//
+//	mockgen -source=./internal/domain/usecases/authenticate_api_key.go -destination=./internal/domain/usecases/mocks/authenticate_api_key_mock.go -package=mock
+//
+
+// Package mock is a generated GoMock package.
+package mock
+
+import (
+	context "context"
+	reflect "reflect"
+
+	domain "github.com/lyracampos/go-clean-architecture/internal/domain"
+	usecases "github.com/lyracampos/go-clean-architecture/internal/domain/usecases"
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockAuthenticateAPIKeyUseCase is a mock of AuthenticateAPIKeyUseCase interface.
+type MockAuthenticateAPIKeyUseCase struct {
+	ctrl     *gomock.Controller
+	recorder *MockAuthenticateAPIKeyUseCaseMockRecorder
+}
+
+// MockAuthenticateAPIKeyUseCaseMockRecorder is the mock recorder for MockAuthenticateAPIKeyUseCase.
+type MockAuthenticateAPIKeyUseCaseMockRecorder struct {
+	mock *MockAuthenticateAPIKeyUseCase
+}
+
+// NewMockAuthenticateAPIKeyUseCase creates a new mock instance.
+func NewMockAuthenticateAPIKeyUseCase(ctrl *gomock.Controller) *MockAuthenticateAPIKeyUseCase {
+	mock := &MockAuthenticateAPIKeyUseCase{ctrl: ctrl}
+	mock.recorder = &MockAuthenticateAPIKeyUseCaseMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockAuthenticateAPIKeyUseCase) EXPECT() *MockAuthenticateAPIKeyUseCaseMockRecorder {
+	return m.recorder
+}
+
+// Execute mocks base method.
+func (m *MockAuthenticateAPIKeyUseCase) Execute(ctx context.Context, input usecases.AuthenticateAPIKeyInput) (domain.Caller, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Execute", ctx, input)
+	ret0, _ := ret[0].(domain.Caller)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Execute indicates an expected call of Execute.
+func (mr *MockAuthenticateAPIKeyUseCaseMockRecorder) Execute(ctx, input any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Execute", reflect.TypeOf((*MockAuthenticateAPIKeyUseCase)(nil).Execute), ctx, input)
+}
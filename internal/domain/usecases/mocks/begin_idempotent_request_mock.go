@@ -0,0 +1,56 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: ./internal/domain/usecases/begin_idempotent_request.go
+//
+// Generated by this command:
+//
+//	mockgen -source=./internal/domain/usecases/begin_idempotent_request.go -destination=./internal/domain/usecases/mocks/begin_idempotent_request_mock.go -package=mock
+//
+
+// Package mock is a generated GoMock package.
+package mock
+
+import (
+	context "context"
+	reflect "reflect"
+
+	usecases "github.com/lyracampos/go-clean-architecture/internal/domain/usecases"
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockBeginIdempotentRequestUseCase is a mock of BeginIdempotentRequestUseCase interface.
+type MockBeginIdempotentRequestUseCase struct {
+	ctrl     *gomock.Controller
+	recorder *MockBeginIdempotentRequestUseCaseMockRecorder
+}
+
+// MockBeginIdempotentRequestUseCaseMockRecorder is the mock recorder for MockBeginIdempotentRequestUseCase.
+type MockBeginIdempotentRequestUseCaseMockRecorder struct {
+	mock *MockBeginIdempotentRequestUseCase
+}
+
+// NewMockBeginIdempotentRequestUseCase creates a new mock instance.
+func NewMockBeginIdempotentRequestUseCase(ctrl *gomock.Controller) *MockBeginIdempotentRequestUseCase {
+	mock := &MockBeginIdempotentRequestUseCase{ctrl: ctrl}
+	mock.recorder = &MockBeginIdempotentRequestUseCaseMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockBeginIdempotentRequestUseCase) EXPECT() *MockBeginIdempotentRequestUseCaseMockRecorder {
+	return m.recorder
+}
+
+// Execute mocks base method.
+func (m *MockBeginIdempotentRequestUseCase) Execute(ctx context.Context, input usecases.BeginIdempotentRequestInput) (usecases.BeginIdempotentRequestOutput, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Execute", ctx, input)
+	ret0, _ := ret[0].(usecases.BeginIdempotentRequestOutput)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Execute indicates an expected call of Execute.
+func (mr *MockBeginIdempotentRequestUseCaseMockRecorder) Execute(ctx, input any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Execute", reflect.TypeOf((*MockBeginIdempotentRequestUseCase)(nil).Execute), ctx, input)
+}
@@ -0,0 +1,55 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: ./internal/domain/usecases/complete_idempotent_request.go
+//
+// Generated by this command:
+//
+//	mockgen -source=./internal/domain/usecases/complete_idempotent_request.go -destination=./internal/domain/usecases/mocks/complete_idempotent_request_mock.go -package=mock
+//
+
+// Package mock is a generated GoMock package.
+package mock
+
+import (
+	context "context"
+	reflect "reflect"
+
+	usecases "github.com/lyracampos/go-clean-architecture/internal/domain/usecases"
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockCompleteIdempotentRequestUseCase is a mock of CompleteIdempotentRequestUseCase interface.
+type MockCompleteIdempotentRequestUseCase struct {
+	ctrl     *gomock.Controller
+	recorder *MockCompleteIdempotentRequestUseCaseMockRecorder
+}
+
+// MockCompleteIdempotentRequestUseCaseMockRecorder is the mock recorder for MockCompleteIdempotentRequestUseCase.
+type MockCompleteIdempotentRequestUseCaseMockRecorder struct {
+	mock *MockCompleteIdempotentRequestUseCase
+}
+
+// NewMockCompleteIdempotentRequestUseCase creates a new mock instance.
+func NewMockCompleteIdempotentRequestUseCase(ctrl *gomock.Controller) *MockCompleteIdempotentRequestUseCase {
+	mock := &MockCompleteIdempotentRequestUseCase{ctrl: ctrl}
+	mock.recorder = &MockCompleteIdempotentRequestUseCaseMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockCompleteIdempotentRequestUseCase) EXPECT() *MockCompleteIdempotentRequestUseCaseMockRecorder {
+	return m.recorder
+}
+
+// Execute mocks base method.
+func (m *MockCompleteIdempotentRequestUseCase) Execute(ctx context.Context, input usecases.CompleteIdempotentRequestInput) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Execute", ctx, input)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Execute indicates an expected call of Execute.
+func (mr *MockCompleteIdempotentRequestUseCaseMockRecorder) Execute(ctx, input any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Execute", reflect.TypeOf((*MockCompleteIdempotentRequestUseCase)(nil).Execute), ctx, input)
+}
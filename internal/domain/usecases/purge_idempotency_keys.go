@@ -0,0 +1,44 @@
+package usecases
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/lyracampos/go-clean-architecture/internal/domain/ports"
+)
+
+var _ PurgeIdempotencyKeysUseCase = (*purgeIdempotencyKeysUseCase)(nil)
+
+type PurgeIdempotencyKeysUseCase interface {
+	Execute(ctx context.Context) (PurgeIdempotencyKeysOutput, error)
+}
+
+type PurgeIdempotencyKeysOutput struct {
+	Purged int64
+}
+
+type purgeIdempotencyKeysUseCase struct {
+	idempotencyDatabase ports.IdempotencyDatabaseGateway
+	now                 func() time.Time
+}
+
+func NewPurgeIdempotencyKeysUseCase(idempotencyDatabase ports.IdempotencyDatabaseGateway) *purgeIdempotencyKeysUseCase {
+	return &purgeIdempotencyKeysUseCase{
+		idempotencyDatabase: idempotencyDatabase,
+		now:                 time.Now,
+	}
+}
+
+// Execute deletes the responses no longer replayed. Expired keys are reused even before
+// being purged, so purging only keeps the table from growing.
+func (u *purgeIdempotencyKeysUseCase) Execute(ctx context.Context) (PurgeIdempotencyKeysOutput, error) {
+	purged, err := u.idempotencyDatabase.PurgeIdempotencyKeys(ctx, u.now())
+	if err != nil {
+		return PurgeIdempotencyKeysOutput{}, fmt.Errorf("failed to purge idempotency keys from database: %w", err)
+	}
+
+	return PurgeIdempotencyKeysOutput{
+		Purged: purged,
+	}, nil
+}
@@ -0,0 +1,73 @@
+package usecases
+
+import (
+	"context"
+	"errors"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/lyracampos/go-clean-architecture/internal/domain"
+	mock "github.com/lyracampos/go-clean-architecture/internal/domain/ports/mocks"
+	"go.uber.org/mock/gomock"
+)
+
+func TestPurgeIdempotencyKeysExecute(t *testing.T) {
+	now := time.Date(2024, 2, 1, 0, 0, 0, 0, time.UTC)
+
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	tests := []struct {
+		name       string
+		beforeTest func(idempotencyDatabase *mock.MockIdempotencyDatabaseGateway)
+		want       PurgeIdempotencyKeysOutput
+		wantErr    bool
+		err        error
+	}{
+		{
+			name: "success purging expired keys",
+			beforeTest: func(idempotencyDatabase *mock.MockIdempotencyDatabaseGateway) {
+				idempotencyDatabase.EXPECT().PurgeIdempotencyKeys(gomock.Any(), now).Return(int64(3), nil)
+			},
+			want: PurgeIdempotencyKeysOutput{Purged: 3},
+		},
+		{
+			name: "fail purging expired keys when database fails",
+			beforeTest: func(idempotencyDatabase *mock.MockIdempotencyDatabaseGateway) {
+				idempotencyDatabase.EXPECT().PurgeIdempotencyKeys(gomock.Any(), now).Return(int64(0), domain.ErrDeadlock)
+			},
+			wantErr: true,
+			err:     errors.New("failed to purge idempotency keys from database: deadlock detected"),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockIdempotencyDatabase := mock.NewMockIdempotencyDatabaseGateway(ctrl)
+
+			usecase := &purgeIdempotencyKeysUseCase{
+				idempotencyDatabase: mockIdempotencyDatabase,
+				now:                 func() time.Time { return now },
+			}
+
+			tt.beforeTest(mockIdempotencyDatabase)
+
+			purged, err := usecase.Execute(context.Background())
+
+			if (err != nil) != tt.wantErr {
+				t.Errorf("purgeIdempotencyKeys.Execute() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if !reflect.DeepEqual(purged, tt.want) {
+				t.Errorf("purgeIdempotencyKeys.Execute() = %v, want %v", purged, tt.want)
+			}
+			if tt.wantErr {
+				if err.Error() != tt.err.Error() {
+					t.Errorf("purgeIdempotencyKeys.Execute() error = %v, wantErr %v", err, tt.err)
+					return
+				}
+			}
+		})
+	}
+}
@@ -0,0 +1,66 @@
+package usecases
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/lyracampos/go-clean-architecture/internal/domain"
+	"github.com/lyracampos/go-clean-architecture/internal/domain/ports"
+)
+
+var _ PurgeUsersUseCase = (*purgeUsersUseCase)(nil)
+
+type PurgeUsersUseCase interface {
+	Execute(ctx context.Context, input PurgeUsersInput) (PurgeUsersOutput, error)
+}
+
+// PurgeUsersInput defines for how long soft deleted users are kept
+// before being permanently removed.
+type PurgeUsersInput struct {
+	Retention time.Duration `validate:"gt=0"`
+}
+
+type PurgeUsersOutput struct {
+	Purged int64
+}
+
+type purgeUsersUseCase struct {
+	userDatabaseGateway ports.UserDatabaseGateway
+	validator           domain.Validator
+	now                 func() time.Time
+}
+
+func NewPurgeUsersUseCase(userDatabaseGateway ports.UserDatabaseGateway, validator domain.Validator) *purgeUsersUseCase {
+	return &purgeUsersUseCase{
+		userDatabaseGateway: userDatabaseGateway,
+		validator:           validator,
+		now:                 time.Now,
+	}
+}
+
+func (u *purgeUsersUseCase) Execute(ctx context.Context, input PurgeUsersInput) (PurgeUsersOutput, error) {
+	// purging reaches every user, so the permission can't be limited to the caller's own
+	_, scope, err := domain.RequirePermission(ctx, domain.PermissionUsersDelete)
+	if err != nil {
+		return PurgeUsersOutput{}, err
+	}
+
+	if scope != domain.ScopeAny {
+		return PurgeUsersOutput{}, domain.ErrForbidden
+	}
+
+	err = u.validator.Validate(input)
+	if err != nil {
+		return PurgeUsersOutput{}, fmt.Errorf("input is invalid: %w", err)
+	}
+
+	purged, err := u.userDatabaseGateway.PurgeUsers(ctx, u.now().Add(-input.Retention))
+	if err != nil {
+		return PurgeUsersOutput{}, fmt.Errorf("failed to purge users from database: %w", err)
+	}
+
+	return PurgeUsersOutput{
+		Purged: purged,
+	}, nil
+}
@@ -0,0 +1,111 @@
+package usecases
+
+import (
+	"context"
+	"errors"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/lyracampos/go-clean-architecture/internal/domain"
+	mock "github.com/lyracampos/go-clean-architecture/internal/domain/ports/mocks"
+	"go.uber.org/mock/gomock"
+)
+
+func TestPurgeUsersExecute(t *testing.T) {
+	ctx := adminContext()
+	now := time.Date(2024, time.March, 10, 12, 0, 0, 0, time.UTC)
+
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	type args struct {
+		ctx   context.Context
+		input *PurgeUsersInput
+	}
+	tests := []struct {
+		name       string
+		args       args
+		beforeTest func(userDatabase *mock.MockUserDatabaseGateway)
+		want       PurgeUsersOutput
+		wantErr    bool
+		err        error
+	}{
+		{
+			name: "success purging users deleted before the retention period",
+			args: args{
+				ctx:   ctx,
+				input: &PurgeUsersInput{Retention: 24 * time.Hour},
+			},
+			beforeTest: func(userDatabase *mock.MockUserDatabaseGateway) {
+				userDatabase.EXPECT().PurgeUsers(gomock.Any(), now.Add(-24*time.Hour)).Return(int64(3), nil)
+			},
+			want: PurgeUsersOutput{Purged: 3},
+		},
+		{
+			name: "fail purging users without retention period",
+			args: args{
+				ctx:   ctx,
+				input: &PurgeUsersInput{},
+			},
+			beforeTest: nil,
+			wantErr:    true,
+			err:        errors.New("input is invalid: the field 'Retention' is invalid; "),
+		},
+		{
+			name: "fail purging users when database fails",
+			args: args{
+				ctx:   ctx,
+				input: &PurgeUsersInput{Retention: time.Hour},
+			},
+			beforeTest: func(userDatabase *mock.MockUserDatabaseGateway) {
+				userDatabase.EXPECT().PurgeUsers(gomock.Any(), gomock.Any()).Return(int64(0), errors.New("connection refused"))
+			},
+			wantErr: true,
+			err:     errors.New("failed to purge users from database: connection refused"),
+		},
+		{
+			name: "fail purging users as contributor",
+			args: args{
+				ctx:   contributorContext(1),
+				input: &PurgeUsersInput{Retention: 24 * time.Hour},
+			},
+			beforeTest: nil,
+			wantErr:    true,
+			err:        domain.ErrForbidden,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockUserDatabase := mock.NewMockUserDatabaseGateway(ctrl)
+			validator := domain.NewValidatorService()
+
+			usecase := &purgeUsersUseCase{
+				userDatabaseGateway: mockUserDatabase,
+				validator:           validator,
+				now:                 func() time.Time { return now },
+			}
+
+			if tt.beforeTest != nil {
+				tt.beforeTest(mockUserDatabase)
+			}
+
+			purgeResult, err := usecase.Execute(tt.args.ctx, *tt.args.input)
+
+			if (err != nil) != tt.wantErr {
+				t.Errorf("purgeUsers.Execute() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if !reflect.DeepEqual(purgeResult, tt.want) {
+				t.Errorf("purgeUsers.Execute() = %v, want %v", purgeResult, tt.want)
+			}
+			if tt.wantErr {
+				if err.Error() != tt.err.Error() {
+					t.Errorf("purgeUsers.Execute() error = %v, wantErr %v", err, tt.err)
+					return
+				}
+			}
+		})
+	}
+}
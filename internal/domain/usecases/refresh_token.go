@@ -0,0 +1,129 @@
+package usecases
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/lyracampos/go-clean-architecture/internal/domain"
+	"github.com/lyracampos/go-clean-architecture/internal/domain/entities"
+	"github.com/lyracampos/go-clean-architecture/internal/domain/ports"
+)
+
+var _ RefreshTokenUseCase = (*refreshTokenUseCase)(nil)
+
+type RefreshTokenUseCase interface {
+	Execute(ctx context.Context, input RefreshTokenInput) (RefreshTokenOutput, error)
+}
+
+// swagger:model
+type RefreshTokenInput struct {
+	// refresh token returned by the login or by the last refresh
+	//
+	// required: true
+	RefreshToken string `validate:"required"`
+}
+
+type RefreshTokenOutput struct {
+	AccessToken  string
+	TokenType    string
+	ExpiresAt    time.Time
+	RefreshToken string
+}
+
+type refreshTokenUseCase struct {
+	userDatabase         ports.UserDatabaseGateway
+	refreshTokenDatabase ports.RefreshTokenDatabaseGateway
+	tokenService         ports.TokenService
+	validator            domain.Validator
+	now                  func() time.Time
+}
+
+func NewRefreshTokenUseCase(
+	userDatabase ports.UserDatabaseGateway,
+	refreshTokenDatabase ports.RefreshTokenDatabaseGateway,
+	tokenService ports.TokenService,
+	validator domain.Validator,
+) *refreshTokenUseCase {
+	return &refreshTokenUseCase{
+		userDatabase:         userDatabase,
+		refreshTokenDatabase: refreshTokenDatabase,
+		tokenService:         tokenService,
+		validator:            validator,
+		now:                  time.Now,
+	}
+}
+
+// Execute exchanges a refresh token for a new access token and a new refresh token.
+// Refresh tokens are single use: presenting one that was already used means it leaked,
+// so its whole family is revoked and the user has to log in again.
+func (u *refreshTokenUseCase) Execute(ctx context.Context, input RefreshTokenInput) (RefreshTokenOutput, error) {
+	err := u.validator.Validate(input)
+	if err != nil {
+		return RefreshTokenOutput{}, fmt.Errorf("input is invalid: %w", err)
+	}
+
+	storedToken, err := u.refreshTokenDatabase.GetRefreshToken(ctx, u.tokenService.HashRefreshToken(input.RefreshToken))
+	if errors.Is(err, domain.ErrRefreshTokenDoesNotExist) {
+		return RefreshTokenOutput{}, domain.ErrInvalidRefreshToken
+	}
+
+	if err != nil {
+		return RefreshTokenOutput{}, err
+	}
+
+	if storedToken.RevokedAt != nil || !u.now().Before(storedToken.ExpiresAt) {
+		return RefreshTokenOutput{}, domain.ErrInvalidRefreshToken
+	}
+
+	if storedToken.UsedAt != nil {
+		return RefreshTokenOutput{}, u.revokeFamily(ctx, storedToken.FamilyID)
+	}
+
+	user, err := u.userDatabase.GetUser(ctx, ports.GetUserFilter{ID: storedToken.UserID})
+	if errors.Is(err, domain.ErrUserDoesNotExist) {
+		return RefreshTokenOutput{}, domain.ErrInvalidRefreshToken
+	}
+
+	if err != nil {
+		return RefreshTokenOutput{}, err
+	}
+
+	refreshToken, err := u.tokenService.IssueRefreshToken(ctx)
+	if err != nil {
+		return RefreshTokenOutput{}, fmt.Errorf("failed to issue refresh token: %w", err)
+	}
+
+	nextToken := entities.NewRefreshToken(user.ID, storedToken.FamilyID, refreshToken.Hash, refreshToken.ExpiresAt)
+
+	_, err = u.refreshTokenDatabase.RotateRefreshToken(ctx, storedToken.ID, nextToken)
+	if errors.Is(err, domain.ErrRefreshTokenAlreadyUsed) {
+		// a concurrent request exchanged the same token first
+		return RefreshTokenOutput{}, u.revokeFamily(ctx, storedToken.FamilyID)
+	}
+
+	if err != nil {
+		return RefreshTokenOutput{}, fmt.Errorf("failed to rotate refresh token into database: %w", err)
+	}
+
+	accessToken, err := u.tokenService.IssueAccessToken(ctx, user)
+	if err != nil {
+		return RefreshTokenOutput{}, fmt.Errorf("failed to issue access token: %w", err)
+	}
+
+	return RefreshTokenOutput{
+		AccessToken:  accessToken.Token,
+		TokenType:    bearerTokenType,
+		ExpiresAt:    accessToken.ExpiresAt,
+		RefreshToken: refreshToken.Token,
+	}, nil
+}
+
+func (u *refreshTokenUseCase) revokeFamily(ctx context.Context, familyID string) error {
+	if err := u.refreshTokenDatabase.RevokeRefreshTokenFamily(ctx, familyID); err != nil {
+		return fmt.Errorf("failed to revoke refresh token family into database: %w", err)
+	}
+
+	return domain.ErrRefreshTokenReused
+}
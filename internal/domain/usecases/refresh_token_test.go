@@ -0,0 +1,211 @@
+package usecases
+
+import (
+	"context"
+	"errors"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/lyracampos/go-clean-architecture/internal/domain"
+	"github.com/lyracampos/go-clean-architecture/internal/domain/entities"
+	"github.com/lyracampos/go-clean-architecture/internal/domain/ports"
+	mock "github.com/lyracampos/go-clean-architecture/internal/domain/ports/mocks"
+	"go.uber.org/mock/gomock"
+)
+
+func TestRefreshTokenExecute(t *testing.T) {
+	ctx := context.Background()
+	now := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	expiresAt := now.Add(15 * time.Minute)
+
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	type args struct {
+		ctx   context.Context
+		input *RefreshTokenInput
+	}
+	tests := []struct {
+		name       string
+		args       args
+		beforeTest func(userDatabase *mock.MockUserDatabaseGateway, refreshTokenDatabase *mock.MockRefreshTokenDatabaseGateway, tokenService *mock.MockTokenService)
+		want       RefreshTokenOutput
+		wantErr    bool
+		err        error
+	}{
+		{
+			name: "success rotating refresh token",
+			args: args{
+				ctx:   ctx,
+				input: &RefreshTokenInput{RefreshToken: "old"},
+			},
+			beforeTest: func(userDatabase *mock.MockUserDatabaseGateway, refreshTokenDatabase *mock.MockRefreshTokenDatabaseGateway, tokenService *mock.MockTokenService) {
+				tokenService.EXPECT().HashRefreshToken("old").Return("oldHash")
+				refreshTokenDatabase.EXPECT().GetRefreshToken(gomock.Any(), "oldHash").Return(storedRefreshToken(now.Add(24*time.Hour)), nil)
+				userDatabase.EXPECT().GetUser(gomock.Any(), ports.GetUserFilter{ID: 1}).Return(defaultGetUserResult())
+				tokenService.EXPECT().IssueRefreshToken(gomock.Any()).Return(defaultIssuedRefreshToken(), nil)
+				refreshTokenDatabase.EXPECT().RotateRefreshToken(gomock.Any(), int64(10), &entities.RefreshToken{
+					UserID:    1,
+					FamilyID:  "family",
+					TokenHash: "refreshHash",
+					ExpiresAt: refreshExpiresAt,
+				}).Return(&entities.RefreshToken{ID: 11}, nil)
+				tokenService.EXPECT().IssueAccessToken(gomock.Any(), gomock.Any()).Return(ports.AccessToken{Token: "token", ExpiresAt: expiresAt}, nil)
+			},
+			want: RefreshTokenOutput{
+				AccessToken:  "token",
+				TokenType:    "Bearer",
+				ExpiresAt:    expiresAt,
+				RefreshToken: "refresh",
+			},
+		},
+		{
+			name: "fail refreshing with empty token",
+			args: args{
+				ctx:   ctx,
+				input: &RefreshTokenInput{},
+			},
+			beforeTest: nil,
+			wantErr:    true,
+			err:        errors.New("input is invalid: the field 'RefreshToken' should not be empty; "),
+		},
+		{
+			name: "fail refreshing with unknown token",
+			args: args{
+				ctx:   ctx,
+				input: &RefreshTokenInput{RefreshToken: "old"},
+			},
+			beforeTest: func(userDatabase *mock.MockUserDatabaseGateway, refreshTokenDatabase *mock.MockRefreshTokenDatabaseGateway, tokenService *mock.MockTokenService) {
+				tokenService.EXPECT().HashRefreshToken("old").Return("oldHash")
+				refreshTokenDatabase.EXPECT().GetRefreshToken(gomock.Any(), "oldHash").Return(nil, domain.ErrRefreshTokenDoesNotExist)
+			},
+			wantErr: true,
+			err:     domain.ErrInvalidRefreshToken,
+		},
+		{
+			name: "fail refreshing with expired token",
+			args: args{
+				ctx:   ctx,
+				input: &RefreshTokenInput{RefreshToken: "old"},
+			},
+			beforeTest: func(userDatabase *mock.MockUserDatabaseGateway, refreshTokenDatabase *mock.MockRefreshTokenDatabaseGateway, tokenService *mock.MockTokenService) {
+				tokenService.EXPECT().HashRefreshToken("old").Return("oldHash")
+				refreshTokenDatabase.EXPECT().GetRefreshToken(gomock.Any(), "oldHash").Return(storedRefreshToken(now.Add(-time.Second)), nil)
+			},
+			wantErr: true,
+			err:     domain.ErrInvalidRefreshToken,
+		},
+		{
+			name: "fail refreshing with revoked token",
+			args: args{
+				ctx:   ctx,
+				input: &RefreshTokenInput{RefreshToken: "old"},
+			},
+			beforeTest: func(userDatabase *mock.MockUserDatabaseGateway, refreshTokenDatabase *mock.MockRefreshTokenDatabaseGateway, tokenService *mock.MockTokenService) {
+				revokedToken := storedRefreshToken(now.Add(24 * time.Hour))
+				revokedToken.RevokedAt = &now
+
+				tokenService.EXPECT().HashRefreshToken("old").Return("oldHash")
+				refreshTokenDatabase.EXPECT().GetRefreshToken(gomock.Any(), "oldHash").Return(revokedToken, nil)
+			},
+			wantErr: true,
+			err:     domain.ErrInvalidRefreshToken,
+		},
+		{
+			name: "fail refreshing with reused token revokes its family",
+			args: args{
+				ctx:   ctx,
+				input: &RefreshTokenInput{RefreshToken: "old"},
+			},
+			beforeTest: func(userDatabase *mock.MockUserDatabaseGateway, refreshTokenDatabase *mock.MockRefreshTokenDatabaseGateway, tokenService *mock.MockTokenService) {
+				usedToken := storedRefreshToken(now.Add(24 * time.Hour))
+				usedToken.UsedAt = &now
+
+				tokenService.EXPECT().HashRefreshToken("old").Return("oldHash")
+				refreshTokenDatabase.EXPECT().GetRefreshToken(gomock.Any(), "oldHash").Return(usedToken, nil)
+				refreshTokenDatabase.EXPECT().RevokeRefreshTokenFamily(gomock.Any(), "family").Return(nil)
+			},
+			wantErr: true,
+			err:     domain.ErrRefreshTokenReused,
+		},
+		{
+			name: "fail refreshing when a concurrent request rotated the token first",
+			args: args{
+				ctx:   ctx,
+				input: &RefreshTokenInput{RefreshToken: "old"},
+			},
+			beforeTest: func(userDatabase *mock.MockUserDatabaseGateway, refreshTokenDatabase *mock.MockRefreshTokenDatabaseGateway, tokenService *mock.MockTokenService) {
+				tokenService.EXPECT().HashRefreshToken("old").Return("oldHash")
+				refreshTokenDatabase.EXPECT().GetRefreshToken(gomock.Any(), "oldHash").Return(storedRefreshToken(now.Add(24*time.Hour)), nil)
+				userDatabase.EXPECT().GetUser(gomock.Any(), gomock.Any()).Return(defaultGetUserResult())
+				tokenService.EXPECT().IssueRefreshToken(gomock.Any()).Return(defaultIssuedRefreshToken(), nil)
+				refreshTokenDatabase.EXPECT().RotateRefreshToken(gomock.Any(), int64(10), gomock.Any()).Return(nil, domain.ErrRefreshTokenAlreadyUsed)
+				refreshTokenDatabase.EXPECT().RevokeRefreshTokenFamily(gomock.Any(), "family").Return(nil)
+			},
+			wantErr: true,
+			err:     domain.ErrRefreshTokenReused,
+		},
+		{
+			name: "fail refreshing when user no longer exists",
+			args: args{
+				ctx:   ctx,
+				input: &RefreshTokenInput{RefreshToken: "old"},
+			},
+			beforeTest: func(userDatabase *mock.MockUserDatabaseGateway, refreshTokenDatabase *mock.MockRefreshTokenDatabaseGateway, tokenService *mock.MockTokenService) {
+				tokenService.EXPECT().HashRefreshToken("old").Return("oldHash")
+				refreshTokenDatabase.EXPECT().GetRefreshToken(gomock.Any(), "oldHash").Return(storedRefreshToken(now.Add(24*time.Hour)), nil)
+				userDatabase.EXPECT().GetUser(gomock.Any(), gomock.Any()).Return(getUserNotFoundResult())
+			},
+			wantErr: true,
+			err:     domain.ErrInvalidRefreshToken,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockUserDatabase := mock.NewMockUserDatabaseGateway(ctrl)
+			mockRefreshTokenDatabase := mock.NewMockRefreshTokenDatabaseGateway(ctrl)
+			mockTokenService := mock.NewMockTokenService(ctrl)
+			validator := domain.NewValidatorService()
+
+			usecase := &refreshTokenUseCase{
+				userDatabase:         mockUserDatabase,
+				refreshTokenDatabase: mockRefreshTokenDatabase,
+				tokenService:         mockTokenService,
+				validator:            validator,
+				now:                  func() time.Time { return now },
+			}
+
+			if tt.beforeTest != nil {
+				tt.beforeTest(mockUserDatabase, mockRefreshTokenDatabase, mockTokenService)
+			}
+
+			refreshResult, err := usecase.Execute(tt.args.ctx, *tt.args.input)
+
+			if (err != nil) != tt.wantErr {
+				t.Errorf("refreshToken.Execute() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if !reflect.DeepEqual(refreshResult, tt.want) {
+				t.Errorf("refreshToken.Execute() = %v, want %v", refreshResult, tt.want)
+			}
+			if tt.wantErr {
+				if err.Error() != tt.err.Error() {
+					t.Errorf("refreshToken.Execute() error = %v, wantErr %v", err, tt.err)
+					return
+				}
+			}
+		})
+	}
+}
+
+func storedRefreshToken(expiresAt time.Time) *entities.RefreshToken {
+	return &entities.RefreshToken{
+		ID:        10,
+		UserID:    1,
+		FamilyID:  "family",
+		TokenHash: "oldHash",
+		ExpiresAt: expiresAt,
+	}
+}
@@ -0,0 +1,124 @@
+package usecases
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/lyracampos/go-clean-architecture/internal/domain"
+	"github.com/lyracampos/go-clean-architecture/internal/domain/ports"
+)
+
+var _ RelayEventsUseCase = (*relayEventsUseCase)(nil)
+
+type RelayEventsUseCase interface {
+	Execute(ctx context.Context, input RelayEventsInput) (RelayEventsOutput, error)
+}
+
+type RelayEventsInput struct {
+	// BatchSize is the maximum number of events relayed at once
+	BatchSize int `validate:"min=1,max=1000"`
+	// Lease is how long the claimed events are hidden from other workers
+	Lease time.Duration `validate:"required"`
+}
+
+type RelayEventsOutput struct {
+	Claimed      int
+	Delivered    int
+	Retried      int
+	DeadLettered int
+}
+
+// RetryPolicy spaces the attempts of a failing delivery exponentially.
+type RetryPolicy struct {
+	// MaxAttempts is the number of attempts before giving up a delivery
+	MaxAttempts int
+	BaseDelay   time.Duration
+	MaxDelay    time.Duration
+}
+
+// Delay returns how long to wait after the given failed attempt, starting at 1.
+func (p RetryPolicy) Delay(attempts int) time.Duration {
+	delay := p.BaseDelay
+	for i := 1; i < attempts && delay < p.MaxDelay; i++ {
+		delay *= 2
+	}
+
+	return min(delay, p.MaxDelay)
+}
+
+// Exhausted reports whether no attempt is left after the given one.
+func (p RetryPolicy) Exhausted(attempts int) bool {
+	return attempts >= p.MaxAttempts
+}
+
+type relayEventsUseCase struct {
+	outboxDatabase ports.OutboxDatabaseGateway
+	publishers     []ports.EventPublisher
+	retryPolicy    RetryPolicy
+	validator      domain.Validator
+}
+
+func NewRelayEventsUseCase(
+	outboxDatabase ports.OutboxDatabaseGateway,
+	publishers []ports.EventPublisher,
+	retryPolicy RetryPolicy,
+	validator domain.Validator,
+) *relayEventsUseCase {
+	return &relayEventsUseCase{
+		outboxDatabase: outboxDatabase,
+		publishers:     publishers,
+		retryPolicy:    retryPolicy,
+		validator:      validator,
+	}
+}
+
+// Execute delivers a batch of outbox events to every publisher. Delivery is at least once:
+// an event is retried as a whole when any publisher fails, so consumers must discard
+// the events whose ID they already processed.
+func (u *relayEventsUseCase) Execute(ctx context.Context, input RelayEventsInput) (RelayEventsOutput, error) {
+	err := u.validator.Validate(input)
+	if err != nil {
+		return RelayEventsOutput{}, fmt.Errorf("input is invalid: %w", err)
+	}
+
+	claimedEvents, err := u.outboxDatabase.ClaimEvents(ctx, input.BatchSize, input.Lease)
+	if err != nil {
+		return RelayEventsOutput{}, fmt.Errorf("failed to claim events from database: %w", err)
+	}
+
+	output := RelayEventsOutput{Claimed: len(claimedEvents)}
+
+	for _, claimed := range claimedEvents {
+		publishErr := u.publish(ctx, claimed.Event)
+
+		switch {
+		case publishErr == nil:
+			err = u.outboxDatabase.MarkEventDelivered(ctx, claimed.Event.ID)
+			output.Delivered++
+		case errors.Is(publishErr, domain.ErrEventRejected), u.retryPolicy.Exhausted(claimed.Attempts):
+			err = u.outboxDatabase.MarkEventDead(ctx, claimed.Event.ID, publishErr.Error())
+			output.DeadLettered++
+		default:
+			err = u.outboxDatabase.MarkEventFailed(ctx, claimed.Event.ID, publishErr.Error(), u.retryPolicy.Delay(claimed.Attempts))
+			output.Retried++
+		}
+
+		if err != nil {
+			return output, fmt.Errorf("failed to update event %s into database: %w", claimed.Event.ID, err)
+		}
+	}
+
+	return output, nil
+}
+
+func (u *relayEventsUseCase) publish(ctx context.Context, event domain.Event) error {
+	for _, publisher := range u.publishers {
+		if err := publisher.Publish(ctx, event); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,197 @@
+package usecases
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/lyracampos/go-clean-architecture/internal/domain"
+	"github.com/lyracampos/go-clean-architecture/internal/domain/ports"
+	mock "github.com/lyracampos/go-clean-architecture/internal/domain/ports/mocks"
+	"go.uber.org/mock/gomock"
+)
+
+func TestRelayEventsExecute(t *testing.T) {
+	ctx := context.Background()
+	input := &RelayEventsInput{BatchSize: 10, Lease: 30 * time.Second}
+	event := domain.Event{ID: "event", AggregateType: "user", AggregateID: "1", Type: domain.EventUserCreated, SchemaVersion: 1}
+
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	type args struct {
+		ctx   context.Context
+		input *RelayEventsInput
+	}
+	tests := []struct {
+		name       string
+		args       args
+		beforeTest func(outboxDatabase *mock.MockOutboxDatabaseGateway, firstPublisher, secondPublisher *mock.MockEventPublisher)
+		want       RelayEventsOutput
+		wantErr    bool
+		err        error
+	}{
+		{
+			name: "success delivering events to every publisher",
+			args: args{
+				ctx:   ctx,
+				input: input,
+			},
+			beforeTest: func(outboxDatabase *mock.MockOutboxDatabaseGateway, firstPublisher, secondPublisher *mock.MockEventPublisher) {
+				outboxDatabase.EXPECT().ClaimEvents(gomock.Any(), 10, 30*time.Second).Return([]ports.ClaimedEvent{{Event: event, Attempts: 1}}, nil)
+				firstPublisher.EXPECT().Publish(gomock.Any(), event).Return(nil)
+				secondPublisher.EXPECT().Publish(gomock.Any(), event).Return(nil)
+				outboxDatabase.EXPECT().MarkEventDelivered(gomock.Any(), "event").Return(nil)
+			},
+			want: RelayEventsOutput{Claimed: 1, Delivered: 1},
+		},
+		{
+			name: "success relaying an empty outbox",
+			args: args{
+				ctx:   ctx,
+				input: input,
+			},
+			beforeTest: func(outboxDatabase *mock.MockOutboxDatabaseGateway, firstPublisher, secondPublisher *mock.MockEventPublisher) {
+				outboxDatabase.EXPECT().ClaimEvents(gomock.Any(), 10, 30*time.Second).Return([]ports.ClaimedEvent{}, nil)
+			},
+			want: RelayEventsOutput{},
+		},
+		{
+			name: "success scheduling a retry with backoff when a publisher fails",
+			args: args{
+				ctx:   ctx,
+				input: input,
+			},
+			beforeTest: func(outboxDatabase *mock.MockOutboxDatabaseGateway, firstPublisher, secondPublisher *mock.MockEventPublisher) {
+				outboxDatabase.EXPECT().ClaimEvents(gomock.Any(), 10, 30*time.Second).Return([]ports.ClaimedEvent{{Event: event, Attempts: 3}}, nil)
+				firstPublisher.EXPECT().Publish(gomock.Any(), event).Return(nil)
+				secondPublisher.EXPECT().Publish(gomock.Any(), event).Return(errors.New("connection refused"))
+				outboxDatabase.EXPECT().MarkEventFailed(gomock.Any(), "event", "connection refused", 4*time.Second).Return(nil)
+			},
+			want: RelayEventsOutput{Claimed: 1, Retried: 1},
+		},
+		{
+			name: "success dead lettering an event out of attempts",
+			args: args{
+				ctx:   ctx,
+				input: input,
+			},
+			beforeTest: func(outboxDatabase *mock.MockOutboxDatabaseGateway, firstPublisher, secondPublisher *mock.MockEventPublisher) {
+				outboxDatabase.EXPECT().ClaimEvents(gomock.Any(), 10, 30*time.Second).Return([]ports.ClaimedEvent{{Event: event, Attempts: 5}}, nil)
+				firstPublisher.EXPECT().Publish(gomock.Any(), event).Return(errors.New("connection refused"))
+				outboxDatabase.EXPECT().MarkEventDead(gomock.Any(), "event", "connection refused").Return(nil)
+			},
+			want: RelayEventsOutput{Claimed: 1, DeadLettered: 1},
+		},
+		{
+			name: "success dead lettering a rejected event right away",
+			args: args{
+				ctx:   ctx,
+				input: input,
+			},
+			beforeTest: func(outboxDatabase *mock.MockOutboxDatabaseGateway, firstPublisher, secondPublisher *mock.MockEventPublisher) {
+				outboxDatabase.EXPECT().ClaimEvents(gomock.Any(), 10, 30*time.Second).Return([]ports.ClaimedEvent{{Event: event, Attempts: 1}}, nil)
+				firstPublisher.EXPECT().Publish(gomock.Any(), event).Return(fmt.Errorf("%w: unknown event type", domain.ErrEventRejected))
+				outboxDatabase.EXPECT().MarkEventDead(gomock.Any(), "event", "event was rejected, retrying won't help: unknown event type").Return(nil)
+			},
+			want: RelayEventsOutput{Claimed: 1, DeadLettered: 1},
+		},
+		{
+			name: "fail relaying events with invalid batch size",
+			args: args{
+				ctx:   ctx,
+				input: &RelayEventsInput{BatchSize: 0, Lease: 30 * time.Second},
+			},
+			beforeTest: nil,
+			wantErr:    true,
+			err:        errors.New("input is invalid: the field 'BatchSize' min size is 1; "),
+		},
+		{
+			name: "fail relaying events when claiming fails",
+			args: args{
+				ctx:   ctx,
+				input: input,
+			},
+			beforeTest: func(outboxDatabase *mock.MockOutboxDatabaseGateway, firstPublisher, secondPublisher *mock.MockEventPublisher) {
+				outboxDatabase.EXPECT().ClaimEvents(gomock.Any(), 10, 30*time.Second).Return(nil, domain.ErrDeadlock)
+			},
+			wantErr: true,
+			err:     errors.New("failed to claim events from database: deadlock detected"),
+		},
+		{
+			name: "fail relaying events when marking fails",
+			args: args{
+				ctx:   ctx,
+				input: input,
+			},
+			beforeTest: func(outboxDatabase *mock.MockOutboxDatabaseGateway, firstPublisher, secondPublisher *mock.MockEventPublisher) {
+				outboxDatabase.EXPECT().ClaimEvents(gomock.Any(), 10, 30*time.Second).Return([]ports.ClaimedEvent{{Event: event, Attempts: 1}}, nil)
+				firstPublisher.EXPECT().Publish(gomock.Any(), event).Return(nil)
+				secondPublisher.EXPECT().Publish(gomock.Any(), event).Return(nil)
+				outboxDatabase.EXPECT().MarkEventDelivered(gomock.Any(), "event").Return(domain.ErrEventDoesNotExist)
+			},
+			want:    RelayEventsOutput{Claimed: 1, Delivered: 1},
+			wantErr: true,
+			err:     errors.New("failed to update event event into database: event does not exist"),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockOutboxDatabase := mock.NewMockOutboxDatabaseGateway(ctrl)
+			mockFirstPublisher := mock.NewMockEventPublisher(ctrl)
+			mockSecondPublisher := mock.NewMockEventPublisher(ctrl)
+
+			usecase := &relayEventsUseCase{
+				outboxDatabase: mockOutboxDatabase,
+				publishers:     []ports.EventPublisher{mockFirstPublisher, mockSecondPublisher},
+				retryPolicy:    RetryPolicy{MaxAttempts: 5, BaseDelay: time.Second, MaxDelay: time.Minute},
+				validator:      domain.NewValidatorService(),
+			}
+
+			if tt.beforeTest != nil {
+				tt.beforeTest(mockOutboxDatabase, mockFirstPublisher, mockSecondPublisher)
+			}
+
+			relayed, err := usecase.Execute(tt.args.ctx, *tt.args.input)
+
+			if (err != nil) != tt.wantErr {
+				t.Errorf("relayEvents.Execute() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if !reflect.DeepEqual(relayed, tt.want) {
+				t.Errorf("relayEvents.Execute() = %v, want %v", relayed, tt.want)
+			}
+			if tt.wantErr {
+				if err.Error() != tt.err.Error() {
+					t.Errorf("relayEvents.Execute() error = %v, wantErr %v", err, tt.err)
+					return
+				}
+			}
+		})
+	}
+}
+
+func TestRetryPolicyDelay(t *testing.T) {
+	policy := RetryPolicy{MaxAttempts: 10, BaseDelay: time.Second, MaxDelay: 10 * time.Second}
+
+	tests := []struct {
+		attempts int
+		want     time.Duration
+	}{
+		{attempts: 1, want: time.Second},
+		{attempts: 2, want: 2 * time.Second},
+		{attempts: 4, want: 8 * time.Second},
+		{attempts: 5, want: 10 * time.Second},
+		{attempts: 60, want: 10 * time.Second},
+	}
+
+	for _, tt := range tests {
+		if got := policy.Delay(tt.attempts); got != tt.want {
+			t.Errorf("RetryPolicy.Delay(%d) = %v, want %v", tt.attempts, got, tt.want)
+		}
+	}
+}
@@ -0,0 +1,70 @@
+package usecases
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/lyracampos/go-clean-architecture/internal/domain"
+	"github.com/lyracampos/go-clean-architecture/internal/domain/entities"
+	"github.com/lyracampos/go-clean-architecture/internal/domain/ports"
+)
+
+var _ RestoreUserUseCase = (*restoreUserUseCase)(nil)
+
+type RestoreUserUseCase interface {
+	Execute(ctx context.Context, input RestoreUserInput) (RestoreUserOutput, error)
+}
+
+type RestoreUserInput struct {
+	ID int64
+	// Versions are the user versions the restore may apply to, entities.AnyVersion skips the check.
+	Versions []int64
+}
+
+type RestoreUserOutput struct {
+	ID        int64
+	FirstName string
+	LastName  string
+	Email     string
+	Role      string
+	CreatedAt time.Time
+	UpdatedAt time.Time
+	Version   int64
+}
+
+type restoreUserUseCase struct {
+	userDatabaseGateway ports.UserDatabaseGateway
+}
+
+func NewRestoreUserUseCase(userDatabaseGateway ports.UserDatabaseGateway) *restoreUserUseCase {
+	return &restoreUserUseCase{
+		userDatabaseGateway: userDatabaseGateway,
+	}
+}
+
+func (u *restoreUserUseCase) Execute(ctx context.Context, input RestoreUserInput) (RestoreUserOutput, error) {
+	if err := domain.Authorize(ctx, domain.PermissionUsersDelete, input.ID); err != nil {
+		return RestoreUserOutput{}, err
+	}
+
+	user, err := u.userDatabaseGateway.RestoreUser(ctx, input.ID, input.Versions)
+	if err != nil {
+		return RestoreUserOutput{}, fmt.Errorf("failed to restore user into database: %w", err)
+	}
+
+	return u.fromEntityToOutput(user), nil
+}
+
+func (u *restoreUserUseCase) fromEntityToOutput(user *entities.User) RestoreUserOutput {
+	return RestoreUserOutput{
+		ID:        user.ID,
+		FirstName: user.FirstName,
+		LastName:  user.LastName,
+		Email:     user.Email,
+		Role:      user.Role,
+		CreatedAt: user.CreatedAt,
+		UpdatedAt: user.UpdatedAt,
+		Version:   user.Version,
+	}
+}
@@ -0,0 +1,119 @@
+package usecases
+
+import (
+	"context"
+	"errors"
+	"reflect"
+	"testing"
+
+	"github.com/lyracampos/go-clean-architecture/internal/domain"
+	"github.com/lyracampos/go-clean-architecture/internal/domain/entities"
+	mock "github.com/lyracampos/go-clean-architecture/internal/domain/ports/mocks"
+	"go.uber.org/mock/gomock"
+)
+
+func TestRestoreUserExecute(t *testing.T) {
+	ctx := adminContext()
+
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	type args struct {
+		ctx   context.Context
+		input *RestoreUserInput
+	}
+	tests := []struct {
+		name       string
+		args       args
+		beforeTest func(userDatabase *mock.MockUserDatabaseGateway)
+		want       RestoreUserOutput
+		wantErr    bool
+		err        error
+	}{
+		{
+			name: "success restoring user",
+			args: args{
+				ctx:   ctx,
+				input: &RestoreUserInput{ID: 1, Versions: []int64{2}},
+			},
+			beforeTest: func(userDatabase *mock.MockUserDatabaseGateway) {
+				userDatabase.EXPECT().RestoreUser(gomock.Any(), int64(1), []int64{2}).Return(defaultGetUserResult())
+			},
+			want: RestoreUserOutput{
+				ID:        1,
+				FirstName: "firstName",
+				LastName:  "lastName",
+				Email:     "useremail@domain.com",
+				Role:      "admin",
+			},
+		},
+		{
+			name: "fail restoring user when id not found",
+			args: args{
+				ctx:   ctx,
+				input: &RestoreUserInput{ID: 1, Versions: []int64{2}},
+			},
+			beforeTest: func(userDatabase *mock.MockUserDatabaseGateway) {
+				userDatabase.EXPECT().RestoreUser(gomock.Any(), int64(1), []int64{2}).Return(restoreUserNotFoundResult())
+			},
+			wantErr: true,
+			err:     errors.New("failed to restore user into database: user does not exist"),
+		},
+		{
+			name: "fail restoring user when email was taken by another user",
+			args: args{
+				ctx:   ctx,
+				input: &RestoreUserInput{ID: 1, Versions: []int64{2}},
+			},
+			beforeTest: func(userDatabase *mock.MockUserDatabaseGateway) {
+				userDatabase.EXPECT().RestoreUser(gomock.Any(), int64(1), []int64{2}).Return(nil, domain.ErrEmailAlreadyInUse)
+			},
+			wantErr: true,
+			err:     errors.New("failed to restore user into database: email is arealdy in use"),
+		},
+		{
+			name: "fail restoring user as contributor",
+			args: args{
+				ctx:   contributorContext(1),
+				input: &RestoreUserInput{ID: 1, Versions: []int64{2}},
+			},
+			beforeTest: nil,
+			wantErr:    true,
+			err:        domain.ErrForbidden,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockUserDatabase := mock.NewMockUserDatabaseGateway(ctrl)
+
+			usecase := &restoreUserUseCase{
+				userDatabaseGateway: mockUserDatabase,
+			}
+
+			if tt.beforeTest != nil {
+				tt.beforeTest(mockUserDatabase)
+			}
+
+			restoredUser, err := usecase.Execute(tt.args.ctx, *tt.args.input)
+
+			if (err != nil) != tt.wantErr {
+				t.Errorf("restoreUser.Execute() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if !reflect.DeepEqual(restoredUser, tt.want) {
+				t.Errorf("restoreUser.Execute() = %v, want %v", restoredUser, tt.want)
+			}
+			if tt.wantErr {
+				if err.Error() != tt.err.Error() {
+					t.Errorf("restoreUser.Execute() error = %v, wantErr %v", err, tt.err)
+					return
+				}
+			}
+		})
+	}
+}
+
+func restoreUserNotFoundResult() (*entities.User, error) {
+	return nil, domain.ErrUserDoesNotExist
+}
@@ -0,0 +1,87 @@
+package usecases
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/lyracampos/go-clean-architecture/internal/domain"
+	"github.com/lyracampos/go-clean-architecture/internal/domain/entities"
+	"github.com/lyracampos/go-clean-architecture/internal/domain/ports"
+)
+
+var _ SetPasswordUseCase = (*setPasswordUseCase)(nil)
+
+type SetPasswordUseCase interface {
+	Execute(ctx context.Context, input SetPasswordInput) error
+}
+
+// swagger:model
+type SetPasswordInput struct {
+	UserID int64 `json:"-"`
+	// new user password, at least 12 characters and not a common password
+	//
+	// required: true
+	Password string `validate:"required,min=12,maxBytes=72,notcommon"`
+}
+
+type setPasswordUseCase struct {
+	userDatabase         ports.UserDatabaseGateway
+	credentialsDatabase  ports.CredentialsDatabaseGateway
+	refreshTokenDatabase ports.RefreshTokenDatabaseGateway
+	transactor           ports.Transactor
+	passwordHasher       ports.PasswordHasher
+	validator            domain.Validator
+}
+
+func NewSetPasswordUseCase(
+	userDatabase ports.UserDatabaseGateway,
+	credentialsDatabase ports.CredentialsDatabaseGateway,
+	refreshTokenDatabase ports.RefreshTokenDatabaseGateway,
+	transactor ports.Transactor,
+	passwordHasher ports.PasswordHasher,
+	validator domain.Validator,
+) *setPasswordUseCase {
+	return &setPasswordUseCase{
+		userDatabase:         userDatabase,
+		credentialsDatabase:  credentialsDatabase,
+		refreshTokenDatabase: refreshTokenDatabase,
+		transactor:           transactor,
+		passwordHasher:       passwordHasher,
+		validator:            validator,
+	}
+}
+
+func (u *setPasswordUseCase) Execute(ctx context.Context, input SetPasswordInput) error {
+	if err := domain.Authorize(ctx, domain.PermissionUsersWrite, input.UserID); err != nil {
+		return err
+	}
+
+	err := u.validator.Validate(input)
+	if err != nil {
+		return fmt.Errorf("input is invalid: %w", err)
+	}
+
+	user, err := u.userDatabase.GetUser(ctx, ports.GetUserFilter{ID: input.UserID})
+	if err != nil {
+		return err
+	}
+
+	passwordHash, err := u.passwordHasher.Hash(input.Password)
+	if err != nil {
+		return err
+	}
+
+	// the sessions started with the previous password end along with it
+	return u.transactor.RunInTx(ctx, func(ctx context.Context) error {
+		_, err := u.credentialsDatabase.UpsertCredentials(ctx, entities.NewCredentials(user.ID, passwordHash))
+		if err != nil {
+			return fmt.Errorf("failed to store credentials into database: %w", err)
+		}
+
+		if err := u.refreshTokenDatabase.RevokeUserRefreshTokens(ctx, user.ID); err != nil {
+			return fmt.Errorf("failed to revoke refresh tokens from database: %w", err)
+		}
+
+		return nil
+	})
+}
@@ -0,0 +1,189 @@
+package usecases
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/lyracampos/go-clean-architecture/internal/domain"
+	"github.com/lyracampos/go-clean-architecture/internal/domain/entities"
+	"github.com/lyracampos/go-clean-architecture/internal/domain/ports"
+	mock "github.com/lyracampos/go-clean-architecture/internal/domain/ports/mocks"
+	"go.uber.org/mock/gomock"
+)
+
+func TestSetPasswordExecute(t *testing.T) {
+	ctx := adminContext()
+
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	type args struct {
+		ctx   context.Context
+		input *SetPasswordInput
+	}
+	tests := []struct {
+		name       string
+		args       args
+		beforeTest func(userDatabase *mock.MockUserDatabaseGateway, credentialsDatabase *mock.MockCredentialsDatabaseGateway, refreshTokenDatabase *mock.MockRefreshTokenDatabaseGateway, transactor *mock.MockTransactor, passwordHasher *mock.MockPasswordHasher)
+		wantErr    bool
+		err        error
+	}{
+		{
+			name: "success setting user password",
+			args: args{
+				ctx:   ctx,
+				input: &SetPasswordInput{UserID: 1, Password: "a long and unusual secret"},
+			},
+			beforeTest: func(userDatabase *mock.MockUserDatabaseGateway, credentialsDatabase *mock.MockCredentialsDatabaseGateway, refreshTokenDatabase *mock.MockRefreshTokenDatabaseGateway, transactor *mock.MockTransactor, passwordHasher *mock.MockPasswordHasher) {
+				userDatabase.EXPECT().GetUser(gomock.Any(), ports.GetUserFilter{ID: 1}).Return(defaultGetUserResult())
+				passwordHasher.EXPECT().Hash("a long and unusual secret").Return("hash", nil)
+				transactor.EXPECT().RunInTx(gomock.Any(), gomock.Any()).DoAndReturn(runInTx)
+				credentialsDatabase.EXPECT().UpsertCredentials(gomock.Any(), &entities.Credentials{UserID: 1, PasswordHash: "hash"}).
+					Return(&entities.Credentials{UserID: 1, PasswordHash: "hash"}, nil)
+				refreshTokenDatabase.EXPECT().RevokeUserRefreshTokens(gomock.Any(), int64(1)).Return(nil)
+			},
+			wantErr: false,
+		},
+		{
+			name: "fail setting password shorter than the minimum length",
+			args: args{
+				ctx:   ctx,
+				input: &SetPasswordInput{UserID: 1, Password: "short"},
+			},
+			beforeTest: nil,
+			wantErr:    true,
+			err:        errors.New("input is invalid: the field 'Password' min size is 12; "),
+		},
+		{
+			name: "fail setting password longer than 72 bytes",
+			args: args{
+				ctx:   ctx,
+				input: &SetPasswordInput{UserID: 1, Password: "çççççççççççççççççççççççççççççççççççççç"},
+			},
+			beforeTest: nil,
+			wantErr:    true,
+			err:        errors.New("input is invalid: the field 'Password' maxBytes size is 72; "),
+		},
+		{
+			name: "fail setting a common password",
+			args: args{
+				ctx:   ctx,
+				input: &SetPasswordInput{UserID: 1, Password: "Password1234"},
+			},
+			beforeTest: nil,
+			wantErr:    true,
+			err:        errors.New("input is invalid: the field 'Password' is too common, choose a less predictable one; "),
+		},
+		{
+			name: "fail setting password when user not found",
+			args: args{
+				ctx:   ctx,
+				input: &SetPasswordInput{UserID: 1, Password: "a long and unusual secret"},
+			},
+			beforeTest: func(userDatabase *mock.MockUserDatabaseGateway, credentialsDatabase *mock.MockCredentialsDatabaseGateway, refreshTokenDatabase *mock.MockRefreshTokenDatabaseGateway, transactor *mock.MockTransactor, passwordHasher *mock.MockPasswordHasher) {
+				userDatabase.EXPECT().GetUser(gomock.Any(), gomock.Any()).Return(getUserNotFoundResult())
+			},
+			wantErr: true,
+			err:     domain.ErrUserDoesNotExist,
+		},
+		{
+			name: "fail setting password when storing credentials fails",
+			args: args{
+				ctx:   ctx,
+				input: &SetPasswordInput{UserID: 1, Password: "a long and unusual secret"},
+			},
+			beforeTest: func(userDatabase *mock.MockUserDatabaseGateway, credentialsDatabase *mock.MockCredentialsDatabaseGateway, refreshTokenDatabase *mock.MockRefreshTokenDatabaseGateway, transactor *mock.MockTransactor, passwordHasher *mock.MockPasswordHasher) {
+				userDatabase.EXPECT().GetUser(gomock.Any(), gomock.Any()).Return(defaultGetUserResult())
+				passwordHasher.EXPECT().Hash(gomock.Any()).Return("hash", nil)
+				transactor.EXPECT().RunInTx(gomock.Any(), gomock.Any()).DoAndReturn(runInTx)
+				credentialsDatabase.EXPECT().UpsertCredentials(gomock.Any(), gomock.Any()).Return(nil, domain.ErrForeignKeyViolation)
+			},
+			wantErr: true,
+			err:     errors.New("failed to store credentials into database: foreign key constraint violated"),
+		},
+		{
+			name: "fail setting password when revoking refresh tokens fails",
+			args: args{
+				ctx:   ctx,
+				input: &SetPasswordInput{UserID: 1, Password: "a long and unusual secret"},
+			},
+			beforeTest: func(userDatabase *mock.MockUserDatabaseGateway, credentialsDatabase *mock.MockCredentialsDatabaseGateway, refreshTokenDatabase *mock.MockRefreshTokenDatabaseGateway, transactor *mock.MockTransactor, passwordHasher *mock.MockPasswordHasher) {
+				userDatabase.EXPECT().GetUser(gomock.Any(), gomock.Any()).Return(defaultGetUserResult())
+				passwordHasher.EXPECT().Hash(gomock.Any()).Return("hash", nil)
+				transactor.EXPECT().RunInTx(gomock.Any(), gomock.Any()).DoAndReturn(runInTx)
+				credentialsDatabase.EXPECT().UpsertCredentials(gomock.Any(), gomock.Any()).Return(&entities.Credentials{UserID: 1, PasswordHash: "hash"}, nil)
+				refreshTokenDatabase.EXPECT().RevokeUserRefreshTokens(gomock.Any(), int64(1)).Return(domain.ErrDeadlineExceeded)
+			},
+			wantErr: true,
+			err:     errors.New("failed to revoke refresh tokens from database: operation deadline exceeded"),
+		},
+		{
+			name: "success setting own password as contributor",
+			args: args{
+				ctx:   contributorContext(1),
+				input: &SetPasswordInput{UserID: 1, Password: "a long and unusual secret"},
+			},
+			beforeTest: func(userDatabase *mock.MockUserDatabaseGateway, credentialsDatabase *mock.MockCredentialsDatabaseGateway, refreshTokenDatabase *mock.MockRefreshTokenDatabaseGateway, transactor *mock.MockTransactor, passwordHasher *mock.MockPasswordHasher) {
+				userDatabase.EXPECT().GetUser(gomock.Any(), ports.GetUserFilter{ID: 1}).Return(defaultGetUserResult())
+				passwordHasher.EXPECT().Hash(gomock.Any()).Return("hash", nil)
+				transactor.EXPECT().RunInTx(gomock.Any(), gomock.Any()).DoAndReturn(runInTx)
+				credentialsDatabase.EXPECT().UpsertCredentials(gomock.Any(), gomock.Any()).Return(&entities.Credentials{UserID: 1, PasswordHash: "hash"}, nil)
+				refreshTokenDatabase.EXPECT().RevokeUserRefreshTokens(gomock.Any(), int64(1)).Return(nil)
+			},
+			wantErr: false,
+		},
+		{
+			name: "fail setting another user password as contributor",
+			args: args{
+				ctx:   contributorContext(2),
+				input: &SetPasswordInput{UserID: 1, Password: "a long and unusual secret"},
+			},
+			beforeTest: nil,
+			wantErr:    true,
+			err:        domain.ErrForbidden,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockUserDatabase := mock.NewMockUserDatabaseGateway(ctrl)
+			mockCredentialsDatabase := mock.NewMockCredentialsDatabaseGateway(ctrl)
+			mockRefreshTokenDatabase := mock.NewMockRefreshTokenDatabaseGateway(ctrl)
+			mockTransactor := mock.NewMockTransactor(ctrl)
+			mockPasswordHasher := mock.NewMockPasswordHasher(ctrl)
+			validator := domain.NewValidatorService()
+
+			usecase := &setPasswordUseCase{
+				userDatabase:         mockUserDatabase,
+				credentialsDatabase:  mockCredentialsDatabase,
+				refreshTokenDatabase: mockRefreshTokenDatabase,
+				transactor:           mockTransactor,
+				passwordHasher:       mockPasswordHasher,
+				validator:            validator,
+			}
+
+			if tt.beforeTest != nil {
+				tt.beforeTest(mockUserDatabase, mockCredentialsDatabase, mockRefreshTokenDatabase, mockTransactor, mockPasswordHasher)
+			}
+
+			err := usecase.Execute(tt.args.ctx, *tt.args.input)
+
+			if (err != nil) != tt.wantErr {
+				t.Errorf("setPassword.Execute() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if tt.wantErr {
+				if err.Error() != tt.err.Error() {
+					t.Errorf("setPassword.Execute() error = %v, wantErr %v", err, tt.err)
+					return
+				}
+			}
+		})
+	}
+}
+
+// runInTx runs the transaction body right away, as the database would.
+func runInTx(ctx context.Context, fn func(ctx context.Context) error) error {
+	return fn(ctx)
+}
@@ -0,0 +1,166 @@
+package usecases
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/lyracampos/go-clean-architecture/internal/domain"
+	"github.com/lyracampos/go-clean-architecture/internal/domain/entities"
+	"github.com/lyracampos/go-clean-architecture/internal/domain/ports"
+)
+
+var _ UpdateUserUseCase = (*updateUserUseCase)(nil)
+
+type UpdateUserUseCase interface {
+	Execute(ctx context.Context, input UpdateUserInput) (UpdateUserOutput, error)
+}
+
+// UpdateUserInput holds the fields to be changed on an user.
+// Nil fields are left untouched, which gives PATCH semantics;
+// a full replacement (PUT) sets every field.
+//
+// swagger:model
+type UpdateUserInput struct {
+	ID int64 `json:"-"`
+	// Versions are the user versions the change may be based on, entities.AnyVersion skips the check.
+	Versions []int64 `json:"-"`
+	// user first name
+	FirstName *string `validate:"omitempty,min=1"`
+	// user last name
+	LastName *string `validate:"omitempty,min=1"`
+	// user email
+	Email *string `validate:"omitempty,email"`
+	// user role [admin or contributor]
+	Role *string `validate:"omitempty,oneof=admin contributor"`
+}
+
+type UpdateUserInputOption func(*UpdateUserInput)
+
+func NewUpdateUserInput(id int64, opts ...UpdateUserInputOption) *UpdateUserInput {
+	input := &UpdateUserInput{ID: id}
+	for _, opt := range opts {
+		opt(input)
+	}
+
+	return input
+}
+
+func WithUpdateUserInputVersions(versions ...int64) UpdateUserInputOption {
+	return func(u *UpdateUserInput) {
+		u.Versions = versions
+	}
+}
+
+func WithUpdateUserInputFirstName(firstName string) UpdateUserInputOption {
+	return func(u *UpdateUserInput) {
+		u.FirstName = &firstName
+	}
+}
+
+func WithUpdateUserInputLastName(lastName string) UpdateUserInputOption {
+	return func(u *UpdateUserInput) {
+		u.LastName = &lastName
+	}
+}
+
+func WithUpdateUserInputEmail(email string) UpdateUserInputOption {
+	return func(u *UpdateUserInput) {
+		u.Email = &email
+	}
+}
+
+func WithUpdateUserInputRole(role string) UpdateUserInputOption {
+	return func(u *UpdateUserInput) {
+		u.Role = &role
+	}
+}
+
+type UpdateUserOutput struct {
+	ID        int64
+	FirstName string
+	LastName  string
+	Email     string
+	Role      string
+	CreatedAt time.Time
+	UpdatedAt time.Time
+	Version   int64
+}
+
+type updateUserUseCase struct {
+	userDatabase ports.UserDatabaseGateway
+	validator    domain.Validator
+}
+
+func NewUpdateUserUseCase(userDatabase ports.UserDatabaseGateway, validator domain.Validator) *updateUserUseCase {
+	return &updateUserUseCase{
+		userDatabase: userDatabase,
+		validator:    validator,
+	}
+}
+
+func (u *updateUserUseCase) Execute(ctx context.Context, input UpdateUserInput) (UpdateUserOutput, error) {
+	if err := domain.Authorize(ctx, domain.PermissionUsersWrite, input.ID); err != nil {
+		return UpdateUserOutput{}, err
+	}
+
+	err := u.validator.Validate(input)
+	if err != nil {
+		return UpdateUserOutput{}, fmt.Errorf("input is invalid: %w", err)
+	}
+
+	user, err := u.userDatabase.GetUser(ctx, ports.GetUserFilter{ID: input.ID})
+	if err != nil {
+		return UpdateUserOutput{}, err
+	}
+
+	if !user.MatchesVersion(input.Versions) {
+		return UpdateUserOutput{}, domain.ErrVersionConflict
+	}
+
+	if input.Role != nil && *input.Role != user.Role {
+		if err := domain.Authorize(ctx, domain.PermissionUsersChangeRole, user.ID); err != nil {
+			return UpdateUserOutput{}, err
+		}
+	}
+
+	u.applyInput(user, input)
+
+	updatedUser, err := u.userDatabase.UpdateUser(ctx, user)
+	if err != nil {
+		return UpdateUserOutput{}, fmt.Errorf("failed to update user into database: %w", err)
+	}
+
+	return u.fromEntityToOutput(updatedUser), nil
+}
+
+func (u *updateUserUseCase) applyInput(user *entities.User, input UpdateUserInput) {
+	if input.FirstName != nil {
+		user.FirstName = *input.FirstName
+	}
+
+	if input.LastName != nil {
+		user.LastName = *input.LastName
+	}
+
+	if input.Email != nil {
+		user.Email = *input.Email
+	}
+
+	if input.Role != nil {
+		user.Role = *input.Role
+	}
+}
+
+func (u *updateUserUseCase) fromEntityToOutput(user *entities.User) UpdateUserOutput {
+	return UpdateUserOutput{
+		ID:        user.ID,
+		FirstName: user.FirstName,
+		LastName:  user.LastName,
+		Email:     user.Email,
+		Role:      user.Role,
+		CreatedAt: user.CreatedAt,
+		UpdatedAt: user.UpdatedAt,
+		Version:   user.Version,
+	}
+}
@@ -0,0 +1,297 @@
+package usecases
+
+import (
+	"context"
+	"errors"
+	"reflect"
+	"testing"
+
+	"github.com/lyracampos/go-clean-architecture/internal/domain"
+	"github.com/lyracampos/go-clean-architecture/internal/domain/entities"
+	"github.com/lyracampos/go-clean-architecture/internal/domain/ports"
+	mock "github.com/lyracampos/go-clean-architecture/internal/domain/ports/mocks"
+	"go.uber.org/mock/gomock"
+)
+
+func TestUpdateUserExecute(t *testing.T) {
+	ctx := adminContext()
+
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	type args struct {
+		ctx   context.Context
+		input *UpdateUserInput
+	}
+	tests := []struct {
+		name       string
+		args       args
+		beforeTest func(userDatabase *mock.MockUserDatabaseGateway)
+		want       UpdateUserOutput
+		wantErr    bool
+		err        error
+	}{
+		{
+			name: "success replacing all user fields",
+			args: args{
+				ctx:   ctx,
+				input: defaultUpdateUserInput(),
+			},
+			beforeTest: func(userDatabase *mock.MockUserDatabaseGateway) {
+				userDatabase.EXPECT().GetUser(gomock.Any(), ports.GetUserFilter{ID: 1}).Return(defaultGetUserResult())
+				userDatabase.EXPECT().UpdateUser(gomock.Any(), &entities.User{
+					ID:        1,
+					FirstName: "newFirstName",
+					LastName:  "newLastName",
+					Email:     "newemail@domain.com",
+					Role:      "contributor",
+				}).DoAndReturn(updateUserResult)
+			},
+			want: UpdateUserOutput{
+				ID:        1,
+				FirstName: "newFirstName",
+				LastName:  "newLastName",
+				Email:     "newemail@domain.com",
+				Role:      "contributor",
+			},
+		},
+		{
+			name: "success patching only the informed fields",
+			args: args{
+				ctx:   ctx,
+				input: NewUpdateUserInput(1, WithUpdateUserInputVersions(entities.AnyVersion), WithUpdateUserInputLastName("newLastName")),
+			},
+			beforeTest: func(userDatabase *mock.MockUserDatabaseGateway) {
+				userDatabase.EXPECT().GetUser(gomock.Any(), ports.GetUserFilter{ID: 1}).Return(defaultGetUserResult())
+				userDatabase.EXPECT().UpdateUser(gomock.Any(), &entities.User{
+					ID:        1,
+					FirstName: "firstName",
+					LastName:  "newLastName",
+					Email:     "useremail@domain.com",
+					Role:      "admin",
+				}).DoAndReturn(updateUserResult)
+			},
+			want: UpdateUserOutput{
+				ID:        1,
+				FirstName: "firstName",
+				LastName:  "newLastName",
+				Email:     "useremail@domain.com",
+				Role:      "admin",
+			},
+		},
+		{
+			name: "fail updating user with empty first name",
+			args: args{
+				ctx:   ctx,
+				input: defaultUpdateUserInput(WithUpdateUserInputFirstName("")),
+			},
+			beforeTest: nil,
+			wantErr:    true,
+			err:        errors.New("input is invalid: the field 'FirstName' min size is 1; "),
+		},
+		{
+			name: "fail updating user with invalid email",
+			args: args{
+				ctx:   ctx,
+				input: NewUpdateUserInput(1, WithUpdateUserInputEmail("invalid@domain")),
+			},
+			beforeTest: nil,
+			wantErr:    true,
+			err:        errors.New("input is invalid: the field 'Email' is invalid; "),
+		},
+		{
+			name: "fail updating user with invalid role",
+			args: args{
+				ctx:   ctx,
+				input: NewUpdateUserInput(1, WithUpdateUserInputRole("invalid")),
+			},
+			beforeTest: nil,
+			wantErr:    true,
+			err:        errors.New("input is invalid: the field 'Role' is not valid, expected one of [admin contributor]; "),
+		},
+		{
+			name: "fail updating user when id not found",
+			args: args{
+				ctx:   ctx,
+				input: defaultUpdateUserInput(),
+			},
+			beforeTest: func(userDatabase *mock.MockUserDatabaseGateway) {
+				userDatabase.EXPECT().GetUser(gomock.Any(), gomock.Any()).Return(getUserNotFoundResult())
+			},
+			wantErr: true,
+			err:     domain.ErrUserDoesNotExist,
+		},
+		{
+			name: "fail updating user when version is stale",
+			args: args{
+				ctx:   ctx,
+				input: defaultUpdateUserInput(WithUpdateUserInputVersions(2)),
+			},
+			beforeTest: func(userDatabase *mock.MockUserDatabaseGateway) {
+				userDatabase.EXPECT().GetUser(gomock.Any(), gomock.Any()).Return(defaultGetUserResult())
+			},
+			wantErr: true,
+			err:     domain.ErrVersionConflict,
+		},
+		{
+			name: "success updating user at one of the versions informed",
+			args: args{
+				ctx:   ctx,
+				input: NewUpdateUserInput(1, WithUpdateUserInputVersions(2, 3), WithUpdateUserInputLastName("newLastName")),
+			},
+			beforeTest: func(userDatabase *mock.MockUserDatabaseGateway) {
+				userDatabase.EXPECT().GetUser(gomock.Any(), ports.GetUserFilter{ID: 1}).Return(&entities.User{
+					ID:      1,
+					Role:    "admin",
+					Version: 3,
+				}, nil)
+				userDatabase.EXPECT().UpdateUser(gomock.Any(), &entities.User{
+					ID:       1,
+					LastName: "newLastName",
+					Role:     "admin",
+					Version:  3,
+				}).DoAndReturn(updateUserResult)
+			},
+			want: UpdateUserOutput{
+				ID:       1,
+				LastName: "newLastName",
+				Role:     "admin",
+				Version:  3,
+			},
+		},
+		{
+			name: "success updating user whatever its version",
+			args: args{
+				ctx:   ctx,
+				input: NewUpdateUserInput(1, WithUpdateUserInputVersions(entities.AnyVersion), WithUpdateUserInputLastName("newLastName")),
+			},
+			beforeTest: func(userDatabase *mock.MockUserDatabaseGateway) {
+				userDatabase.EXPECT().GetUser(gomock.Any(), ports.GetUserFilter{ID: 1}).Return(&entities.User{
+					ID:        1,
+					FirstName: "firstName",
+					LastName:  "lastName",
+					Email:     "useremail@domain.com",
+					Role:      "admin",
+					Version:   3,
+				}, nil)
+				userDatabase.EXPECT().UpdateUser(gomock.Any(), &entities.User{
+					ID:        1,
+					FirstName: "firstName",
+					LastName:  "newLastName",
+					Email:     "useremail@domain.com",
+					Role:      "admin",
+					Version:   3,
+				}).DoAndReturn(updateUserResult)
+			},
+			want: UpdateUserOutput{
+				ID:        1,
+				FirstName: "firstName",
+				LastName:  "newLastName",
+				Email:     "useremail@domain.com",
+				Role:      "admin",
+				Version:   3,
+			},
+		},
+		{
+			name: "fail updating user when email is already in use",
+			args: args{
+				ctx:   ctx,
+				input: defaultUpdateUserInput(),
+			},
+			beforeTest: func(userDatabase *mock.MockUserDatabaseGateway) {
+				userDatabase.EXPECT().GetUser(gomock.Any(), gomock.Any()).Return(defaultGetUserResult())
+				userDatabase.EXPECT().UpdateUser(gomock.Any(), gomock.Any()).Return(emailIsAlreadyInUseResult())
+			},
+			wantErr: true,
+			err:     errors.New("failed to update user into database: email is arealdy in use"),
+		},
+		{
+			name: "success patching own user as contributor",
+			args: args{
+				ctx:   contributorContext(1),
+				input: NewUpdateUserInput(1, WithUpdateUserInputVersions(entities.AnyVersion), WithUpdateUserInputLastName("newLastName"), WithUpdateUserInputRole("admin")),
+			},
+			beforeTest: func(userDatabase *mock.MockUserDatabaseGateway) {
+				userDatabase.EXPECT().GetUser(gomock.Any(), ports.GetUserFilter{ID: 1}).Return(defaultGetUserResult())
+				userDatabase.EXPECT().UpdateUser(gomock.Any(), gomock.Any()).DoAndReturn(updateUserResult)
+			},
+			want: UpdateUserOutput{
+				ID:        1,
+				FirstName: "firstName",
+				LastName:  "newLastName",
+				Email:     "useremail@domain.com",
+				Role:      "admin",
+			},
+		},
+		{
+			name: "fail changing own role as contributor",
+			args: args{
+				ctx:   contributorContext(1),
+				input: defaultUpdateUserInput(),
+			},
+			beforeTest: func(userDatabase *mock.MockUserDatabaseGateway) {
+				userDatabase.EXPECT().GetUser(gomock.Any(), gomock.Any()).Return(defaultGetUserResult())
+			},
+			wantErr: true,
+			err:     domain.ErrForbidden,
+		},
+		{
+			name: "fail updating another user as contributor",
+			args: args{
+				ctx:   contributorContext(2),
+				input: NewUpdateUserInput(1, WithUpdateUserInputVersions(entities.AnyVersion), WithUpdateUserInputLastName("newLastName")),
+			},
+			beforeTest: nil,
+			wantErr:    true,
+			err:        domain.ErrForbidden,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockUserDatabase := mock.NewMockUserDatabaseGateway(ctrl)
+			validator := domain.NewValidatorService()
+
+			usecase := &updateUserUseCase{
+				userDatabase: mockUserDatabase,
+				validator:    validator,
+			}
+
+			if tt.beforeTest != nil {
+				tt.beforeTest(mockUserDatabase)
+			}
+
+			updatedUser, err := usecase.Execute(tt.args.ctx, *tt.args.input)
+
+			if (err != nil) != tt.wantErr {
+				t.Errorf("updateUser.Execute() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if !reflect.DeepEqual(updatedUser, tt.want) {
+				t.Errorf("updateUser.Execute() = %v, want %v", updatedUser, tt.want)
+			}
+			if tt.wantErr {
+				if err.Error() != tt.err.Error() {
+					t.Errorf("updateUser.Execute() error = %v, wantErr %v", err, tt.err)
+					return
+				}
+			}
+		})
+	}
+}
+
+func defaultUpdateUserInput(options ...UpdateUserInputOption) *UpdateUserInput {
+	options = append([]UpdateUserInputOption{
+		WithUpdateUserInputFirstName("newFirstName"),
+		WithUpdateUserInputLastName("newLastName"),
+		WithUpdateUserInputEmail("newemail@domain.com"),
+		WithUpdateUserInputRole("contributor"),
+		WithUpdateUserInputVersions(entities.AnyVersion),
+	}, options...)
+
+	return NewUpdateUserInput(1, options...)
+}
+
+func updateUserResult(_ context.Context, user *entities.User) (*entities.User, error) {
+	return user, nil
+}
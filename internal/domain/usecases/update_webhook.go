@@ -0,0 +1,88 @@
+package usecases
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/lyracampos/go-clean-architecture/internal/domain"
+	"github.com/lyracampos/go-clean-architecture/internal/domain/ports"
+)
+
+var _ UpdateWebhookUseCase = (*updateWebhookUseCase)(nil)
+
+type UpdateWebhookUseCase interface {
+	Execute(ctx context.Context, input UpdateWebhookInput) (WebhookOutput, error)
+}
+
+// swagger:model
+type UpdateWebhookInput struct {
+	ID int64 `json:"-"`
+	// URL the events are posted to
+	//
+	// required: true
+	URL string `validate:"required,http_url"`
+	// event types the webhook is subscribed to [user.created, user.updated, user.deleted, user.role_changed]
+	//
+	// required: true
+	EventTypes []string `validate:"required,min=1,dive,oneof=user.created user.updated user.deleted user.role_changed"`
+	// new secret the X-Signature header is computed with, the current one is kept when empty
+	Secret string `validate:"omitempty,min=16,max=256"`
+	// enabling a webhook disabled after failures resets its failure count
+	Enabled bool
+}
+
+type updateWebhookUseCase struct {
+	webhookDatabase ports.WebhookDatabaseGateway
+	validator       domain.Validator
+	now             func() time.Time
+}
+
+func NewUpdateWebhookUseCase(webhookDatabase ports.WebhookDatabaseGateway, validator domain.Validator) *updateWebhookUseCase {
+	return &updateWebhookUseCase{
+		webhookDatabase: webhookDatabase,
+		validator:       validator,
+		now:             time.Now,
+	}
+}
+
+func (u *updateWebhookUseCase) Execute(ctx context.Context, input UpdateWebhookInput) (WebhookOutput, error) {
+	if _, _, err := domain.RequirePermission(ctx, domain.PermissionWebhooksManage); err != nil {
+		return WebhookOutput{}, err
+	}
+
+	err := u.validator.Validate(input)
+	if err != nil {
+		return WebhookOutput{}, fmt.Errorf("input is invalid: %w", err)
+	}
+
+	webhook, err := u.webhookDatabase.GetWebhook(ctx, input.ID)
+	if err != nil {
+		return WebhookOutput{}, fmt.Errorf("failed to get webhook from database: %w", err)
+	}
+
+	webhook.URL = input.URL
+	webhook.EventTypes = input.EventTypes
+
+	if input.Secret != "" {
+		webhook.Secret = input.Secret
+	}
+
+	switch {
+	case input.Enabled && !webhook.Enabled:
+		webhook.ConsecutiveFailures = 0
+		webhook.DisabledAt = nil
+	case !input.Enabled && webhook.Enabled:
+		disabledAt := u.now()
+		webhook.DisabledAt = &disabledAt
+	}
+
+	webhook.Enabled = input.Enabled
+
+	webhook, err = u.webhookDatabase.UpdateWebhook(ctx, webhook)
+	if err != nil {
+		return WebhookOutput{}, fmt.Errorf("failed to update webhook into database: %w", err)
+	}
+
+	return newWebhookOutput(webhook), nil
+}
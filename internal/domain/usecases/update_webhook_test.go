@@ -0,0 +1,178 @@
+package usecases
+
+import (
+	"context"
+	"errors"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/lyracampos/go-clean-architecture/internal/domain"
+	mock "github.com/lyracampos/go-clean-architecture/internal/domain/ports/mocks"
+	"go.uber.org/mock/gomock"
+)
+
+func TestUpdateWebhookExecute(t *testing.T) {
+	ctx := adminContext()
+	now := time.Date(2024, 2, 1, 0, 0, 0, 0, time.UTC)
+
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	type args struct {
+		ctx   context.Context
+		input *UpdateWebhookInput
+	}
+	tests := []struct {
+		name       string
+		args       args
+		beforeTest func(webhookDatabase *mock.MockWebhookDatabaseGateway)
+		want       WebhookOutput
+		wantErr    bool
+		err        error
+	}{
+		{
+			name: "success updating webhook keeping its secret",
+			args: args{
+				ctx:   ctx,
+				input: &UpdateWebhookInput{ID: 1, URL: "https://example.com/v2", EventTypes: []string{"user.deleted"}, Enabled: true},
+			},
+			beforeTest: func(webhookDatabase *mock.MockWebhookDatabaseGateway) {
+				webhookDatabase.EXPECT().GetWebhook(gomock.Any(), int64(1)).Return(defaultWebhook(), nil)
+
+				updated := defaultWebhook()
+				updated.URL = "https://example.com/v2"
+				updated.EventTypes = []string{"user.deleted"}
+				webhookDatabase.EXPECT().UpdateWebhook(gomock.Any(), updated).Return(updated, nil)
+			},
+			want: WebhookOutput{
+				ID:         1,
+				URL:        "https://example.com/v2",
+				EventTypes: []string{"user.deleted"},
+				Enabled:    true,
+				CreatedAt:  webhookCreatedAt,
+				UpdatedAt:  webhookCreatedAt,
+			},
+		},
+		{
+			name: "success re-enabling webhook resets its failures",
+			args: args{
+				ctx:   ctx,
+				input: &UpdateWebhookInput{ID: 1, URL: "https://example.com/hooks", EventTypes: []string{"user.created"}, Secret: "fedcba9876543210", Enabled: true},
+			},
+			beforeTest: func(webhookDatabase *mock.MockWebhookDatabaseGateway) {
+				disabled := defaultWebhook()
+				disabled.Enabled = false
+				disabled.ConsecutiveFailures = 10
+				disabled.DisabledAt = &now
+				webhookDatabase.EXPECT().GetWebhook(gomock.Any(), int64(1)).Return(disabled, nil)
+
+				updated := defaultWebhook()
+				updated.Secret = "fedcba9876543210"
+				webhookDatabase.EXPECT().UpdateWebhook(gomock.Any(), updated).Return(updated, nil)
+			},
+			want: defaultWebhookOutput(),
+		},
+		{
+			name: "success disabling webhook",
+			args: args{
+				ctx:   ctx,
+				input: &UpdateWebhookInput{ID: 1, URL: "https://example.com/hooks", EventTypes: []string{"user.created"}, Enabled: false},
+			},
+			beforeTest: func(webhookDatabase *mock.MockWebhookDatabaseGateway) {
+				webhookDatabase.EXPECT().GetWebhook(gomock.Any(), int64(1)).Return(defaultWebhook(), nil)
+
+				updated := defaultWebhook()
+				updated.Enabled = false
+				updated.DisabledAt = &now
+				webhookDatabase.EXPECT().UpdateWebhook(gomock.Any(), updated).Return(updated, nil)
+			},
+			want: WebhookOutput{
+				ID:         1,
+				URL:        "https://example.com/hooks",
+				EventTypes: []string{"user.created"},
+				Enabled:    false,
+				CreatedAt:  webhookCreatedAt,
+				UpdatedAt:  webhookCreatedAt,
+				DisabledAt: &now,
+			},
+		},
+		{
+			name: "fail updating webhook with short secret",
+			args: args{
+				ctx:   ctx,
+				input: &UpdateWebhookInput{ID: 1, URL: "https://example.com/hooks", EventTypes: []string{"user.created"}, Secret: "secret"},
+			},
+			beforeTest: nil,
+			wantErr:    true,
+			err:        errors.New("input is invalid: the field 'Secret' min size is 16; "),
+		},
+		{
+			name: "fail updating webhook when id not found",
+			args: args{
+				ctx:   ctx,
+				input: &UpdateWebhookInput{ID: 1, URL: "https://example.com/hooks", EventTypes: []string{"user.created"}},
+			},
+			beforeTest: func(webhookDatabase *mock.MockWebhookDatabaseGateway) {
+				webhookDatabase.EXPECT().GetWebhook(gomock.Any(), int64(1)).Return(nil, domain.ErrWebhookDoesNotExist)
+			},
+			wantErr: true,
+			err:     errors.New("failed to get webhook from database: webhook does not exist"),
+		},
+		{
+			name: "fail updating webhook when update fails",
+			args: args{
+				ctx:   ctx,
+				input: &UpdateWebhookInput{ID: 1, URL: "https://example.com/hooks", EventTypes: []string{"user.created"}, Enabled: true},
+			},
+			beforeTest: func(webhookDatabase *mock.MockWebhookDatabaseGateway) {
+				webhookDatabase.EXPECT().GetWebhook(gomock.Any(), int64(1)).Return(defaultWebhook(), nil)
+				webhookDatabase.EXPECT().UpdateWebhook(gomock.Any(), gomock.Any()).Return(nil, domain.ErrWebhookDoesNotExist)
+			},
+			wantErr: true,
+			err:     errors.New("failed to update webhook into database: webhook does not exist"),
+		},
+		{
+			name: "fail updating webhook as contributor",
+			args: args{
+				ctx:   contributorContext(1),
+				input: &UpdateWebhookInput{ID: 1, URL: "https://example.com/hooks", EventTypes: []string{"user.created"}},
+			},
+			beforeTest: nil,
+			wantErr:    true,
+			err:        domain.ErrForbidden,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockWebhookDatabase := mock.NewMockWebhookDatabaseGateway(ctrl)
+
+			usecase := &updateWebhookUseCase{
+				webhookDatabase: mockWebhookDatabase,
+				validator:       domain.NewValidatorService(),
+				now:             func() time.Time { return now },
+			}
+
+			if tt.beforeTest != nil {
+				tt.beforeTest(mockWebhookDatabase)
+			}
+
+			webhook, err := usecase.Execute(tt.args.ctx, *tt.args.input)
+
+			if (err != nil) != tt.wantErr {
+				t.Errorf("updateWebhook.Execute() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if !reflect.DeepEqual(webhook, tt.want) {
+				t.Errorf("updateWebhook.Execute() = %v, want %v", webhook, tt.want)
+			}
+			if tt.wantErr {
+				if err.Error() != tt.err.Error() {
+					t.Errorf("updateWebhook.Execute() error = %v, wantErr %v", err, tt.err)
+					return
+				}
+			}
+		})
+	}
+}
@@ -0,0 +1,96 @@
+package usecases
+
+import (
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"github.com/lyracampos/go-clean-architecture/internal/domain"
+	"github.com/lyracampos/go-clean-architecture/internal/domain/entities"
+	"github.com/lyracampos/go-clean-architecture/internal/domain/ports"
+)
+
+// userCursor is the opaque cursor handed to clients, it is bound to the sort and the searched text
+// it was created for so that it can't be replayed against another ordering or ranking.
+type userCursor struct {
+	Sort  string `json:"s"`
+	Query string `json:"q,omitempty"`
+	Value string `json:"v"`
+	ID    int64  `json:"id"`
+}
+
+func encodeUserCursor(sort ports.UserSort, query string, user *entities.User) (string, error) {
+	cursor, err := json.Marshal(userCursor{
+		Sort:  sortKey(sort),
+		Query: queryKey(query),
+		Value: userSortValue(sort.Field, user),
+		ID:    user.ID,
+	})
+	if err != nil {
+		return "", fmt.Errorf("failed to encode cursor: %w", err)
+	}
+
+	return base64.RawURLEncoding.EncodeToString(cursor), nil
+}
+
+func decodeUserCursor(encoded string, sort ports.UserSort, query string) (*ports.UserCursor, error) {
+	if encoded == "" {
+		return nil, nil
+	}
+
+	decoded, err := base64.RawURLEncoding.DecodeString(encoded)
+	if err != nil {
+		return nil, domain.ErrInvalidCursor
+	}
+
+	var cursor userCursor
+	if err := json.Unmarshal(decoded, &cursor); err != nil {
+		return nil, domain.ErrInvalidCursor
+	}
+
+	if cursor.Sort != sortKey(sort) || cursor.Query != queryKey(query) {
+		return nil, domain.ErrInvalidCursor
+	}
+
+	return &ports.UserCursor{
+		Value: cursor.Value,
+		ID:    cursor.ID,
+	}, nil
+}
+
+func sortKey(sort ports.UserSort) string {
+	if sort.Descending {
+		return "-" + sort.Field
+	}
+
+	return sort.Field
+}
+
+// queryKey is a hash of the searched text, so that the cursor doesn't carry the text itself.
+func queryKey(query string) string {
+	if query == "" {
+		return ""
+	}
+
+	sum := sha256.Sum256([]byte(query))
+
+	return base64.RawURLEncoding.EncodeToString(sum[:12])
+}
+
+func userSortValue(field string, user *entities.User) string {
+	switch field {
+	case ports.UserSortRelevance:
+		// the rank is computed by the database again from the user ID
+		return ""
+	case ports.UserSortFirstName:
+		return user.FirstName
+	case ports.UserSortLastName:
+		return user.LastName
+	case ports.UserSortEmail:
+		return user.Email
+	default:
+		return user.CreatedAt.Format(time.RFC3339Nano)
+	}
+}
@@ -18,6 +18,9 @@ type validatorService struct {
 
 func NewValidatorService() *validatorService {
 	validate := validator.New()
+	// the rules are static and valid, registering them can't fail
+	_ = validate.RegisterValidation("notcommon", validateNotCommon)
+	_ = validate.RegisterValidation("maxBytes", validateMaxBytes)
 
 	return &validatorService{
 		validate: validate,
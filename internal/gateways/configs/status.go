@@ -0,0 +1,42 @@
+package configs
+
+import (
+	"context"
+	"time"
+
+	"github.com/lyracampos/go-clean-architecture/config"
+	"github.com/lyracampos/go-clean-architecture/internal/domain/entities"
+	"github.com/lyracampos/go-clean-architecture/internal/domain/ports"
+)
+
+var _ ports.ConfigStatusGateway = (*statusGateway)(nil)
+
+type statusGateway struct {
+	watcher *config.Watcher
+}
+
+// NewStatusGateway reports the reloads of the config file watched by the watcher.
+func NewStatusGateway(watcher *config.Watcher) *statusGateway {
+	return &statusGateway{
+		watcher: watcher,
+	}
+}
+
+func (g *statusGateway) ConfigStatus(_ context.Context) (*entities.ConfigStatus, error) {
+	status := g.watcher.Status()
+
+	return &entities.ConfigStatus{
+		Generation:  status.Generation,
+		ReloadedAt:  optionalTime(status.ReloadedAt),
+		LastError:   status.LastError,
+		LastErrorAt: optionalTime(status.LastErrorAt),
+	}, nil
+}
+
+func optionalTime(t time.Time) *time.Time {
+	if t.IsZero() {
+		return nil
+	}
+
+	return &t
+}
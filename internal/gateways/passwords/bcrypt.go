@@ -0,0 +1,52 @@
+package passwords
+
+import (
+	"fmt"
+
+	"github.com/lyracampos/go-clean-architecture/internal/domain/ports"
+	"golang.org/x/crypto/bcrypt"
+)
+
+var _ ports.PasswordHasher = (*bcryptHasher)(nil)
+
+type bcryptHasher struct {
+	cost int
+	// dummyHash is compared when there is no hash, so it costs the same as a real comparison.
+	dummyHash []byte
+}
+
+// NewBcryptHasher instantiates a bcrypt password hasher, zero cost means bcrypt.DefaultCost.
+func NewBcryptHasher(cost int) (*bcryptHasher, error) {
+	if cost == 0 {
+		cost = bcrypt.DefaultCost
+	}
+
+	dummyHash, err := bcrypt.GenerateFromPassword([]byte("dummy password"), cost)
+	if err != nil {
+		return nil, fmt.Errorf("failed to generate dummy password hash: %w", err)
+	}
+
+	return &bcryptHasher{
+		cost:      cost,
+		dummyHash: dummyHash,
+	}, nil
+}
+
+func (h *bcryptHasher) Hash(password string) (string, error) {
+	hash, err := bcrypt.GenerateFromPassword([]byte(password), h.cost)
+	if err != nil {
+		return "", fmt.Errorf("failed to hash password: %w", err)
+	}
+
+	return string(hash), nil
+}
+
+func (h *bcryptHasher) Compare(hash, password string) error {
+	if hash == "" {
+		_ = bcrypt.CompareHashAndPassword(h.dummyHash, []byte(password))
+
+		return bcrypt.ErrMismatchedHashAndPassword
+	}
+
+	return bcrypt.CompareHashAndPassword([]byte(hash), []byte(password))
+}
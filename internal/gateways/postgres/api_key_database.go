@@ -0,0 +1,98 @@
+package postgres
+
+import (
+	"context"
+	"time"
+
+	"github.com/lyracampos/go-clean-architecture/internal/domain"
+	"github.com/lyracampos/go-clean-architecture/internal/domain/entities"
+	"github.com/lyracampos/go-clean-architecture/internal/domain/ports"
+	"github.com/lyracampos/go-clean-architecture/internal/gateways/postgres/models"
+	"github.com/uptrace/bun"
+)
+
+var _ ports.APIKeyDatabaseGateway = (*apiKeyDatabase)(nil)
+
+type apiKeyDatabase struct {
+	Client *Client
+}
+
+func NewAPIKeyDatabase(client *Client) *apiKeyDatabase {
+	return &apiKeyDatabase{
+		Client: client,
+	}
+}
+
+func (g *apiKeyDatabase) InsertAPIKey(ctx context.Context, key *entities.APIKey) (*entities.APIKey, error) {
+	model := models.NewAPIKeyModel(key)
+
+	_, err := g.Client.DB.NewInsert().Model(model).Returning("*").Exec(ctx)
+	if err != nil {
+		return nil, newInsertError(models.APIKeysTableName, translateError(err, domain.ErrAPIKeyDoesNotExist))
+	}
+
+	return model.ToEntity(), nil
+}
+
+func (g *apiKeyDatabase) ListAPIKeys(ctx context.Context) ([]*entities.APIKey, error) {
+	var modelList []models.APIKey
+
+	err := g.Client.DB.NewSelect().Model(&modelList).
+		Order("id").
+		Scan(ctx)
+	if err != nil {
+		return nil, newListError(models.APIKeysTableName, translateError(err, domain.ErrAPIKeyDoesNotExist))
+	}
+
+	list := make([]*entities.APIKey, 0, len(modelList))
+	for _, model := range modelList {
+		list = append(list, model.ToEntity())
+	}
+
+	return list, nil
+}
+
+func (g *apiKeyDatabase) GetAPIKey(ctx context.Context, prefix string) (*entities.APIKey, error) {
+	model := models.APIKey{}
+
+	err := g.Client.DB.NewSelect().Model(&model).
+		Where("? = ?", bun.Ident("prefix"), prefix).
+		Scan(ctx)
+	if err != nil {
+		return nil, newListError(models.APIKeysTableName, translateError(err, domain.ErrAPIKeyDoesNotExist))
+	}
+
+	return model.ToEntity(), nil
+}
+
+func (g *apiKeyDatabase) DeleteAPIKey(ctx context.Context, id int64) error {
+	result, err := g.Client.DB.NewDelete().Model((*models.APIKey)(nil)).
+		Where("? = ?", bun.Ident("id"), id).
+		Exec(ctx)
+	if err != nil {
+		return newDeleteError(models.APIKeysTableName, translateError(err, domain.ErrAPIKeyDoesNotExist))
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return newDeleteError(models.APIKeysTableName, err)
+	}
+
+	if rowsAffected == 0 {
+		return domain.ErrAPIKeyDoesNotExist
+	}
+
+	return nil
+}
+
+func (g *apiKeyDatabase) TouchAPIKey(ctx context.Context, id int64, usedAt time.Time) error {
+	_, err := g.Client.DB.NewUpdate().Model((*models.APIKey)(nil)).
+		Set("? = ?", bun.Ident("last_used_at"), usedAt).
+		Where("? = ?", bun.Ident("id"), id).
+		Exec(ctx)
+	if err != nil {
+		return newUpdateError(models.APIKeysTableName, translateError(err, domain.ErrAPIKeyDoesNotExist))
+	}
+
+	return nil
+}
@@ -12,11 +12,6 @@ import (
 	"go.uber.org/zap"
 )
 
-const (
-	DuplicateKeyPrefix = "duplicate key value violates unique constraint"
-	NoRowsInResultSet  = "no rows in result set"
-)
-
 type Client struct {
 	log    *zap.SugaredLogger
 	DB     *bun.DB
@@ -30,10 +25,7 @@ func NewClient(log *zap.SugaredLogger, config *config.Config) (*Client, error) {
 
 	newDB := bun.NewDB(sqlDB, pgdialect.New())
 	newDB.AddQueryHook(bunotel.NewQueryHook())
-
-	// 	if conf.Log.Environment == config.LogEnvironmentDevelopment {
-	// 		newDB.AddQueryHook(bundebug.NewQueryHook(bundebug.WithVerbose(true)))
-	// 	}
+	newDB.AddQueryHook(newQueryLogHook(log, config.Database.LogQueries))
 
 	if err := newDB.Ping(); err != nil {
 		return nil, fmt.Errorf("failed to connecto to postgres database: %w", err)
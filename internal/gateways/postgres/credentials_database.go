@@ -0,0 +1,53 @@
+package postgres
+
+import (
+	"context"
+
+	"github.com/lyracampos/go-clean-architecture/internal/domain"
+	"github.com/lyracampos/go-clean-architecture/internal/domain/entities"
+	"github.com/lyracampos/go-clean-architecture/internal/domain/ports"
+	"github.com/lyracampos/go-clean-architecture/internal/gateways/postgres/models"
+	"github.com/uptrace/bun"
+)
+
+var _ ports.CredentialsDatabaseGateway = (*credentialsDatabase)(nil)
+
+type credentialsDatabase struct {
+	Client *Client
+}
+
+func NewCredentialsDatabase(client *Client) *credentialsDatabase {
+	return &credentialsDatabase{
+		Client: client,
+	}
+}
+
+func (g *credentialsDatabase) GetCredentials(ctx context.Context, userID int64) (*entities.Credentials, error) {
+	model := models.Credentials{}
+
+	err := g.Client.DB.NewSelect().Model(&model).
+		Where("? = ?", bun.Ident("user_id"), userID).
+		Scan(ctx)
+	if err != nil {
+		return nil, newListError(models.CredentialsTableName, translateError(err, domain.ErrCredentialsDoNotExist))
+	}
+
+	return model.ToEntity(), nil
+}
+
+// UpsertCredentials stores the user credentials, replacing the password hash when they already exist.
+func (g *credentialsDatabase) UpsertCredentials(ctx context.Context, credentials *entities.Credentials) (*entities.Credentials, error) {
+	model := models.NewCredentialsModel(credentials)
+
+	err := g.Client.conn(ctx).NewInsert().Model(model).
+		On("CONFLICT (user_id) DO UPDATE").
+		Set("? = EXCLUDED.?", bun.Ident("password_hash"), bun.Ident("password_hash")).
+		Set("? = current_timestamp", bun.Ident("updated_at")).
+		Returning("*").
+		Scan(ctx)
+	if err != nil {
+		return nil, newInsertError(models.CredentialsTableName, translateError(err, domain.ErrCredentialsDoNotExist))
+	}
+
+	return model.ToEntity(), nil
+}
@@ -0,0 +1,106 @@
+package postgres
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"net"
+
+	"github.com/lyracampos/go-clean-architecture/internal/domain"
+	"github.com/uptrace/bun/driver/pgdriver"
+)
+
+// SQLSTATE codes translated into domain errors.
+//
+// https://www.postgresql.org/docs/current/errcodes-appendix.html
+const (
+	notNullViolation     = "23502"
+	foreignKeyViolation  = "23503"
+	uniqueViolation      = "23505"
+	checkViolation       = "23514"
+	serializationFailure = "40001"
+	deadlockDetected     = "40P01"
+	queryCanceled        = "57014"
+)
+
+// constraintErrors maps the constraints that carry a business meaning to their domain errors,
+// violations of any other constraint are reported by their SQLSTATE class.
+var constraintErrors = map[string]error{
+	"users_email_active_key": domain.ErrEmailAlreadyInUse,
+}
+
+// translateError inspects the driver error and returns the matching domain error,
+// notFound is returned when the query had no rows to scan.
+// Errors without a translation are returned untouched.
+func translateError(err error, notFound error) error {
+	if errors.Is(err, sql.ErrNoRows) {
+		return notFound
+	}
+
+	if contextErr, ok := contextError(err); ok {
+		return contextErr
+	}
+
+	var pgErr pgdriver.Error
+	if !errors.As(err, &pgErr) {
+		return err
+	}
+
+	constraint := pgErr.Field('n')
+	column := pgErr.Field('c')
+
+	switch pgErr.Field('C') {
+	case uniqueViolation:
+		if constraintErr, ok := constraintErrors[constraint]; ok {
+			return domain.NewConstraintError(constraintErr, constraint, column, err)
+		}
+
+		return domain.NewConstraintError(domain.ErrUniqueViolation, constraint, column, err)
+	case foreignKeyViolation:
+		return domain.NewConstraintError(domain.ErrForeignKeyViolation, constraint, column, err)
+	case checkViolation:
+		return domain.NewConstraintError(domain.ErrCheckViolation, constraint, column, err)
+	case notNullViolation:
+		return domain.NewConstraintError(domain.ErrNotNullViolation, constraint, column, err)
+	case serializationFailure:
+		return domain.NewTransientError(domain.ErrSerializationFailure, err)
+	case deadlockDetected:
+		return domain.NewTransientError(domain.ErrDeadlock, err)
+	case queryCanceled:
+		// raised by statement_timeout, the driver itself never cancels a running query
+		return domain.NewTransientError(domain.ErrDeadlineExceeded, err)
+	default:
+		return err
+	}
+}
+
+// translateContextError translates the errors of queries interrupted by their context,
+// such as the ones of beginning or committing a transaction.
+// Any other error, including the ones already translated, is returned untouched.
+func translateContextError(err error) error {
+	if contextErr, ok := contextError(err); ok {
+		return contextErr
+	}
+
+	return err
+}
+
+// contextError tells apart the queries interrupted because their context deadline passed
+// from the ones whose context was canceled, such as when the client went away.
+func contextError(err error) (error, bool) {
+	if errors.Is(err, domain.ErrDeadlineExceeded) || errors.Is(err, domain.ErrCanceled) {
+		return err, true
+	}
+
+	// the driver sets the context deadline on the connection, so it surfaces as a network timeout
+	var netErr net.Error
+	if errors.Is(err, context.DeadlineExceeded) || (errors.As(err, &netErr) && netErr.Timeout()) {
+		return domain.NewTransientError(domain.ErrDeadlineExceeded, err), true
+	}
+
+	if errors.Is(err, context.Canceled) {
+		return domain.NewTransientError(domain.ErrCanceled, err), true
+	}
+
+	return nil, false
+}
@@ -10,6 +10,14 @@ func newInsertError(model string, err error) error {
 	return fmt.Errorf("failed to insert table %s: %w", model, err)
 }
 
+func newUpdateError(model string, err error) error {
+	return fmt.Errorf("failed to update table %s: %w", model, err)
+}
+
+func newDeleteError(model string, err error) error {
+	return fmt.Errorf("failed to delete table %s: %w", model, err)
+}
+
 func newNoRowsError(model string, err error) error {
 	return fmt.Errorf("failed to return rows for table %s: %w", model, err)
 }
@@ -0,0 +1,143 @@
+package postgres
+
+import (
+	"context"
+	"time"
+
+	"github.com/lyracampos/go-clean-architecture/internal/domain"
+	"github.com/lyracampos/go-clean-architecture/internal/domain/entities"
+	"github.com/lyracampos/go-clean-architecture/internal/domain/ports"
+	"github.com/lyracampos/go-clean-architecture/internal/gateways/postgres/models"
+	"github.com/uptrace/bun"
+)
+
+var _ ports.IdempotencyDatabaseGateway = (*idempotencyDatabase)(nil)
+
+type idempotencyDatabase struct {
+	Client *Client
+}
+
+func NewIdempotencyDatabase(client *Client) *idempotencyDatabase {
+	return &idempotencyDatabase{
+		Client: client,
+	}
+}
+
+// ReserveIdempotencyKey relies on the primary key, so that of two concurrent requests
+// with the same key only one reserves it.
+// Expired records and the uncompleted ones whose lock passed are replaced.
+func (g *idempotencyDatabase) ReserveIdempotencyKey(ctx context.Context, record *entities.IdempotencyRecord) (*entities.IdempotencyRecord, bool, error) {
+	model := models.NewIdempotencyKeyModel(record)
+	existing := models.IdempotencyKey{}
+	reserved := false
+
+	err := g.Client.DB.RunInTx(ctx, nil, func(ctx context.Context, tx bun.Tx) error {
+		// an expired key is free to be used again, and so is the key of a request
+		// that never completed, such as when the process was killed
+		_, err := tx.NewDelete().Model((*models.IdempotencyKey)(nil)).
+			Where("? = ?", bun.Ident("scope"), model.Scope).
+			Where("? = ?", bun.Ident("key"), model.Key).
+			WhereGroup(" AND ", func(q *bun.DeleteQuery) *bun.DeleteQuery {
+				return q.
+					WhereOr("? <= ?", bun.Ident("expires_at"), model.CreatedAt).
+					WhereOr("NOT ? AND ? <= ?", bun.Ident("completed"), bun.Ident("locked_until"), model.CreatedAt)
+			}).
+			Exec(ctx)
+		if err != nil {
+			return newDeleteError(models.IdempotencyKeysTableName, translateError(err, domain.ErrIdempotencyKeyDoesNotExist))
+		}
+
+		result, err := tx.NewInsert().Model(model).
+			On("CONFLICT DO NOTHING").
+			Exec(ctx)
+		if err != nil {
+			return newInsertError(models.IdempotencyKeysTableName, translateError(err, domain.ErrIdempotencyKeyDoesNotExist))
+		}
+
+		rowsAffected, err := result.RowsAffected()
+		if err != nil {
+			return newInsertError(models.IdempotencyKeysTableName, err)
+		}
+
+		if rowsAffected == 1 {
+			reserved = true
+			return nil
+		}
+
+		err = tx.NewSelect().Model(&existing).
+			Where("? = ?", bun.Ident("scope"), model.Scope).
+			Where("? = ?", bun.Ident("key"), model.Key).
+			Scan(ctx)
+		if err != nil {
+			return newListError(models.IdempotencyKeysTableName, translateError(err, domain.ErrIdempotencyKeyDoesNotExist))
+		}
+
+		return nil
+	})
+	if err != nil {
+		return nil, false, err
+	}
+
+	if reserved {
+		return model.ToEntity(), true, nil
+	}
+
+	return existing.ToEntity(), false, nil
+}
+
+// CompleteIdempotencyKey only updates the reservation made by the request, identified by its creation time,
+// as the key may have been reserved again by a retry once the request outlived its lock.
+func (g *idempotencyDatabase) CompleteIdempotencyKey(ctx context.Context, record *entities.IdempotencyRecord) error {
+	model := models.NewIdempotencyKeyModel(record)
+
+	result, err := g.Client.DB.NewUpdate().Model(model).
+		Column("completed", "status_code", "header", "body").
+		WherePK().
+		Where("NOT ?", bun.Ident("completed")).
+		Where("? = ?", bun.Ident("created_at"), model.CreatedAt).
+		Exec(ctx)
+	if err != nil {
+		return newUpdateError(models.IdempotencyKeysTableName, translateError(err, domain.ErrIdempotencyKeyDoesNotExist))
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return newUpdateError(models.IdempotencyKeysTableName, err)
+	}
+
+	if rowsAffected == 0 {
+		return newNoRowsError(models.IdempotencyKeysTableName, domain.ErrIdempotencyKeyDoesNotExist)
+	}
+
+	return nil
+}
+
+func (g *idempotencyDatabase) ReleaseIdempotencyKey(ctx context.Context, scope, key string, reservedAt time.Time) error {
+	_, err := g.Client.DB.NewDelete().Model((*models.IdempotencyKey)(nil)).
+		Where("? = ?", bun.Ident("scope"), scope).
+		Where("? = ?", bun.Ident("key"), key).
+		Where("NOT ?", bun.Ident("completed")).
+		Where("? = ?", bun.Ident("created_at"), reservedAt).
+		Exec(ctx)
+	if err != nil {
+		return newDeleteError(models.IdempotencyKeysTableName, translateError(err, domain.ErrIdempotencyKeyDoesNotExist))
+	}
+
+	return nil
+}
+
+func (g *idempotencyDatabase) PurgeIdempotencyKeys(ctx context.Context, expiredBefore time.Time) (int64, error) {
+	result, err := g.Client.DB.NewDelete().Model((*models.IdempotencyKey)(nil)).
+		Where("? <= ?", bun.Ident("expires_at"), expiredBefore).
+		Exec(ctx)
+	if err != nil {
+		return 0, newDeleteError(models.IdempotencyKeysTableName, translateError(err, domain.ErrIdempotencyKeyDoesNotExist))
+	}
+
+	purged, err := result.RowsAffected()
+	if err != nil {
+		return 0, newDeleteError(models.IdempotencyKeysTableName, err)
+	}
+
+	return purged, nil
+}
@@ -0,0 +1,55 @@
+package postgres
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/collectors"
+	"github.com/uptrace/bun"
+)
+
+var _ bun.QueryHook = (*queryMetricsHook)(nil)
+
+// RegisterMetrics exposes the connection pool stats and the duration of every query.
+// It must be called before the client is used, as the query hooks are not safe to add concurrently.
+func (c *Client) RegisterMetrics(registerer prometheus.Registerer) error {
+	queryDuration := prometheus.NewHistogramVec(prometheus.HistogramOpts{
+		Name:    "db_query_duration_seconds",
+		Help:    "Time taken by the database queries, by operation and result.",
+		Buckets: prometheus.DefBuckets,
+	}, []string{"operation", "result"})
+
+	if err := registerer.Register(queryDuration); err != nil {
+		return fmt.Errorf("failed to register query metrics: %w", err)
+	}
+
+	if err := registerer.Register(collectors.NewDBStatsCollector(c.DB.DB, "postgres")); err != nil {
+		return fmt.Errorf("failed to register connection pool metrics: %w", err)
+	}
+
+	c.DB.AddQueryHook(&queryMetricsHook{queryDuration: queryDuration})
+
+	return nil
+}
+
+// queryMetricsHook measures the queries by their operation, such as SELECT or INSERT.
+type queryMetricsHook struct {
+	queryDuration *prometheus.HistogramVec
+}
+
+func (h *queryMetricsHook) BeforeQuery(ctx context.Context, _ *bun.QueryEvent) context.Context {
+	return ctx
+}
+
+func (h *queryMetricsHook) AfterQuery(_ context.Context, event *bun.QueryEvent) {
+	result := "ok"
+	if event.Err != nil && !errors.Is(event.Err, sql.ErrNoRows) {
+		result = "error"
+	}
+
+	h.queryDuration.WithLabelValues(event.Operation(), result).Observe(time.Since(event.StartTime).Seconds())
+}
@@ -0,0 +1,3 @@
+CREATE INDEX users_created_at_id_idx ON users (created_at, id);
+CREATE INDEX users_first_name_id_idx ON users (first_name, id);
+CREATE INDEX users_last_name_id_idx ON users (last_name, id);
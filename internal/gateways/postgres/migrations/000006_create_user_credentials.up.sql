@@ -0,0 +1,6 @@
+CREATE TABLE user_credentials (
+      user_id bigint PRIMARY KEY REFERENCES users(id) ON DELETE CASCADE,
+      password_hash text NOT NULL,
+      created_at timestamp NOT NULL DEFAULT now(),
+      updated_at timestamp NOT NULL DEFAULT now()
+);
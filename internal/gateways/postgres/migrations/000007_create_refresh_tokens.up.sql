@@ -0,0 +1,13 @@
+CREATE TABLE refresh_tokens (
+      id bigserial PRIMARY KEY,
+      user_id bigint NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+      family_id uuid NOT NULL DEFAULT gen_random_uuid(),
+      token_hash text NOT NULL,
+      expires_at timestamp NOT NULL,
+      created_at timestamp NOT NULL DEFAULT now(),
+      used_at timestamp,
+      revoked_at timestamp,
+      UNIQUE(token_hash)
+);
+
+CREATE INDEX refresh_tokens_family_id_idx ON refresh_tokens (family_id);
@@ -0,0 +1,12 @@
+CREATE TABLE api_keys (
+      id bigserial PRIMARY KEY,
+      user_id bigint NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+      name varchar(100) NOT NULL,
+      prefix text NOT NULL,
+      key_hash text NOT NULL,
+      scopes text[] NOT NULL,
+      expires_at timestamp,
+      created_at timestamp NOT NULL DEFAULT now(),
+      last_used_at timestamp,
+      UNIQUE(prefix)
+);
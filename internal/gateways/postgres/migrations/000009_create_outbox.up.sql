@@ -0,0 +1,11 @@
+CREATE TABLE outbox (
+      id uuid PRIMARY KEY DEFAULT gen_random_uuid(),
+      aggregate_type text NOT NULL,
+      aggregate_id text NOT NULL,
+      event_type text NOT NULL,
+      schema_version integer NOT NULL,
+      payload jsonb NOT NULL,
+      occurred_at timestamp NOT NULL DEFAULT now()
+);
+
+CREATE INDEX outbox_occurred_at_idx ON outbox (occurred_at);
@@ -0,0 +1,8 @@
+DROP INDEX IF EXISTS outbox_pending_idx;
+
+ALTER TABLE outbox
+      DROP COLUMN IF EXISTS status,
+      DROP COLUMN IF EXISTS attempts,
+      DROP COLUMN IF EXISTS next_attempt_at,
+      DROP COLUMN IF EXISTS last_error,
+      DROP COLUMN IF EXISTS delivered_at;
@@ -0,0 +1,8 @@
+ALTER TABLE outbox
+      ADD COLUMN status text NOT NULL DEFAULT 'pending' CHECK (status IN ('pending', 'delivered', 'dead')),
+      ADD COLUMN attempts integer NOT NULL DEFAULT 0,
+      ADD COLUMN next_attempt_at timestamp NOT NULL DEFAULT now(),
+      ADD COLUMN last_error text,
+      ADD COLUMN delivered_at timestamp;
+
+CREATE INDEX outbox_pending_idx ON outbox (next_attempt_at) WHERE status = 'pending';
@@ -0,0 +1,43 @@
+CREATE TABLE webhooks (
+      id bigserial PRIMARY KEY,
+      url text NOT NULL,
+      event_types text[] NOT NULL,
+      secret text NOT NULL,
+      enabled boolean NOT NULL DEFAULT true,
+      consecutive_failures integer NOT NULL DEFAULT 0,
+      created_at timestamp NOT NULL DEFAULT now(),
+      updated_at timestamp NOT NULL DEFAULT now(),
+      disabled_at timestamp
+);
+
+CREATE TABLE webhook_deliveries (
+      id bigserial PRIMARY KEY,
+      webhook_id bigint NOT NULL REFERENCES webhooks(id) ON DELETE CASCADE,
+      event_id uuid NOT NULL,
+      event_type text NOT NULL,
+      payload jsonb NOT NULL,
+      status text NOT NULL DEFAULT 'pending' CHECK (status IN ('pending', 'delivered', 'failed')),
+      attempts integer NOT NULL DEFAULT 0,
+      next_attempt_at timestamp NOT NULL DEFAULT now(),
+      created_at timestamp NOT NULL DEFAULT now(),
+      delivered_at timestamp,
+      UNIQUE(webhook_id, event_id)
+);
+
+CREATE INDEX webhook_deliveries_pending_idx ON webhook_deliveries (next_attempt_at) WHERE status = 'pending';
+
+CREATE TABLE webhook_delivery_attempts (
+      id bigserial PRIMARY KEY,
+      delivery_id bigint NOT NULL REFERENCES webhook_deliveries(id) ON DELETE CASCADE,
+      webhook_id bigint NOT NULL REFERENCES webhooks(id) ON DELETE CASCADE,
+      event_id uuid NOT NULL,
+      event_type text NOT NULL,
+      attempt integer NOT NULL,
+      status_code integer,
+      error text,
+      duration_ms bigint NOT NULL,
+      succeeded boolean NOT NULL,
+      attempted_at timestamp NOT NULL
+);
+
+CREATE INDEX webhook_delivery_attempts_webhook_idx ON webhook_delivery_attempts (webhook_id, attempted_at DESC);
@@ -0,0 +1,14 @@
+CREATE TABLE idempotency_keys (
+      scope text NOT NULL,
+      key text NOT NULL,
+      fingerprint text NOT NULL,
+      completed boolean NOT NULL DEFAULT false,
+      status_code integer,
+      header jsonb,
+      body bytea,
+      created_at timestamp NOT NULL,
+      expires_at timestamp NOT NULL,
+      PRIMARY KEY (scope, key)
+);
+
+CREATE INDEX idempotency_keys_expires_at_idx ON idempotency_keys (expires_at);
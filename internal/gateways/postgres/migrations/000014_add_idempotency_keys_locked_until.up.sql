@@ -0,0 +1,5 @@
+ALTER TABLE idempotency_keys ADD COLUMN locked_until timestamp;
+
+UPDATE idempotency_keys SET locked_until = created_at + interval '1 minute';
+
+ALTER TABLE idempotency_keys ALTER COLUMN locked_until SET NOT NULL;
@@ -0,0 +1 @@
+CREATE INDEX users_email_id_idx ON users (email, id);
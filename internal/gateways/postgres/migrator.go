@@ -0,0 +1,414 @@
+package postgres
+
+import (
+	"context"
+	"database/sql"
+	"embed"
+	"errors"
+	"fmt"
+	"io/fs"
+	"regexp"
+	"sort"
+	"strconv"
+
+	"github.com/uptrace/bun/driver/pgdriver"
+	"go.uber.org/zap"
+)
+
+//go:embed migrations/*.sql
+var migrationFiles embed.FS
+
+const (
+	// schemaMigrationsTable has the same layout used by golang-migrate,
+	// so databases migrated by its CLI keep their version.
+	schemaMigrationsTable = "schema_migrations"
+
+	// migrationsLockID identifies the advisory lock held while migrating,
+	// so that concurrent replicas can't migrate at the same time.
+	migrationsLockID int64 = 7362514380341551061
+
+	// undefinedTable is the SQLSTATE of querying schemaMigrationsTable before any migration created it.
+	undefinedTable = "42P01"
+)
+
+var (
+	ErrSchemaDirty       = errors.New("schema is dirty, a migration failed halfway: fix it manually and force the version")
+	ErrSchemaOutdated    = errors.New("schema version is behind the version expected by this binary")
+	ErrSchemaAhead       = errors.New("schema version is ahead of the migrations embedded in this binary, it was migrated by a newer release")
+	ErrMigrationNotFound = errors.New("migration version not found")
+)
+
+var migrationFileName = regexp.MustCompile(`^(\d+)_(.+)\.(up|down)\.sql$`)
+
+// Migration is a pair of embedded SQL scripts applying and reverting one schema version.
+type Migration struct {
+	Version int64
+	Name    string
+	up      string
+	down    string
+}
+
+// SchemaStatus describes the schema version of the database against the embedded migrations.
+type SchemaStatus struct {
+	Version    int64
+	Dirty      bool
+	Latest     int64
+	Migrations []Migration
+}
+
+type Migrator struct {
+	log        *zap.SugaredLogger
+	db         *sql.DB
+	migrations []Migration
+}
+
+func NewMigrator(log *zap.SugaredLogger, client *Client) (*Migrator, error) {
+	migrations, err := loadMigrations(migrationFiles)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load embedded migrations: %w", err)
+	}
+
+	return &Migrator{
+		log:        log,
+		db:         client.DB.DB,
+		migrations: migrations,
+	}, nil
+}
+
+// Latest returns the schema version expected by this binary.
+func (m *Migrator) Latest() int64 {
+	if len(m.migrations) == 0 {
+		return 0
+	}
+
+	return m.migrations[len(m.migrations)-1].Version
+}
+
+// Up applies all pending migrations, it fails when the database is ahead of this binary.
+func (m *Migrator) Up(ctx context.Context) error {
+	return m.Goto(ctx, m.Latest())
+}
+
+// Down reverts the last steps migrations.
+func (m *Migrator) Down(ctx context.Context, steps int) error {
+	if steps < 1 {
+		return fmt.Errorf("down steps must be positive, got %d", steps)
+	}
+
+	return m.withLock(ctx, func(conn *sql.Conn) error {
+		version, err := m.cleanVersion(ctx, conn)
+		if err != nil {
+			return err
+		}
+
+		index := m.indexOf(version)
+		target := int64(0)
+		if index-steps >= 0 {
+			target = m.migrations[index-steps].Version
+		}
+
+		return m.migrate(ctx, conn, version, target)
+	})
+}
+
+// Goto migrates up or down until the schema is at the given version, zero reverts every migration.
+func (m *Migrator) Goto(ctx context.Context, target int64) error {
+	if target != 0 && m.indexOf(target) < 0 {
+		return fmt.Errorf("%w: %d", ErrMigrationNotFound, target)
+	}
+
+	return m.withLock(ctx, func(conn *sql.Conn) error {
+		version, err := m.cleanVersion(ctx, conn)
+		if err != nil {
+			return err
+		}
+
+		return m.migrate(ctx, conn, version, target)
+	})
+}
+
+// Force sets the schema version without running any migration and clears the dirty flag.
+func (m *Migrator) Force(ctx context.Context, version int64) error {
+	if version != 0 && m.indexOf(version) < 0 {
+		return fmt.Errorf("%w: %d", ErrMigrationNotFound, version)
+	}
+
+	return m.withLock(ctx, func(conn *sql.Conn) error {
+		tx, err := conn.BeginTx(ctx, nil)
+		if err != nil {
+			return fmt.Errorf("failed to begin transaction: %w", err)
+		}
+		defer tx.Rollback() //nolint:errcheck
+
+		if err := setVersion(ctx, tx, version); err != nil {
+			return err
+		}
+
+		m.log.Infof("schema version forced to %d", version)
+
+		return tx.Commit()
+	})
+}
+
+// Version returns the current schema version and whether it is dirty. It only reads the database,
+// so that it can run often and with a role that can't change the schema.
+func (m *Migrator) Version(ctx context.Context) (int64, bool, error) {
+	return readVersion(ctx, m.db)
+}
+
+// Status returns the current schema version and the embedded migrations.
+func (m *Migrator) Status(ctx context.Context) (SchemaStatus, error) {
+	version, dirty, err := m.Version(ctx)
+	if err != nil {
+		return SchemaStatus{}, err
+	}
+
+	return SchemaStatus{
+		Version:    version,
+		Dirty:      dirty,
+		Latest:     m.Latest(),
+		Migrations: m.migrations,
+	}, nil
+}
+
+// Verify fails when the schema is dirty or behind the version expected by this binary.
+func (m *Migrator) Verify(ctx context.Context) error {
+	version, dirty, err := m.Version(ctx)
+	if err != nil {
+		return err
+	}
+
+	if dirty {
+		return fmt.Errorf("%w: version %d", ErrSchemaDirty, version)
+	}
+
+	if version < m.Latest() {
+		return fmt.Errorf("%w: database is at %d, expected %d", ErrSchemaOutdated, version, m.Latest())
+	}
+
+	return nil
+}
+
+// withLock runs fn holding the migrations advisory lock. Advisory locks belong to the session,
+// so the lock and every migration statement share a single connection.
+func (m *Migrator) withLock(ctx context.Context, fn func(conn *sql.Conn) error) error {
+	conn, err := m.db.Conn(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to get database connection: %w", err)
+	}
+	defer conn.Close()
+
+	m.log.Info("waiting for migrations lock")
+
+	if _, err := conn.ExecContext(ctx, "SELECT pg_advisory_lock($1)", migrationsLockID); err != nil {
+		return fmt.Errorf("failed to acquire migrations lock: %w", err)
+	}
+	defer func() {
+		// the context may be done already, the lock must be released anyway
+		if _, err := conn.ExecContext(context.Background(), "SELECT pg_advisory_unlock($1)", migrationsLockID); err != nil {
+			m.log.Errorf("failed to release migrations lock: %v", err)
+		}
+	}()
+
+	if err := ensureSchemaMigrationsTable(ctx, conn); err != nil {
+		return err
+	}
+
+	return fn(conn)
+}
+
+// cleanVersion reads the schema version to migrate from, which must be clean and one of the
+// embedded migrations: stepping from an unknown version would revert or apply the wrong scripts.
+func (m *Migrator) cleanVersion(ctx context.Context, conn *sql.Conn) (int64, error) {
+	version, dirty, err := readVersion(ctx, conn)
+	if err != nil {
+		return 0, err
+	}
+
+	if dirty {
+		return 0, fmt.Errorf("%w: version %d", ErrSchemaDirty, version)
+	}
+
+	if version != 0 && m.indexOf(version) < 0 {
+		if version > m.Latest() {
+			return 0, fmt.Errorf("%w: database is at %d, latest embedded is %d", ErrSchemaAhead, version, m.Latest())
+		}
+
+		return 0, fmt.Errorf("%w: database is at %d", ErrMigrationNotFound, version)
+	}
+
+	return version, nil
+}
+
+// migrate applies or reverts one migration at a time, each in its own transaction along with
+// the version change, so that a failure leaves the schema clean at the last successful version.
+func (m *Migrator) migrate(ctx context.Context, conn *sql.Conn, from, to int64) error {
+	if from == to {
+		m.log.Infof("schema is already at version %d", to)
+		return nil
+	}
+
+	for _, migration := range m.migrations {
+		if from < to && migration.Version > from && migration.Version <= to {
+			if err := m.apply(ctx, conn, migration.up, migration.Version); err != nil {
+				return fmt.Errorf("failed to apply migration %d_%s: %w", migration.Version, migration.Name, err)
+			}
+
+			m.log.Infof("applied migration %d_%s", migration.Version, migration.Name)
+		}
+	}
+
+	for i := len(m.migrations) - 1; i >= 0; i-- {
+		migration := m.migrations[i]
+		if from > to && migration.Version <= from && migration.Version > to {
+			previous := int64(0)
+			if i > 0 {
+				previous = m.migrations[i-1].Version
+			}
+
+			if err := m.apply(ctx, conn, migration.down, previous); err != nil {
+				return fmt.Errorf("failed to revert migration %d_%s: %w", migration.Version, migration.Name, err)
+			}
+
+			m.log.Infof("reverted migration %d_%s", migration.Version, migration.Name)
+		}
+	}
+
+	return nil
+}
+
+func (m *Migrator) apply(ctx context.Context, conn *sql.Conn, script string, version int64) error {
+	tx, err := conn.BeginTx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback() //nolint:errcheck
+
+	if _, err := tx.ExecContext(ctx, script); err != nil {
+		return err
+	}
+
+	if err := setVersion(ctx, tx, version); err != nil {
+		return err
+	}
+
+	return tx.Commit()
+}
+
+func (m *Migrator) indexOf(version int64) int {
+	for i, migration := range m.migrations {
+		if migration.Version == version {
+			return i
+		}
+	}
+
+	return -1
+}
+
+func ensureSchemaMigrationsTable(ctx context.Context, conn *sql.Conn) error {
+	_, err := conn.ExecContext(ctx, "CREATE TABLE IF NOT EXISTS "+schemaMigrationsTable+
+		" (version bigint NOT NULL PRIMARY KEY, dirty boolean NOT NULL)")
+	if err != nil {
+		return fmt.Errorf("failed to create table %s: %w", schemaMigrationsTable, err)
+	}
+
+	return nil
+}
+
+// rowQuerier is either the connection pool or a single connection.
+type rowQuerier interface {
+	QueryRowContext(ctx context.Context, query string, args ...any) *sql.Row
+}
+
+// readVersion reads the schema version, a missing table or an empty one means no migration applied.
+func readVersion(ctx context.Context, db rowQuerier) (int64, bool, error) {
+	var version int64
+	var dirty bool
+
+	err := db.QueryRowContext(ctx, "SELECT version, dirty FROM "+schemaMigrationsTable+" LIMIT 1").
+		Scan(&version, &dirty)
+	if errors.Is(err, sql.ErrNoRows) {
+		return 0, false, nil
+	}
+
+	var pgErr pgdriver.Error
+	if errors.As(err, &pgErr) && pgErr.Field('C') == undefinedTable {
+		return 0, false, nil
+	}
+
+	if err != nil {
+		return 0, false, newListError(schemaMigrationsTable, err)
+	}
+
+	return version, dirty, nil
+}
+
+// setVersion keeps a single row in the table, an empty table means no migration applied.
+func setVersion(ctx context.Context, tx *sql.Tx, version int64) error {
+	if _, err := tx.ExecContext(ctx, "DELETE FROM "+schemaMigrationsTable); err != nil {
+		return newDeleteError(schemaMigrationsTable, err)
+	}
+
+	if version == 0 {
+		return nil
+	}
+
+	_, err := tx.ExecContext(ctx, "INSERT INTO "+schemaMigrationsTable+" (version, dirty) VALUES ($1, false)", version)
+	if err != nil {
+		return newInsertError(schemaMigrationsTable, err)
+	}
+
+	return nil
+}
+
+func loadMigrations(files fs.FS) ([]Migration, error) {
+	entries, err := fs.ReadDir(files, "migrations")
+	if err != nil {
+		return nil, err
+	}
+
+	byVersion := map[int64]*Migration{}
+	for _, entry := range entries {
+		match := migrationFileName.FindStringSubmatch(entry.Name())
+		if match == nil {
+			continue
+		}
+
+		version, err := strconv.ParseInt(match[1], 10, 64)
+		if err != nil {
+			return nil, fmt.Errorf("invalid migration version %q: %w", entry.Name(), err)
+		}
+
+		script, err := fs.ReadFile(files, "migrations/"+entry.Name())
+		if err != nil {
+			return nil, err
+		}
+
+		migration, ok := byVersion[version]
+		if !ok {
+			migration = &Migration{Version: version, Name: match[2]}
+			byVersion[version] = migration
+		}
+
+		if match[3] == "up" {
+			migration.up = string(script)
+		} else {
+			migration.down = string(script)
+		}
+	}
+
+	migrations := make([]Migration, 0, len(byVersion))
+	for _, migration := range byVersion {
+		if migration.up == "" || migration.down == "" {
+			return nil, fmt.Errorf("migration %d_%s must have both up and down scripts", migration.Version, migration.Name)
+		}
+
+		migrations = append(migrations, *migration)
+	}
+
+	sort.Slice(migrations, func(i, j int) bool {
+		return migrations[i].Version < migrations[j].Version
+	})
+
+	return migrations, nil
+}
@@ -0,0 +1,71 @@
+package models
+
+import (
+	"time"
+
+	"github.com/lyracampos/go-clean-architecture/internal/domain/entities"
+	"github.com/uptrace/bun"
+)
+
+const APIKeysTableName = "api_keys"
+
+type APIKey struct {
+	bun.BaseModel `bun:"table:api_keys,alias:ak"`
+
+	ID         int64     `bun:"id,pk,autoincrement"`
+	UserID     int64     `bun:"user_id,notnull"`
+	Name       string    `bun:"name,notnull"`
+	Prefix     string    `bun:"prefix,notnull"`
+	KeyHash    string    `bun:"key_hash,notnull"`
+	Scopes     []string  `bun:"scopes,array,notnull"`
+	ExpiresAt  time.Time `bun:"expires_at,nullzero"`
+	CreatedAt  time.Time `bun:"created_at,notnull,default:current_timestamp"`
+	LastUsedAt time.Time `bun:"last_used_at,nullzero"`
+}
+
+func NewAPIKeyModel(entity *entities.APIKey) *APIKey {
+	model := &APIKey{
+		BaseModel: bun.BaseModel{},
+		ID:        entity.ID,
+		UserID:    entity.UserID,
+		Name:      entity.Name,
+		Prefix:    entity.Prefix,
+		KeyHash:   entity.KeyHash,
+		Scopes:    entity.Scopes,
+		CreatedAt: entity.CreatedAt,
+	}
+
+	if entity.ExpiresAt != nil {
+		model.ExpiresAt = *entity.ExpiresAt
+	}
+
+	if entity.LastUsedAt != nil {
+		model.LastUsedAt = *entity.LastUsedAt
+	}
+
+	return model
+}
+
+func (k *APIKey) ToEntity() *entities.APIKey {
+	entity := &entities.APIKey{
+		ID:        k.ID,
+		UserID:    k.UserID,
+		Name:      k.Name,
+		Prefix:    k.Prefix,
+		KeyHash:   k.KeyHash,
+		Scopes:    k.Scopes,
+		CreatedAt: k.CreatedAt,
+	}
+
+	if !k.ExpiresAt.IsZero() {
+		expiresAt := k.ExpiresAt
+		entity.ExpiresAt = &expiresAt
+	}
+
+	if !k.LastUsedAt.IsZero() {
+		lastUsedAt := k.LastUsedAt
+		entity.LastUsedAt = &lastUsedAt
+	}
+
+	return entity
+}
@@ -0,0 +1,38 @@
+package models
+
+import (
+	"time"
+
+	"github.com/lyracampos/go-clean-architecture/internal/domain/entities"
+	"github.com/uptrace/bun"
+)
+
+const CredentialsTableName = "user_credentials"
+
+type Credentials struct {
+	bun.BaseModel `bun:"table:user_credentials,alias:uc"`
+
+	UserID       int64     `bun:"user_id,pk"`
+	PasswordHash string    `bun:"password_hash,notnull"`
+	CreatedAt    time.Time `bun:"created_at,notnull,default:current_timestamp"`
+	UpdatedAt    time.Time `bun:"updated_at,notnull,default:current_timestamp"`
+}
+
+func NewCredentialsModel(entity *entities.Credentials) *Credentials {
+	return &Credentials{
+		BaseModel:    bun.BaseModel{},
+		UserID:       entity.UserID,
+		PasswordHash: entity.PasswordHash,
+		CreatedAt:    entity.CreatedAt,
+		UpdatedAt:    entity.UpdatedAt,
+	}
+}
+
+func (c *Credentials) ToEntity() *entities.Credentials {
+	return &entities.Credentials{
+		UserID:       c.UserID,
+		PasswordHash: c.PasswordHash,
+		CreatedAt:    c.CreatedAt,
+		UpdatedAt:    c.UpdatedAt,
+	}
+}
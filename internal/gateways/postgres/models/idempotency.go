@@ -0,0 +1,56 @@
+package models
+
+import (
+	"time"
+
+	"github.com/lyracampos/go-clean-architecture/internal/domain/entities"
+	"github.com/uptrace/bun"
+)
+
+const IdempotencyKeysTableName = "idempotency_keys"
+
+type IdempotencyKey struct {
+	bun.BaseModel `bun:"table:idempotency_keys,alias:ik"`
+
+	Scope       string              `bun:"scope,pk"`
+	Key         string              `bun:"key,pk"`
+	Fingerprint string              `bun:"fingerprint,notnull"`
+	Completed   bool                `bun:"completed,notnull"`
+	StatusCode  int                 `bun:"status_code,nullzero"`
+	Header      map[string][]string `bun:"header,type:jsonb"`
+	Body        []byte              `bun:"body,type:bytea"`
+	CreatedAt   time.Time           `bun:"created_at,notnull"`
+	ExpiresAt   time.Time           `bun:"expires_at,notnull"`
+	LockedUntil time.Time           `bun:"locked_until,notnull"`
+}
+
+func NewIdempotencyKeyModel(entity *entities.IdempotencyRecord) *IdempotencyKey {
+	return &IdempotencyKey{
+		BaseModel:   bun.BaseModel{},
+		Scope:       entity.Scope,
+		Key:         entity.Key,
+		Fingerprint: entity.Fingerprint,
+		Completed:   entity.Completed,
+		StatusCode:  entity.StatusCode,
+		Header:      entity.Header,
+		Body:        entity.Body,
+		CreatedAt:   entity.CreatedAt,
+		ExpiresAt:   entity.ExpiresAt,
+		LockedUntil: entity.LockedUntil,
+	}
+}
+
+func (k *IdempotencyKey) ToEntity() *entities.IdempotencyRecord {
+	return &entities.IdempotencyRecord{
+		Scope:       k.Scope,
+		Key:         k.Key,
+		Fingerprint: k.Fingerprint,
+		Completed:   k.Completed,
+		StatusCode:  k.StatusCode,
+		Header:      k.Header,
+		Body:        k.Body,
+		CreatedAt:   k.CreatedAt,
+		ExpiresAt:   k.ExpiresAt,
+		LockedUntil: k.LockedUntil,
+	}
+}
@@ -0,0 +1,68 @@
+package models
+
+import (
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"github.com/lyracampos/go-clean-architecture/internal/domain"
+	"github.com/uptrace/bun"
+)
+
+const OutboxTableName = "outbox"
+
+// Delivery states of the outbox events.
+const (
+	OutboxStatusPending   = "pending"
+	OutboxStatusDelivered = "delivered"
+	OutboxStatusDead      = "dead"
+)
+
+type OutboxEvent struct {
+	bun.BaseModel `bun:"table:outbox,alias:o"`
+
+	ID            string          `bun:"id,pk,type:uuid,nullzero,default:gen_random_uuid()"`
+	AggregateType string          `bun:"aggregate_type,notnull"`
+	AggregateID   string          `bun:"aggregate_id,notnull"`
+	EventType     string          `bun:"event_type,notnull"`
+	SchemaVersion int             `bun:"schema_version,notnull"`
+	Payload       json.RawMessage `bun:"payload,type:jsonb,notnull"`
+	OccurredAt    time.Time       `bun:"occurred_at,nullzero,notnull,default:current_timestamp"`
+
+	Status        string    `bun:"status,nullzero,notnull,default:'pending'"`
+	Attempts      int       `bun:"attempts,notnull,default:0"`
+	NextAttemptAt time.Time `bun:"next_attempt_at,nullzero,notnull,default:current_timestamp"`
+	LastError     string    `bun:"last_error,nullzero"`
+	DeliveredAt   time.Time `bun:"delivered_at,nullzero"`
+}
+
+func NewOutboxEventModel(event domain.Event) (*OutboxEvent, error) {
+	payload, err := json.Marshal(event.Payload)
+	if err != nil {
+		return nil, fmt.Errorf("failed to encode %s event payload: %w", event.Type, err)
+	}
+
+	return &OutboxEvent{
+		BaseModel:     bun.BaseModel{},
+		ID:            event.ID,
+		AggregateType: event.AggregateType,
+		AggregateID:   event.AggregateID,
+		EventType:     string(event.Type),
+		SchemaVersion: event.SchemaVersion,
+		Payload:       payload,
+		OccurredAt:    event.OccurredAt,
+	}, nil
+}
+
+// ToEvent returns the event with its payload still encoded, as json.RawMessage.
+func (e *OutboxEvent) ToEvent() domain.Event {
+	return domain.Event{
+		ID:            e.ID,
+		AggregateType: e.AggregateType,
+		AggregateID:   e.AggregateID,
+		Type:          domain.EventType(e.EventType),
+		SchemaVersion: e.SchemaVersion,
+		OccurredAt:    e.OccurredAt,
+		Payload:       e.Payload,
+	}
+}
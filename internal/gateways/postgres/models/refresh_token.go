@@ -0,0 +1,68 @@
+package models
+
+import (
+	"time"
+
+	"github.com/lyracampos/go-clean-architecture/internal/domain/entities"
+	"github.com/uptrace/bun"
+)
+
+const RefreshTokensTableName = "refresh_tokens"
+
+type RefreshToken struct {
+	bun.BaseModel `bun:"table:refresh_tokens,alias:rt"`
+
+	ID        int64     `bun:"id,pk,autoincrement"`
+	UserID    int64     `bun:"user_id,notnull"`
+	FamilyID  string    `bun:"family_id,type:uuid,nullzero,notnull,default:gen_random_uuid()"`
+	TokenHash string    `bun:"token_hash,notnull"`
+	ExpiresAt time.Time `bun:"expires_at,notnull"`
+	CreatedAt time.Time `bun:"created_at,notnull,default:current_timestamp"`
+	UsedAt    time.Time `bun:"used_at,nullzero"`
+	RevokedAt time.Time `bun:"revoked_at,nullzero"`
+}
+
+func NewRefreshTokenModel(entity *entities.RefreshToken) *RefreshToken {
+	model := &RefreshToken{
+		BaseModel: bun.BaseModel{},
+		ID:        entity.ID,
+		UserID:    entity.UserID,
+		FamilyID:  entity.FamilyID,
+		TokenHash: entity.TokenHash,
+		ExpiresAt: entity.ExpiresAt,
+		CreatedAt: entity.CreatedAt,
+	}
+
+	if entity.UsedAt != nil {
+		model.UsedAt = *entity.UsedAt
+	}
+
+	if entity.RevokedAt != nil {
+		model.RevokedAt = *entity.RevokedAt
+	}
+
+	return model
+}
+
+func (t *RefreshToken) ToEntity() *entities.RefreshToken {
+	entity := &entities.RefreshToken{
+		ID:        t.ID,
+		UserID:    t.UserID,
+		FamilyID:  t.FamilyID,
+		TokenHash: t.TokenHash,
+		ExpiresAt: t.ExpiresAt,
+		CreatedAt: t.CreatedAt,
+	}
+
+	if !t.UsedAt.IsZero() {
+		usedAt := t.UsedAt
+		entity.UsedAt = &usedAt
+	}
+
+	if !t.RevokedAt.IsZero() {
+		revokedAt := t.RevokedAt
+		entity.RevokedAt = &revokedAt
+	}
+
+	return entity
+}
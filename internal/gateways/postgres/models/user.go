@@ -19,10 +19,12 @@ type User struct {
 	Role      string    `bun:"role,notnull"`
 	CreatedAt time.Time `bun:"created_at,notnull,default:current_timestamp"`
 	UpdatedAt time.Time `bun:"updated_at,notnull,default:current_timestamp"`
+	DeletedAt time.Time `bun:"deleted_at,soft_delete,nullzero"`
+	Version   int64     `bun:"version,notnull,default:1"`
 }
 
 func NewUserModel(entity *entities.User) *User {
-	return &User{
+	model := &User{
 		BaseModel: bun.BaseModel{},
 		ID:        entity.ID,
 		FirstName: entity.FirstName,
@@ -31,11 +33,18 @@ func NewUserModel(entity *entities.User) *User {
 		Role:      entity.Role,
 		CreatedAt: entity.CreatedAt,
 		UpdatedAt: entity.UpdatedAt,
+		Version:   entity.Version,
 	}
+
+	if entity.DeletedAt != nil {
+		model.DeletedAt = *entity.DeletedAt
+	}
+
+	return model
 }
 
 func (u *User) ToEntity() *entities.User {
-	return &entities.User{
+	entity := &entities.User{
 		ID:        u.ID,
 		FirstName: u.FirstName,
 		LastName:  u.LastName,
@@ -43,5 +52,13 @@ func (u *User) ToEntity() *entities.User {
 		Role:      u.Role,
 		CreatedAt: u.CreatedAt,
 		UpdatedAt: u.UpdatedAt,
+		Version:   u.Version,
 	}
+
+	if !u.DeletedAt.IsZero() {
+		deletedAt := u.DeletedAt
+		entity.DeletedAt = &deletedAt
+	}
+
+	return entity
 }
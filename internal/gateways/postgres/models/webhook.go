@@ -0,0 +1,155 @@
+package models
+
+import (
+	"encoding/json"
+	"time"
+
+	"github.com/lyracampos/go-clean-architecture/internal/domain/entities"
+	"github.com/uptrace/bun"
+)
+
+const (
+	WebhooksTableName                = "webhooks"
+	WebhookDeliveriesTableName       = "webhook_deliveries"
+	WebhookDeliveryAttemptsTableName = "webhook_delivery_attempts"
+)
+
+// Delivery states of the webhook deliveries.
+const (
+	WebhookDeliveryStatusPending   = "pending"
+	WebhookDeliveryStatusDelivered = "delivered"
+	WebhookDeliveryStatusFailed    = "failed"
+)
+
+type Webhook struct {
+	bun.BaseModel `bun:"table:webhooks,alias:w"`
+
+	ID                  int64     `bun:"id,pk,autoincrement"`
+	URL                 string    `bun:"url,notnull"`
+	EventTypes          []string  `bun:"event_types,array,notnull"`
+	Secret              string    `bun:"secret,notnull"`
+	Enabled             bool      `bun:"enabled,notnull"`
+	ConsecutiveFailures int       `bun:"consecutive_failures,notnull"`
+	CreatedAt           time.Time `bun:"created_at,nullzero,notnull,default:current_timestamp"`
+	UpdatedAt           time.Time `bun:"updated_at,nullzero,notnull,default:current_timestamp"`
+	DisabledAt          time.Time `bun:"disabled_at,nullzero"`
+}
+
+func NewWebhookModel(entity *entities.Webhook) *Webhook {
+	model := &Webhook{
+		BaseModel:           bun.BaseModel{},
+		ID:                  entity.ID,
+		URL:                 entity.URL,
+		EventTypes:          entity.EventTypes,
+		Secret:              entity.Secret,
+		Enabled:             entity.Enabled,
+		ConsecutiveFailures: entity.ConsecutiveFailures,
+		CreatedAt:           entity.CreatedAt,
+		UpdatedAt:           entity.UpdatedAt,
+	}
+
+	if entity.DisabledAt != nil {
+		model.DisabledAt = *entity.DisabledAt
+	}
+
+	return model
+}
+
+func (w *Webhook) ToEntity() *entities.Webhook {
+	entity := &entities.Webhook{
+		ID:                  w.ID,
+		URL:                 w.URL,
+		EventTypes:          w.EventTypes,
+		Secret:              w.Secret,
+		Enabled:             w.Enabled,
+		ConsecutiveFailures: w.ConsecutiveFailures,
+		CreatedAt:           w.CreatedAt,
+		UpdatedAt:           w.UpdatedAt,
+	}
+
+	if !w.DisabledAt.IsZero() {
+		disabledAt := w.DisabledAt
+		entity.DisabledAt = &disabledAt
+	}
+
+	return entity
+}
+
+type WebhookDelivery struct {
+	bun.BaseModel `bun:"table:webhook_deliveries,alias:wd"`
+
+	ID            int64           `bun:"id,pk,autoincrement"`
+	WebhookID     int64           `bun:"webhook_id,notnull"`
+	EventID       string          `bun:"event_id,type:uuid,notnull"`
+	EventType     string          `bun:"event_type,notnull"`
+	Payload       json.RawMessage `bun:"payload,type:jsonb,notnull"`
+	Status        string          `bun:"status,nullzero,notnull,default:'pending'"`
+	Attempts      int             `bun:"attempts,notnull,default:0"`
+	NextAttemptAt time.Time       `bun:"next_attempt_at,nullzero,notnull,default:current_timestamp"`
+	CreatedAt     time.Time       `bun:"created_at,nullzero,notnull,default:current_timestamp"`
+	DeliveredAt   time.Time       `bun:"delivered_at,nullzero"`
+}
+
+// ToEntity returns the delivery along with the URL and secret of its webhook.
+func (d *WebhookDelivery) ToEntity(webhook *Webhook) *entities.WebhookDelivery {
+	return &entities.WebhookDelivery{
+		ID:        d.ID,
+		WebhookID: d.WebhookID,
+		URL:       webhook.URL,
+		Secret:    webhook.Secret,
+		EventID:   d.EventID,
+		EventType: d.EventType,
+		Payload:   d.Payload,
+		Attempts:  d.Attempts,
+		CreatedAt: d.CreatedAt,
+	}
+}
+
+type WebhookDeliveryAttempt struct {
+	bun.BaseModel `bun:"table:webhook_delivery_attempts,alias:wda"`
+
+	ID          int64     `bun:"id,pk,autoincrement"`
+	DeliveryID  int64     `bun:"delivery_id,notnull"`
+	WebhookID   int64     `bun:"webhook_id,notnull"`
+	EventID     string    `bun:"event_id,type:uuid,notnull"`
+	EventType   string    `bun:"event_type,notnull"`
+	Attempt     int       `bun:"attempt,notnull"`
+	StatusCode  int       `bun:"status_code,nullzero"`
+	Error       string    `bun:"error,nullzero"`
+	DurationMs  int64     `bun:"duration_ms,notnull"`
+	Succeeded   bool      `bun:"succeeded,notnull"`
+	AttemptedAt time.Time `bun:"attempted_at,notnull"`
+}
+
+func NewWebhookDeliveryAttemptModel(entity *entities.WebhookDeliveryAttempt) *WebhookDeliveryAttempt {
+	return &WebhookDeliveryAttempt{
+		BaseModel:   bun.BaseModel{},
+		ID:          entity.ID,
+		DeliveryID:  entity.DeliveryID,
+		WebhookID:   entity.WebhookID,
+		EventID:     entity.EventID,
+		EventType:   entity.EventType,
+		Attempt:     entity.Attempt,
+		StatusCode:  entity.StatusCode,
+		Error:       entity.Error,
+		DurationMs:  entity.Duration.Milliseconds(),
+		Succeeded:   entity.Succeeded,
+		AttemptedAt: entity.AttemptedAt,
+	}
+}
+
+func (a *WebhookDeliveryAttempt) ToEntity() *entities.WebhookDeliveryAttempt {
+	return &entities.WebhookDeliveryAttempt{
+		ID:          a.ID,
+		DeliveryID:  a.DeliveryID,
+		WebhookID:   a.WebhookID,
+		EventID:     a.EventID,
+		EventType:   a.EventType,
+		Attempt:     a.Attempt,
+		StatusCode:  a.StatusCode,
+		Error:       a.Error,
+		Duration:    time.Duration(a.DurationMs) * time.Millisecond,
+		Succeeded:   a.Succeeded,
+		AttemptedAt: a.AttemptedAt,
+	}
+}
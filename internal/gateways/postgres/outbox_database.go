@@ -0,0 +1,142 @@
+package postgres
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"sort"
+	"time"
+
+	"github.com/lyracampos/go-clean-architecture/internal/domain"
+	"github.com/lyracampos/go-clean-architecture/internal/domain/ports"
+	"github.com/lyracampos/go-clean-architecture/internal/gateways/postgres/models"
+	"github.com/uptrace/bun"
+)
+
+var _ ports.OutboxDatabaseGateway = (*outboxDatabase)(nil)
+
+type outboxDatabase struct {
+	Client *Client
+}
+
+func NewOutboxDatabase(client *Client) *outboxDatabase {
+	return &outboxDatabase{
+		Client: client,
+	}
+}
+
+func (g *outboxDatabase) AppendEvents(ctx context.Context, events ...domain.Event) error {
+	return appendEvents(ctx, g.Client.DB, events...)
+}
+
+// appendEvents inserts the events with the given connection, so that
+// gateways can store them in the same transaction as the change they describe.
+func appendEvents(ctx context.Context, db bun.IDB, events ...domain.Event) error {
+	if len(events) == 0 {
+		return nil
+	}
+
+	eventModels := make([]*models.OutboxEvent, 0, len(events))
+	for _, event := range events {
+		model, err := models.NewOutboxEventModel(event)
+		if err != nil {
+			return newInsertError(models.OutboxTableName, err)
+		}
+
+		eventModels = append(eventModels, model)
+	}
+
+	_, err := db.NewInsert().Model(&eventModels).Exec(ctx)
+	if err != nil {
+		return newInsertError(models.OutboxTableName, translateError(err, domain.ErrEventDoesNotExist))
+	}
+
+	return nil
+}
+
+// ClaimEvents pushes the next attempt of the claimed events past the lease, so that
+// the row locks can be released right away instead of being held while publishing.
+func (g *outboxDatabase) ClaimEvents(ctx context.Context, limit int, lease time.Duration) ([]ports.ClaimedEvent, error) {
+	claimable := g.Client.DB.NewSelect().Model((*models.OutboxEvent)(nil)).
+		Column("id").
+		Where("? = ?", bun.Ident("status"), models.OutboxStatusPending).
+		Where("? <= current_timestamp", bun.Ident("next_attempt_at")).
+		OrderExpr("? ASC", bun.Ident("occurred_at")).
+		Limit(limit).
+		For("UPDATE SKIP LOCKED")
+
+	var modelList []models.OutboxEvent
+
+	err := g.Client.DB.NewUpdate().Model((*models.OutboxEvent)(nil)).
+		Set("? = ? + 1", bun.Ident("attempts"), bun.Ident("attempts")).
+		Set("? = current_timestamp + make_interval(secs => ?)", bun.Ident("next_attempt_at"), lease.Seconds()).
+		Where("? IN (?)", bun.Ident("id"), claimable).
+		Returning("*").
+		Scan(ctx, &modelList)
+	if err != nil && !errors.Is(err, sql.ErrNoRows) {
+		return nil, newUpdateError(models.OutboxTableName, translateError(err, domain.ErrEventDoesNotExist))
+	}
+
+	claimed := make([]ports.ClaimedEvent, 0, len(modelList))
+	for _, model := range modelList {
+		claimed = append(claimed, ports.ClaimedEvent{
+			Event:    model.ToEvent(),
+			Attempts: model.Attempts,
+		})
+	}
+
+	// the update returns rows in no particular order
+	sort.SliceStable(claimed, func(i, j int) bool {
+		return claimed[i].Event.OccurredAt.Before(claimed[j].Event.OccurredAt)
+	})
+
+	return claimed, nil
+}
+
+func (g *outboxDatabase) MarkEventDelivered(ctx context.Context, id string) error {
+	return g.markEvent(ctx, id, func(query *bun.UpdateQuery) {
+		query.
+			Set("? = ?", bun.Ident("status"), models.OutboxStatusDelivered).
+			Set("? = current_timestamp", bun.Ident("delivered_at")).
+			Set("? = NULL", bun.Ident("last_error"))
+	})
+}
+
+func (g *outboxDatabase) MarkEventFailed(ctx context.Context, id, lastError string, retryIn time.Duration) error {
+	return g.markEvent(ctx, id, func(query *bun.UpdateQuery) {
+		query.
+			Set("? = ?", bun.Ident("last_error"), lastError).
+			Set("? = current_timestamp + make_interval(secs => ?)", bun.Ident("next_attempt_at"), retryIn.Seconds())
+	})
+}
+
+func (g *outboxDatabase) MarkEventDead(ctx context.Context, id, lastError string) error {
+	return g.markEvent(ctx, id, func(query *bun.UpdateQuery) {
+		query.
+			Set("? = ?", bun.Ident("status"), models.OutboxStatusDead).
+			Set("? = ?", bun.Ident("last_error"), lastError)
+	})
+}
+
+func (g *outboxDatabase) markEvent(ctx context.Context, id string, set func(query *bun.UpdateQuery)) error {
+	query := g.Client.DB.NewUpdate().Model((*models.OutboxEvent)(nil)).
+		Where("? = ?", bun.Ident("id"), id)
+
+	set(query)
+
+	result, err := query.Exec(ctx)
+	if err != nil {
+		return newUpdateError(models.OutboxTableName, translateError(err, domain.ErrEventDoesNotExist))
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return newUpdateError(models.OutboxTableName, err)
+	}
+
+	if rowsAffected == 0 {
+		return newNoRowsError(models.OutboxTableName, domain.ErrEventDoesNotExist)
+	}
+
+	return nil
+}
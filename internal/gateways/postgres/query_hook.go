@@ -0,0 +1,57 @@
+package postgres
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"time"
+
+	"github.com/lyracampos/go-clean-architecture/internal/logging"
+	"github.com/uptrace/bun"
+	"go.uber.org/zap"
+)
+
+var _ bun.QueryHook = (*queryLogHook)(nil)
+
+// queryLogHook logs the queries with the logger of their context, so that they
+// carry the ID of the request that ran them.
+type queryLogHook struct {
+	log *zap.SugaredLogger
+	// verbose logs every query along with its text, otherwise only the failed ones are logged.
+	verbose bool
+}
+
+func newQueryLogHook(log *zap.SugaredLogger, verbose bool) *queryLogHook {
+	return &queryLogHook{
+		log:     log,
+		verbose: verbose,
+	}
+}
+
+func (h *queryLogHook) BeforeQuery(ctx context.Context, _ *bun.QueryEvent) context.Context {
+	return ctx
+}
+
+func (h *queryLogHook) AfterQuery(ctx context.Context, event *bun.QueryEvent) {
+	failed := event.Err != nil && !errors.Is(event.Err, sql.ErrNoRows)
+	if !failed && !h.verbose {
+		return
+	}
+
+	log := logging.FromContext(ctx, h.log).With(
+		"operation", event.Operation(),
+		"duration", time.Since(event.StartTime),
+	)
+
+	// the query text carries its arguments, such as emails and password hashes
+	if h.verbose {
+		log = log.With("query", event.Query)
+	}
+
+	if failed {
+		log.Warnw("query failed", "error", event.Err)
+		return
+	}
+
+	log.Info("query executed")
+}
@@ -0,0 +1,113 @@
+package postgres
+
+import (
+	"context"
+
+	"github.com/lyracampos/go-clean-architecture/internal/domain"
+	"github.com/lyracampos/go-clean-architecture/internal/domain/entities"
+	"github.com/lyracampos/go-clean-architecture/internal/domain/ports"
+	"github.com/lyracampos/go-clean-architecture/internal/gateways/postgres/models"
+	"github.com/uptrace/bun"
+)
+
+var _ ports.RefreshTokenDatabaseGateway = (*refreshTokenDatabase)(nil)
+
+type refreshTokenDatabase struct {
+	Client *Client
+}
+
+func NewRefreshTokenDatabase(client *Client) *refreshTokenDatabase {
+	return &refreshTokenDatabase{
+		Client: client,
+	}
+}
+
+func (g *refreshTokenDatabase) InsertRefreshToken(ctx context.Context, token *entities.RefreshToken) (*entities.RefreshToken, error) {
+	return g.insertRefreshToken(ctx, g.Client.DB, token)
+}
+
+func (g *refreshTokenDatabase) GetRefreshToken(ctx context.Context, tokenHash string) (*entities.RefreshToken, error) {
+	model := models.RefreshToken{}
+
+	err := g.Client.DB.NewSelect().Model(&model).
+		Where("? = ?", bun.Ident("token_hash"), tokenHash).
+		Scan(ctx)
+	if err != nil {
+		return nil, newListError(models.RefreshTokensTableName, translateError(err, domain.ErrRefreshTokenDoesNotExist))
+	}
+
+	return model.ToEntity(), nil
+}
+
+// RotateRefreshToken marks the used token only if no other request used or revoked it first,
+// so that a token can't be exchanged twice even by concurrent requests.
+func (g *refreshTokenDatabase) RotateRefreshToken(ctx context.Context, usedID int64, next *entities.RefreshToken) (*entities.RefreshToken, error) {
+	var inserted *entities.RefreshToken
+
+	err := g.Client.DB.RunInTx(ctx, nil, func(ctx context.Context, tx bun.Tx) error {
+		result, err := tx.NewUpdate().Model((*models.RefreshToken)(nil)).
+			Set("? = current_timestamp", bun.Ident("used_at")).
+			Where("? = ?", bun.Ident("id"), usedID).
+			Where("? IS NULL", bun.Ident("used_at")).
+			Where("? IS NULL", bun.Ident("revoked_at")).
+			Exec(ctx)
+		if err != nil {
+			return newUpdateError(models.RefreshTokensTableName, translateError(err, domain.ErrRefreshTokenDoesNotExist))
+		}
+
+		rowsAffected, err := result.RowsAffected()
+		if err != nil {
+			return newUpdateError(models.RefreshTokensTableName, err)
+		}
+
+		if rowsAffected == 0 {
+			return domain.ErrRefreshTokenAlreadyUsed
+		}
+
+		inserted, err = g.insertRefreshToken(ctx, tx, next)
+
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return inserted, nil
+}
+
+func (g *refreshTokenDatabase) RevokeRefreshTokenFamily(ctx context.Context, familyID string) error {
+	_, err := g.Client.DB.NewUpdate().Model((*models.RefreshToken)(nil)).
+		Set("? = current_timestamp", bun.Ident("revoked_at")).
+		Where("? = ?", bun.Ident("family_id"), familyID).
+		Where("? IS NULL", bun.Ident("revoked_at")).
+		Exec(ctx)
+	if err != nil {
+		return newUpdateError(models.RefreshTokensTableName, translateError(err, domain.ErrRefreshTokenDoesNotExist))
+	}
+
+	return nil
+}
+
+func (g *refreshTokenDatabase) RevokeUserRefreshTokens(ctx context.Context, userID int64) error {
+	_, err := g.Client.conn(ctx).NewUpdate().Model((*models.RefreshToken)(nil)).
+		Set("? = current_timestamp", bun.Ident("revoked_at")).
+		Where("? = ?", bun.Ident("user_id"), userID).
+		Where("? IS NULL", bun.Ident("revoked_at")).
+		Exec(ctx)
+	if err != nil {
+		return newUpdateError(models.RefreshTokensTableName, translateError(err, domain.ErrRefreshTokenDoesNotExist))
+	}
+
+	return nil
+}
+
+func (g *refreshTokenDatabase) insertRefreshToken(ctx context.Context, db bun.IDB, token *entities.RefreshToken) (*entities.RefreshToken, error) {
+	model := models.NewRefreshTokenModel(token)
+
+	_, err := db.NewInsert().Model(model).Returning("*").Exec(ctx)
+	if err != nil {
+		return nil, newInsertError(models.RefreshTokensTableName, translateError(err, domain.ErrRefreshTokenDoesNotExist))
+	}
+
+	return model.ToEntity(), nil
+}
@@ -0,0 +1,35 @@
+package postgres
+
+import (
+	"context"
+
+	"github.com/lyracampos/go-clean-architecture/internal/domain/ports"
+	"github.com/uptrace/bun"
+)
+
+var _ ports.Transactor = (*Client)(nil)
+
+type txContextKey struct{}
+
+// RunInTx runs fn in a transaction carried by its context, see conn.
+// Calls nested in a transaction join it instead of starting another one.
+func (c *Client) RunInTx(ctx context.Context, fn func(ctx context.Context) error) error {
+	if _, ok := ctx.Value(txContextKey{}).(bun.Tx); ok {
+		return fn(ctx)
+	}
+
+	err := c.DB.RunInTx(ctx, nil, func(ctx context.Context, tx bun.Tx) error {
+		return fn(context.WithValue(ctx, txContextKey{}, tx))
+	})
+
+	return translateContextError(err)
+}
+
+// conn returns the transaction started by RunInTx, or the connection pool outside of one.
+func (c *Client) conn(ctx context.Context) bun.IDB {
+	if tx, ok := ctx.Value(txContextKey{}).(bun.Tx); ok {
+		return tx
+	}
+
+	return c.DB
+}
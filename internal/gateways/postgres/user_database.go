@@ -2,13 +2,18 @@ package postgres
 
 import (
 	"context"
+	"database/sql"
+	"errors"
+	"slices"
 	"strings"
+	"time"
 
 	"github.com/lyracampos/go-clean-architecture/internal/domain"
 	"github.com/lyracampos/go-clean-architecture/internal/domain/entities"
 	"github.com/lyracampos/go-clean-architecture/internal/domain/ports"
 	"github.com/lyracampos/go-clean-architecture/internal/gateways/postgres/models"
 	"github.com/uptrace/bun"
+	"github.com/uptrace/bun/schema"
 )
 
 var _ ports.UserDatabaseGateway = (*userDatabase)(nil)
@@ -27,65 +32,355 @@ func (g *userDatabase) ListUser(ctx context.Context, filter ports.ListUserFilter
 	var modelList []models.User
 	query := g.Client.DB.NewSelect().Model(&modelList)
 
-	if filter.FirstName != "" {
-		query.Where("first_name LIKE ?", "%"+filter.FirstName+"%")
+	applyListUserFilter(query, filter)
+	applyListUserPage(query, filter)
+
+	if err := query.Scan(ctx); err != nil {
+		return nil, newListError(models.UsersTableName, translateError(err, domain.ErrUserDoesNotExist))
 	}
 
-	if filter.LastName != "" {
-		query.Where("last_name LIKE ?", "%"+filter.LastName+"%")
+	var list []*entities.User
+	for _, model := range modelList {
+		list = append(list, model.ToEntity())
 	}
 
-	if filter.Email != "" {
-		query.Where("email LIKE ?", "%"+filter.Email+"%")
+	return list, nil
+}
+
+// CountUsers returns how many users match the filter, ignoring its page settings.
+func (g *userDatabase) CountUsers(ctx context.Context, filter ports.ListUserFilter) (int, error) {
+	query := g.Client.DB.NewSelect().Model((*models.User)(nil))
+
+	applyListUserFilter(query, filter)
+
+	count, err := query.Count(ctx)
+	if err != nil {
+		return 0, newListError(models.UsersTableName, translateError(err, domain.ErrUserDoesNotExist))
 	}
 
-	if filter.Role != "" {
-		query.Where("? = ?", bun.Ident("role"), filter.Role)
+	return count, nil
+}
+
+func (g *userDatabase) GetUser(ctx context.Context, filter ports.GetUserFilter) (*entities.User, error) {
+	model := models.User{}
+	query := g.Client.DB.NewSelect().Model(&model)
+
+	if filter.ID != 0 {
+		query.Where("? = ?", bun.Ident("id"), filter.ID)
+	} else {
+		query.Where("? = ?", bun.Ident("email"), filter.Email)
+	}
+
+	if filter.IncludeDeleted {
+		query.WhereAllWithDeleted()
 	}
 
 	if err := query.Scan(ctx); err != nil {
-		if strings.Contains(err.Error(), NoRowsInResultSet) {
-			return nil, newNoRowsError(models.UsersTableName, domain.ErrUserDoesNotExist)
+		return nil, newListError(models.UsersTableName, translateError(err, domain.ErrUserDoesNotExist))
+	}
+
+	return model.ToEntity(), nil
+}
+
+func (g *userDatabase) InsertUser(ctx context.Context, user *entities.User) (*entities.User, error) {
+	model := models.NewUserModel(user)
+
+	err := g.Client.DB.RunInTx(ctx, nil, func(ctx context.Context, tx bun.Tx) error {
+		_, err := tx.NewInsert().Model(model).Exec(ctx)
+		if err != nil {
+			return newInsertError(models.UsersTableName, translateError(err, domain.ErrUserDoesNotExist))
 		}
 
-		return nil, newListError(models.UsersTableName, err)
+		return appendEvents(ctx, tx, domain.NewUserCreatedEvent(model.ToEntity()))
+	})
+	if err != nil {
+		return nil, translateContextError(err)
 	}
 
-	var list []*entities.User
-	for _, model := range modelList {
-		list = append(list, model.ToEntity())
+	return model.ToEntity(), nil
+}
+
+// UpdateUser stores the user when its version still matches the one informed,
+// compare-and-swap style, and bumps both version and updated_at.
+func (g *userDatabase) UpdateUser(ctx context.Context, user *entities.User) (*entities.User, error) {
+	model := models.NewUserModel(user)
+
+	err := g.Client.DB.RunInTx(ctx, nil, func(ctx context.Context, tx bun.Tx) error {
+		// the row is locked so that the role can't change between reading and updating it
+		var previousRole string
+		err := tx.NewSelect().Model((*models.User)(nil)).
+			Column("role").
+			Where("? = ?", bun.Ident("id"), user.ID).
+			For("UPDATE").
+			Scan(ctx, &previousRole)
+		if err != nil {
+			if errors.Is(err, sql.ErrNoRows) {
+				return err
+			}
+
+			return newListError(models.UsersTableName, translateError(err, domain.ErrUserDoesNotExist))
+		}
+
+		err = tx.NewUpdate().Model(model).
+			Set("? = ?", bun.Ident("first_name"), model.FirstName).
+			Set("? = ?", bun.Ident("last_name"), model.LastName).
+			Set("? = ?", bun.Ident("email"), model.Email).
+			Set("? = ?", bun.Ident("role"), model.Role).
+			Set("? = current_timestamp", bun.Ident("updated_at")).
+			Set("? = ? + 1", bun.Ident("version"), bun.Ident("version")).
+			WherePK().
+			Where("? = ?", bun.Ident("version"), user.Version).
+			Returning("*").
+			Scan(ctx)
+		if err != nil {
+			if errors.Is(err, sql.ErrNoRows) {
+				return err
+			}
+
+			return newUpdateError(models.UsersTableName, translateError(err, domain.ErrUserDoesNotExist))
+		}
+
+		updatedUser := model.ToEntity()
+		events := []domain.Event{domain.NewUserUpdatedEvent(updatedUser)}
+		if updatedUser.Role != previousRole {
+			events = append(events, domain.NewUserRoleChangedEvent(updatedUser, previousRole))
+		}
+
+		return appendEvents(ctx, tx, events...)
+	})
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, g.versionConflictOrNotFound(ctx, user.ID, false)
+		}
+
+		return nil, translateContextError(err)
 	}
 
-	return list, nil
+	return model.ToEntity(), nil
 }
 
-func (g *userDatabase) GetUser(ctx context.Context, id int64) (*entities.User, error) {
-	model := models.User{}
+// DeleteUser soft deletes the user, keeping the row until it is purged.
+func (g *userDatabase) DeleteUser(ctx context.Context, id int64, versions []int64) error {
+	err := g.Client.DB.RunInTx(ctx, nil, func(ctx context.Context, tx bun.Tx) error {
+		query := tx.NewUpdate().Model((*models.User)(nil)).
+			Set("? = current_timestamp", bun.Ident("deleted_at")).
+			Set("? = ? + 1", bun.Ident("version"), bun.Ident("version")).
+			Where("? = ?", bun.Ident("id"), id)
+		whereVersions(query, versions)
+
+		var deletedVersion int64
+		err := query.Returning("?", bun.Ident("version")).Scan(ctx, &deletedVersion)
+		if err != nil {
+			if errors.Is(err, sql.ErrNoRows) {
+				return err
+			}
 
-	if err := g.Client.DB.NewSelect().Model(&model).
-		Where("? = ?", bun.Ident("id"), id).
-		Scan(ctx); err != nil {
-		if strings.Contains(err.Error(), NoRowsInResultSet) {
-			return nil, newNoRowsError(models.UsersTableName, domain.ErrUserDoesNotExist)
+			return newDeleteError(models.UsersTableName, translateError(err, domain.ErrUserDoesNotExist))
 		}
 
-		return nil, newListError(models.UsersTableName, err)
+		return appendEvents(ctx, tx, domain.NewUserDeletedEvent(id, deletedVersion))
+	})
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return g.versionConflictOrNotFound(ctx, id, false)
+		}
+
+		return translateContextError(err)
 	}
 
-	return model.ToEntity(), nil
+	return nil
 }
 
-func (g *userDatabase) InsertUser(ctx context.Context, user *entities.User) (*entities.User, error) {
-	model := models.NewUserModel(user)
+// RestoreUser undoes a soft delete, consumers are told the user is back by an update event.
+// Users that are not deleted are reported as not found, and the email taken by another user
+// in the meantime as already in use.
+func (g *userDatabase) RestoreUser(ctx context.Context, id int64, versions []int64) (*entities.User, error) {
+	model := models.User{}
+
+	err := g.Client.DB.RunInTx(ctx, nil, func(ctx context.Context, tx bun.Tx) error {
+		query := tx.NewUpdate().Model(&model).
+			WhereDeleted().
+			Set("? = NULL", bun.Ident("deleted_at")).
+			Set("? = current_timestamp", bun.Ident("updated_at")).
+			Set("? = ? + 1", bun.Ident("version"), bun.Ident("version")).
+			Where("? = ?", bun.Ident("id"), id)
+		whereVersions(query, versions)
 
-	_, err := g.Client.DB.NewInsert().Model(model).Exec(ctx)
+		err := query.Returning("*").Scan(ctx)
+		if err != nil {
+			if errors.Is(err, sql.ErrNoRows) {
+				return err
+			}
+
+			return newUpdateError(models.UsersTableName, translateError(err, domain.ErrUserDoesNotExist))
+		}
+
+		return appendEvents(ctx, tx, domain.NewUserUpdatedEvent(model.ToEntity()))
+	})
 	if err != nil {
-		if strings.Contains(err.Error(), DuplicateKeyPrefix) {
-			return nil, domain.ErrEmailAlreadyInUse
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, g.versionConflictOrNotFound(ctx, id, true)
 		}
 
-		return nil, newInsertError(models.UsersTableName, err)
+		return nil, translateContextError(err)
 	}
 
 	return model.ToEntity(), nil
 }
+
+// PurgeUsers permanently deletes the users soft deleted before the given time.
+func (g *userDatabase) PurgeUsers(ctx context.Context, deletedBefore time.Time) (int64, error) {
+	result, err := g.Client.DB.NewDelete().Model((*models.User)(nil)).
+		WhereDeleted().
+		Where("? < ?", bun.Ident("deleted_at"), deletedBefore).
+		ForceDelete().
+		Exec(ctx)
+	if err != nil {
+		return 0, newDeleteError(models.UsersTableName, translateError(err, domain.ErrUserDoesNotExist))
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return 0, newDeleteError(models.UsersTableName, err)
+	}
+
+	return rowsAffected, nil
+}
+
+// versionConflictOrNotFound tells why a compare-and-swap statement touched no rows:
+// either the user does not exist or its version has changed in the meantime.
+// onlyDeleted looks for a soft deleted user instead of a live one.
+func (g *userDatabase) versionConflictOrNotFound(ctx context.Context, id int64, onlyDeleted bool) error {
+	query := g.Client.DB.NewSelect().Model((*models.User)(nil)).
+		Where("? = ?", bun.Ident("id"), id)
+
+	if onlyDeleted {
+		query.WhereDeleted()
+	}
+
+	exists, err := query.Exists(ctx)
+	if err != nil {
+		return newListError(models.UsersTableName, translateError(err, domain.ErrUserDoesNotExist))
+	}
+
+	if !exists {
+		return newNoRowsError(models.UsersTableName, domain.ErrUserDoesNotExist)
+	}
+
+	return domain.ErrVersionConflict
+}
+
+// whereVersions restricts a compare-and-swap statement to the versions informed,
+// entities.AnyVersion matches whatever the current version is and no version matches none.
+func whereVersions(query *bun.UpdateQuery, versions []int64) {
+	if !slices.Contains(versions, entities.AnyVersion) {
+		query.Where("? IN (?)", bun.Ident("version"), bun.In(versions))
+	}
+}
+
+var userSortColumns = map[string]string{
+	ports.UserSortCreatedAt: "created_at",
+	ports.UserSortFirstName: "first_name",
+	ports.UserSortLastName:  "last_name",
+	ports.UserSortEmail:     "email",
+}
+
+// applyListUserFilter matches names and email case-insensitively,
+// the trigram GIN indexes back both ILIKE and the similarity operator (%).
+func applyListUserFilter(query *bun.SelectQuery, filter ports.ListUserFilter) {
+	if filter.ID != 0 {
+		query.Where("? = ?", bun.Ident("id"), filter.ID)
+	}
+
+	if filter.FirstName != "" {
+		query.Where("? ILIKE ?", bun.Ident("first_name"), containsPattern(filter.FirstName))
+	}
+
+	if filter.LastName != "" {
+		query.Where("? ILIKE ?", bun.Ident("last_name"), containsPattern(filter.LastName))
+	}
+
+	if filter.Email != "" {
+		query.Where("? ILIKE ?", bun.Ident("email"), containsPattern(filter.Email))
+	}
+
+	if filter.Query != "" {
+		pattern := containsPattern(filter.Query)
+		query.WhereGroup(" AND ", func(q *bun.SelectQuery) *bun.SelectQuery {
+			return q.
+				WhereOr("? ILIKE ?", bun.Ident("first_name"), pattern).
+				WhereOr("? ILIKE ?", bun.Ident("last_name"), pattern).
+				WhereOr("? ILIKE ?", bun.Ident("email"), pattern).
+				WhereOr("? % ?", bun.Ident("first_name"), filter.Query).
+				WhereOr("? % ?", bun.Ident("last_name"), filter.Query)
+		})
+	}
+
+	if filter.Role != "" {
+		query.Where("? = ?", bun.Ident("role"), filter.Role)
+	}
+
+	if filter.IncludeDeleted {
+		query.WhereAllWithDeleted()
+	}
+}
+
+// applyListUserPage orders the users by the sort column and the ID as tie breaker,
+// and seeks past the cursor position (keyset pagination) instead of using OFFSET.
+func applyListUserPage(query *bun.SelectQuery, filter ports.ListUserFilter) {
+	if filter.Sort.Field == ports.UserSortRelevance {
+		applyListUserRelevancePage(query, filter)
+
+		return
+	}
+
+	column, ok := userSortColumns[filter.Sort.Field]
+	if !ok {
+		column = userSortColumns[ports.UserSortCreatedAt]
+	}
+
+	direction, comparison := "ASC", ">"
+	if filter.Sort.Descending {
+		direction, comparison = "DESC", "<"
+	}
+
+	if filter.After != nil {
+		query.Where("(?, ?) "+comparison+" (?, ?)",
+			bun.Ident(column), bun.Ident("id"), filter.After.Value, filter.After.ID)
+	}
+
+	query.OrderExpr("? "+direction+", ? "+direction, bun.Ident(column), bun.Ident("id"))
+
+	if filter.Limit > 0 {
+		query.Limit(filter.Limit)
+	}
+}
+
+// applyListUserRelevancePage orders the users by how similar they are to the searched text.
+// The rank is not stored, so the cursor position is ranked again from the cursor user ID.
+func applyListUserRelevancePage(query *bun.SelectQuery, filter ports.ListUserFilter) {
+	rank := schema.SafeQuery(
+		"greatest(similarity(first_name, ?0), similarity(last_name, ?0), similarity(email, ?0))",
+		[]interface{}{filter.Query},
+	)
+
+	if filter.After != nil {
+		query.Where("(?, ?) < ((SELECT ? FROM ? WHERE ? = ?), ?)",
+			rank, bun.Ident("id"),
+			rank, bun.Ident(models.UsersTableName), bun.Ident("id"), filter.After.ID,
+			filter.After.ID)
+	}
+
+	query.OrderExpr("? DESC, ? DESC", rank, bun.Ident("id"))
+
+	if filter.Limit > 0 {
+		query.Limit(filter.Limit)
+	}
+}
+
+// containsPattern builds an ILIKE pattern matching the text anywhere, escaping its wildcards.
+func containsPattern(text string) string {
+	return "%" + likeEscaper.Replace(text) + "%"
+}
+
+var likeEscaper = strings.NewReplacer(`\`, `\\`, "%", `\%`, "_", `\_`)
@@ -0,0 +1,274 @@
+package postgres
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"sort"
+	"time"
+
+	"github.com/lyracampos/go-clean-architecture/internal/domain"
+	"github.com/lyracampos/go-clean-architecture/internal/domain/entities"
+	"github.com/lyracampos/go-clean-architecture/internal/domain/ports"
+	"github.com/lyracampos/go-clean-architecture/internal/gateways/postgres/models"
+	"github.com/uptrace/bun"
+)
+
+var _ ports.WebhookDatabaseGateway = (*webhookDatabase)(nil)
+
+type webhookDatabase struct {
+	Client *Client
+}
+
+func NewWebhookDatabase(client *Client) *webhookDatabase {
+	return &webhookDatabase{
+		Client: client,
+	}
+}
+
+func (g *webhookDatabase) InsertWebhook(ctx context.Context, webhook *entities.Webhook) (*entities.Webhook, error) {
+	model := models.NewWebhookModel(webhook)
+
+	_, err := g.Client.DB.NewInsert().Model(model).Returning("*").Exec(ctx)
+	if err != nil {
+		return nil, newInsertError(models.WebhooksTableName, translateError(err, domain.ErrWebhookDoesNotExist))
+	}
+
+	return model.ToEntity(), nil
+}
+
+func (g *webhookDatabase) ListWebhooks(ctx context.Context) ([]*entities.Webhook, error) {
+	var modelList []models.Webhook
+
+	err := g.Client.DB.NewSelect().Model(&modelList).
+		Order("id").
+		Scan(ctx)
+	if err != nil {
+		return nil, newListError(models.WebhooksTableName, translateError(err, domain.ErrWebhookDoesNotExist))
+	}
+
+	list := make([]*entities.Webhook, 0, len(modelList))
+	for _, model := range modelList {
+		list = append(list, model.ToEntity())
+	}
+
+	return list, nil
+}
+
+func (g *webhookDatabase) GetWebhook(ctx context.Context, id int64) (*entities.Webhook, error) {
+	model := models.Webhook{}
+
+	err := g.Client.DB.NewSelect().Model(&model).
+		Where("? = ?", bun.Ident("id"), id).
+		Scan(ctx)
+	if err != nil {
+		return nil, newListError(models.WebhooksTableName, translateError(err, domain.ErrWebhookDoesNotExist))
+	}
+
+	return model.ToEntity(), nil
+}
+
+func (g *webhookDatabase) UpdateWebhook(ctx context.Context, webhook *entities.Webhook) (*entities.Webhook, error) {
+	model := models.NewWebhookModel(webhook)
+
+	err := g.Client.DB.NewUpdate().Model(model).
+		Column("url", "event_types", "secret", "enabled", "consecutive_failures", "disabled_at", "updated_at").
+		Value("updated_at", "current_timestamp").
+		WherePK().
+		Returning("*").
+		Scan(ctx)
+	if err != nil {
+		return nil, newUpdateError(models.WebhooksTableName, translateError(err, domain.ErrWebhookDoesNotExist))
+	}
+
+	return model.ToEntity(), nil
+}
+
+func (g *webhookDatabase) DeleteWebhook(ctx context.Context, id int64) error {
+	result, err := g.Client.DB.NewDelete().Model((*models.Webhook)(nil)).
+		Where("? = ?", bun.Ident("id"), id).
+		Exec(ctx)
+	if err != nil {
+		return newDeleteError(models.WebhooksTableName, translateError(err, domain.ErrWebhookDoesNotExist))
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return newDeleteError(models.WebhooksTableName, err)
+	}
+
+	if rowsAffected == 0 {
+		return domain.ErrWebhookDoesNotExist
+	}
+
+	return nil
+}
+
+func (g *webhookDatabase) ListWebhookDeliveryAttempts(ctx context.Context, webhookID int64, limit int) ([]*entities.WebhookDeliveryAttempt, error) {
+	var modelList []models.WebhookDeliveryAttempt
+
+	err := g.Client.DB.NewSelect().Model(&modelList).
+		Where("? = ?", bun.Ident("webhook_id"), webhookID).
+		OrderExpr("? DESC, ? DESC", bun.Ident("attempted_at"), bun.Ident("id")).
+		Limit(limit).
+		Scan(ctx)
+	if err != nil {
+		return nil, newListError(models.WebhookDeliveryAttemptsTableName, translateError(err, domain.ErrWebhookDeliveryDoesNotExist))
+	}
+
+	list := make([]*entities.WebhookDeliveryAttempt, 0, len(modelList))
+	for _, model := range modelList {
+		list = append(list, model.ToEntity())
+	}
+
+	return list, nil
+}
+
+func (g *webhookDatabase) EnqueueWebhookDeliveries(ctx context.Context, eventID, eventType string, payload []byte) error {
+	_, err := g.Client.DB.NewRaw(
+		`INSERT INTO ? (webhook_id, event_id, event_type, payload)
+		SELECT id, ?, ?, ?::jsonb FROM ? WHERE enabled AND ? = ANY(event_types)
+		ON CONFLICT (webhook_id, event_id) DO NOTHING`,
+		bun.Ident(models.WebhookDeliveriesTableName), eventID, eventType, string(payload),
+		bun.Ident(models.WebhooksTableName), eventType,
+	).Exec(ctx)
+	if err != nil {
+		return newInsertError(models.WebhookDeliveriesTableName, translateError(err, domain.ErrWebhookDeliveryDoesNotExist))
+	}
+
+	return nil
+}
+
+// ClaimWebhookDeliveries pushes the next attempt of the claimed deliveries past the lease,
+// the same way the outbox is claimed, so that no row lock is held while posting them.
+func (g *webhookDatabase) ClaimWebhookDeliveries(ctx context.Context, limit int, lease time.Duration) ([]*entities.WebhookDelivery, error) {
+	claimable := g.Client.DB.NewSelect().Model((*models.WebhookDelivery)(nil)).
+		Column("wd.id").
+		Join("JOIN ? AS w ON w.id = wd.webhook_id", bun.Ident(models.WebhooksTableName)).
+		Where("wd.status = ?", models.WebhookDeliveryStatusPending).
+		Where("wd.next_attempt_at <= current_timestamp").
+		Where("w.enabled").
+		OrderExpr("wd.next_attempt_at ASC").
+		Limit(limit).
+		For("UPDATE OF wd SKIP LOCKED")
+
+	var deliveryList []models.WebhookDelivery
+
+	err := g.Client.DB.NewUpdate().Model((*models.WebhookDelivery)(nil)).
+		Set("? = ? + 1", bun.Ident("attempts"), bun.Ident("attempts")).
+		Set("? = current_timestamp + make_interval(secs => ?)", bun.Ident("next_attempt_at"), lease.Seconds()).
+		Where("? IN (?)", bun.Ident("id"), claimable).
+		Returning("*").
+		Scan(ctx, &deliveryList)
+	if err != nil && !errors.Is(err, sql.ErrNoRows) {
+		return nil, newUpdateError(models.WebhookDeliveriesTableName, translateError(err, domain.ErrWebhookDeliveryDoesNotExist))
+	}
+
+	if len(deliveryList) == 0 {
+		return []*entities.WebhookDelivery{}, nil
+	}
+
+	webhookIDs := make([]int64, 0, len(deliveryList))
+	for _, delivery := range deliveryList {
+		webhookIDs = append(webhookIDs, delivery.WebhookID)
+	}
+
+	var webhookList []models.Webhook
+
+	err = g.Client.DB.NewSelect().Model(&webhookList).
+		Where("? IN (?)", bun.Ident("id"), bun.In(webhookIDs)).
+		Scan(ctx)
+	if err != nil {
+		return nil, newListError(models.WebhooksTableName, translateError(err, domain.ErrWebhookDoesNotExist))
+	}
+
+	webhooks := make(map[int64]*models.Webhook, len(webhookList))
+	for i := range webhookList {
+		webhooks[webhookList[i].ID] = &webhookList[i]
+	}
+
+	claimed := make([]*entities.WebhookDelivery, 0, len(deliveryList))
+	for _, delivery := range deliveryList {
+		// the webhook was deleted meanwhile, its deliveries are gone with it
+		webhook, ok := webhooks[delivery.WebhookID]
+		if !ok {
+			continue
+		}
+
+		claimed = append(claimed, delivery.ToEntity(webhook))
+	}
+
+	// the update returns rows in no particular order
+	sort.SliceStable(claimed, func(i, j int) bool {
+		return claimed[i].ID < claimed[j].ID
+	})
+
+	return claimed, nil
+}
+
+// RecordWebhookDeliveryAttempt keeps the webhook failure count in the same transaction as the attempt,
+// disabling the webhook as soon as it reaches the limit.
+func (g *webhookDatabase) RecordWebhookDeliveryAttempt(ctx context.Context, outcome ports.WebhookDeliveryOutcome) error {
+	attempt := models.NewWebhookDeliveryAttemptModel(outcome.Attempt)
+
+	return g.Client.DB.RunInTx(ctx, nil, func(ctx context.Context, tx bun.Tx) error {
+		delivery := tx.NewUpdate().Model((*models.WebhookDelivery)(nil)).
+			Where("? = ?", bun.Ident("id"), attempt.DeliveryID)
+
+		switch {
+		case attempt.Succeeded:
+			delivery.
+				Set("? = ?", bun.Ident("status"), models.WebhookDeliveryStatusDelivered).
+				Set("? = current_timestamp", bun.Ident("delivered_at"))
+		case outcome.RetryIn > 0:
+			delivery.
+				Set("? = current_timestamp + make_interval(secs => ?)", bun.Ident("next_attempt_at"), outcome.RetryIn.Seconds())
+		default:
+			delivery.
+				Set("? = ?", bun.Ident("status"), models.WebhookDeliveryStatusFailed)
+		}
+
+		result, err := delivery.Exec(ctx)
+		if err != nil {
+			return newUpdateError(models.WebhookDeliveriesTableName, translateError(err, domain.ErrWebhookDeliveryDoesNotExist))
+		}
+
+		rowsAffected, err := result.RowsAffected()
+		if err != nil {
+			return newUpdateError(models.WebhookDeliveriesTableName, err)
+		}
+
+		if rowsAffected == 0 {
+			return newNoRowsError(models.WebhookDeliveriesTableName, domain.ErrWebhookDeliveryDoesNotExist)
+		}
+
+		_, err = tx.NewInsert().Model(attempt).Exec(ctx)
+		if err != nil {
+			return newInsertError(models.WebhookDeliveryAttemptsTableName, translateError(err, domain.ErrWebhookDeliveryDoesNotExist))
+		}
+
+		webhook := tx.NewUpdate().Model((*models.Webhook)(nil)).
+			Where("? = ?", bun.Ident("id"), attempt.WebhookID)
+
+		if attempt.Succeeded {
+			webhook.Set("? = 0", bun.Ident("consecutive_failures"))
+		} else {
+			webhook.Set("? = ? + 1", bun.Ident("consecutive_failures"), bun.Ident("consecutive_failures"))
+
+			// the right hand sides see the row as it was before the update
+			if outcome.DisableAfter > 0 {
+				webhook.
+					Set("? = ? AND ? + 1 < ?", bun.Ident("enabled"), bun.Ident("enabled"), bun.Ident("consecutive_failures"), outcome.DisableAfter).
+					Set("? = CASE WHEN ? AND ? + 1 >= ? THEN current_timestamp ELSE ? END",
+						bun.Ident("disabled_at"), bun.Ident("enabled"), bun.Ident("consecutive_failures"), outcome.DisableAfter, bun.Ident("disabled_at"))
+			}
+		}
+
+		_, err = webhook.Exec(ctx)
+		if err != nil {
+			return newUpdateError(models.WebhooksTableName, translateError(err, domain.ErrWebhookDoesNotExist))
+		}
+
+		return nil
+	})
+}
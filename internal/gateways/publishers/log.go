@@ -0,0 +1,48 @@
+package publishers
+
+import (
+	"context"
+	"encoding/json"
+
+	"github.com/lyracampos/go-clean-architecture/internal/domain"
+	"github.com/lyracampos/go-clean-architecture/internal/domain/ports"
+	"go.uber.org/zap"
+)
+
+var _ ports.EventPublisher = (*logPublisher)(nil)
+
+type logPublisher struct {
+	log *zap.SugaredLogger
+}
+
+// NewLogPublisher writes the events to the log, it is useful
+// during development and while no other system consumes them.
+func NewLogPublisher(log *zap.SugaredLogger) *logPublisher {
+	return &logPublisher{
+		log: log,
+	}
+}
+
+func (p *logPublisher) Publish(_ context.Context, event domain.Event) error {
+	payload, ok := event.Payload.(json.RawMessage)
+	if !ok {
+		encoded, err := json.Marshal(event.Payload)
+		if err != nil {
+			return err
+		}
+
+		payload = encoded
+	}
+
+	p.log.Infow("event published",
+		"event_id", event.ID,
+		"event_type", event.Type,
+		"aggregate_type", event.AggregateType,
+		"aggregate_id", event.AggregateID,
+		"schema_version", event.SchemaVersion,
+		"occurred_at", event.OccurredAt,
+		"payload", string(payload),
+	)
+
+	return nil
+}
@@ -0,0 +1,63 @@
+package publishers
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"github.com/lyracampos/go-clean-architecture/internal/domain"
+	"github.com/lyracampos/go-clean-architecture/internal/domain/ports"
+)
+
+var _ ports.EventPublisher = (*webhookPublisher)(nil)
+
+type webhookPublisher struct {
+	webhookDatabase ports.WebhookDatabaseGateway
+}
+
+// NewWebhookPublisher enqueues the events for the webhooks subscribed to them,
+// the worker posts them afterwards with their own retry schedule.
+func NewWebhookPublisher(webhookDatabase ports.WebhookDatabaseGateway) *webhookPublisher {
+	return &webhookPublisher{
+		webhookDatabase: webhookDatabase,
+	}
+}
+
+// webhookPayload is the body posted to the webhooks.
+type webhookPayload struct {
+	ID            string          `json:"id"`
+	Type          string          `json:"type"`
+	AggregateType string          `json:"aggregate_type"`
+	AggregateID   string          `json:"aggregate_id"`
+	SchemaVersion int             `json:"schema_version"`
+	OccurredAt    time.Time       `json:"occurred_at"`
+	Data          json.RawMessage `json:"data"`
+}
+
+func (p *webhookPublisher) Publish(ctx context.Context, event domain.Event) error {
+	data, ok := event.Payload.(json.RawMessage)
+	if !ok {
+		encoded, err := json.Marshal(event.Payload)
+		if err != nil {
+			return fmt.Errorf("%w: %s", domain.ErrEventRejected, err)
+		}
+
+		data = encoded
+	}
+
+	payload, err := json.Marshal(webhookPayload{
+		ID:            event.ID,
+		Type:          string(event.Type),
+		AggregateType: event.AggregateType,
+		AggregateID:   event.AggregateID,
+		SchemaVersion: event.SchemaVersion,
+		OccurredAt:    event.OccurredAt,
+		Data:          data,
+	})
+	if err != nil {
+		return fmt.Errorf("%w: %s", domain.ErrEventRejected, err)
+	}
+
+	return p.webhookDatabase.EnqueueWebhookDeliveries(ctx, event.ID, string(event.Type), payload)
+}
@@ -0,0 +1,75 @@
+package tokens
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"fmt"
+	"strings"
+
+	"github.com/lyracampos/go-clean-architecture/internal/domain"
+	"github.com/lyracampos/go-clean-architecture/internal/domain/ports"
+)
+
+var _ ports.APIKeyService = (*apiKeyService)(nil)
+
+const (
+	// apiKeyTag makes the keys easy to recognize, such as by secret scanners.
+	apiKeyTag = "gca"
+	// apiKeyPrefixSize is the number of random bytes of the lookup prefix.
+	apiKeyPrefixSize = 6
+	// apiKeySecretSize is the number of random bytes of the secret part of the key.
+	apiKeySecretSize = 32
+)
+
+type apiKeyService struct{}
+
+func NewAPIKeyService() *apiKeyService {
+	return &apiKeyService{}
+}
+
+// IssueAPIKey generates a key formatted as gca_{prefix}_{secret}.
+func (s *apiKeyService) IssueAPIKey(_ context.Context) (ports.APIKey, error) {
+	prefix := make([]byte, apiKeyPrefixSize)
+	if _, err := rand.Read(prefix); err != nil {
+		return ports.APIKey{}, fmt.Errorf("failed to generate api key prefix: %w", err)
+	}
+
+	secret := make([]byte, apiKeySecretSize)
+	if _, err := rand.Read(secret); err != nil {
+		return ports.APIKey{}, fmt.Errorf("failed to generate api key secret: %w", err)
+	}
+
+	encodedPrefix := hex.EncodeToString(prefix)
+	key := fmt.Sprintf("%s_%s_%s", apiKeyTag, encodedPrefix, base64.RawURLEncoding.EncodeToString(secret))
+
+	return ports.APIKey{
+		Key:    key,
+		Prefix: encodedPrefix,
+		Hash:   hashAPIKey(key),
+	}, nil
+}
+
+func (s *apiKeyService) ParseAPIKey(key string) (string, string, error) {
+	tag, rest, ok := strings.Cut(key, "_")
+	if !ok || tag != apiKeyTag {
+		return "", "", domain.ErrInvalidAPIKey
+	}
+
+	prefix, secret, ok := strings.Cut(rest, "_")
+	if !ok || len(prefix) != hex.EncodedLen(apiKeyPrefixSize) || secret == "" {
+		return "", "", domain.ErrInvalidAPIKey
+	}
+
+	return prefix, hashAPIKey(key), nil
+}
+
+// hashAPIKey returns the SHA-256 of the key, as for refresh tokens
+// the key has enough entropy to not need a slow hash.
+func hashAPIKey(key string) string {
+	sum := sha256.Sum256([]byte(key))
+
+	return hex.EncodeToString(sum[:])
+}
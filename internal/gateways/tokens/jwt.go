@@ -0,0 +1,137 @@
+package tokens
+
+import (
+	"context"
+	"crypto"
+	"errors"
+	"fmt"
+	"strconv"
+	"time"
+
+	"github.com/golang-jwt/jwt/v5"
+	"github.com/lyracampos/go-clean-architecture/config"
+	"github.com/lyracampos/go-clean-architecture/internal/domain"
+	"github.com/lyracampos/go-clean-architecture/internal/domain/entities"
+	"github.com/lyracampos/go-clean-architecture/internal/domain/ports"
+)
+
+var _ ports.TokenService = (*jwtService)(nil)
+
+// Signing methods supported for the access tokens.
+const (
+	SigningMethodHS256 = "HS256"
+	SigningMethodEdDSA = "EdDSA"
+)
+
+var (
+	errMissingSigningKey        = errors.New("auth signing key must not be empty")
+	errUnsupportedSigningMethod = errors.New("auth signing method must be one of [HS256, EdDSA]")
+)
+
+// AccessClaims are the claims of the access tokens, the subject is the user ID.
+type AccessClaims struct {
+	Role string `json:"role"`
+	jwt.RegisteredClaims
+}
+
+type jwtService struct {
+	issuer          string
+	method          jwt.SigningMethod
+	signingKey      interface{}
+	verifyingKey    interface{}
+	accessTokenTTL  time.Duration
+	refreshTokenTTL time.Duration
+	now             func() time.Time
+}
+
+// NewJWTService instantiates a token service signing JSON Web Tokens either with
+// an HMAC-SHA256 secret or with an Ed25519 private key, as configured.
+func NewJWTService(config *config.Config) (*jwtService, error) {
+	service := &jwtService{
+		issuer:          config.App.Name,
+		accessTokenTTL:  config.Auth.AccessTokenTTL,
+		refreshTokenTTL: config.Auth.RefreshTokenTTL,
+		now:             time.Now,
+	}
+
+	switch config.Auth.SigningMethod {
+	case "", SigningMethodHS256:
+		if config.Auth.SigningKey == "" {
+			return nil, errMissingSigningKey
+		}
+
+		service.method = jwt.SigningMethodHS256
+		service.signingKey = []byte(config.Auth.SigningKey)
+		service.verifyingKey = service.signingKey
+	case SigningMethodEdDSA:
+		if config.Auth.Ed25519PrivateKey == "" {
+			return nil, errMissingSigningKey
+		}
+
+		privateKey, err := jwt.ParseEdPrivateKeyFromPEM([]byte(config.Auth.Ed25519PrivateKey))
+		if err != nil {
+			return nil, fmt.Errorf("failed to parse Ed25519 private key: %w", err)
+		}
+
+		service.method = jwt.SigningMethodEdDSA
+		service.signingKey = privateKey
+		service.verifyingKey = privateKey.(crypto.Signer).Public()
+	default:
+		return nil, fmt.Errorf("%w, got %q", errUnsupportedSigningMethod, config.Auth.SigningMethod)
+	}
+
+	return service, nil
+}
+
+func (s *jwtService) IssueAccessToken(_ context.Context, user *entities.User) (ports.AccessToken, error) {
+	now := s.now()
+	expiresAt := now.Add(s.accessTokenTTL)
+
+	claims := AccessClaims{
+		Role: user.Role,
+		RegisteredClaims: jwt.RegisteredClaims{
+			Issuer:    s.issuer,
+			Subject:   strconv.FormatInt(user.ID, 10),
+			IssuedAt:  jwt.NewNumericDate(now),
+			NotBefore: jwt.NewNumericDate(now),
+			ExpiresAt: jwt.NewNumericDate(expiresAt),
+		},
+	}
+
+	token, err := jwt.NewWithClaims(s.method, claims).SignedString(s.signingKey)
+	if err != nil {
+		return ports.AccessToken{}, fmt.Errorf("failed to sign access token: %w", err)
+	}
+
+	return ports.AccessToken{
+		Token:     token,
+		ExpiresAt: expiresAt,
+	}, nil
+}
+
+func (s *jwtService) ParseAccessToken(_ context.Context, token string) (domain.Caller, error) {
+	claims := AccessClaims{}
+
+	_, err := jwt.ParseWithClaims(token, &claims,
+		func(*jwt.Token) (interface{}, error) {
+			return s.verifyingKey, nil
+		},
+		jwt.WithValidMethods([]string{s.method.Alg()}),
+		jwt.WithIssuer(s.issuer),
+		jwt.WithExpirationRequired(),
+		jwt.WithTimeFunc(s.now),
+	)
+	if err != nil {
+		return domain.Caller{}, fmt.Errorf("%w: %v", domain.ErrInvalidAccessToken, err)
+	}
+
+	userID, err := strconv.ParseInt(claims.Subject, 10, 64)
+	if err != nil {
+		return domain.Caller{}, fmt.Errorf("%w: invalid subject %q", domain.ErrInvalidAccessToken, claims.Subject)
+	}
+
+	return domain.Caller{
+		UserID: userID,
+		Role:   claims.Role,
+	}, nil
+}
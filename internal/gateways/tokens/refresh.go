@@ -0,0 +1,39 @@
+package tokens
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"fmt"
+
+	"github.com/lyracampos/go-clean-architecture/internal/domain/ports"
+)
+
+// refreshTokenSize is the number of random bytes of a refresh token.
+const refreshTokenSize = 32
+
+// IssueRefreshToken generates an opaque random token, only its hash should be stored.
+func (s *jwtService) IssueRefreshToken(_ context.Context) (ports.RefreshToken, error) {
+	random := make([]byte, refreshTokenSize)
+	if _, err := rand.Read(random); err != nil {
+		return ports.RefreshToken{}, fmt.Errorf("failed to generate refresh token: %w", err)
+	}
+
+	token := base64.RawURLEncoding.EncodeToString(random)
+
+	return ports.RefreshToken{
+		Token:     token,
+		Hash:      s.HashRefreshToken(token),
+		ExpiresAt: s.now().Add(s.refreshTokenTTL),
+	}, nil
+}
+
+// HashRefreshToken returns the SHA-256 of the token, a slow hash is not needed
+// as the token has enough entropy to not be guessed.
+func (s *jwtService) HashRefreshToken(token string) string {
+	sum := sha256.Sum256([]byte(token))
+
+	return hex.EncodeToString(sum[:])
+}
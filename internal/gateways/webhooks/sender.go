@@ -0,0 +1,71 @@
+package webhooks
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/lyracampos/go-clean-architecture/internal/domain/entities"
+	"github.com/lyracampos/go-clean-architecture/internal/domain/ports"
+)
+
+var _ ports.WebhookSender = (*httpSender)(nil)
+
+const (
+	userAgent = "go-clean-architecture-webhooks/1"
+	// maxResponseBody is how much of the response is read, so that the connection can be reused.
+	maxResponseBody = 64 << 10
+)
+
+type httpSender struct {
+	client *http.Client
+	now    func() time.Time
+}
+
+// NewHTTPSender posts the deliveries, giving up on receivers slower than timeout.
+func NewHTTPSender(timeout time.Duration) *httpSender {
+	return &httpSender{
+		client: &http.Client{
+			Timeout: timeout,
+			// a redirect would be followed without the signature headers
+			CheckRedirect: func(*http.Request, []*http.Request) error {
+				return http.ErrUseLastResponse
+			},
+		},
+		now: time.Now,
+	}
+}
+
+func (s *httpSender) Send(ctx context.Context, delivery *entities.WebhookDelivery) (int, error) {
+	request, err := http.NewRequestWithContext(ctx, http.MethodPost, delivery.URL, bytes.NewReader(delivery.Payload))
+	if err != nil {
+		return 0, fmt.Errorf("failed to build webhook request: %w", err)
+	}
+
+	timestamp := s.now().Unix()
+
+	request.Header.Set("Content-Type", "application/json")
+	request.Header.Set("User-Agent", userAgent)
+	request.Header.Set(EventIDHeader, delivery.EventID)
+	request.Header.Set(EventTypeHeader, delivery.EventType)
+	request.Header.Set(SignatureTimestampHeader, strconv.FormatInt(timestamp, 10))
+	request.Header.Set(SignatureHeader, Sign(delivery.Secret, timestamp, delivery.Payload))
+
+	response, err := s.client.Do(request)
+	if err != nil {
+		return 0, fmt.Errorf("failed to post webhook: %w", err)
+	}
+	defer response.Body.Close()
+
+	_, _ = io.Copy(io.Discard, io.LimitReader(response.Body, maxResponseBody))
+
+	if response.StatusCode < http.StatusOK || response.StatusCode >= http.StatusMultipleChoices {
+		return response.StatusCode, fmt.Errorf("webhook answered with status %d", response.StatusCode)
+	}
+
+	return response.StatusCode, nil
+}
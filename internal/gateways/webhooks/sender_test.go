@@ -0,0 +1,152 @@
+package webhooks
+
+import (
+	"context"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/lyracampos/go-clean-architecture/internal/domain/entities"
+)
+
+func TestHTTPSenderSend(t *testing.T) {
+	now := time.Date(2024, 2, 1, 0, 0, 0, 0, time.UTC)
+	secret := "0123456789abcdef"
+	payload := []byte(`{"id":"event","type":"user.created"}`)
+
+	tests := []struct {
+		name           string
+		status         int
+		wantStatusCode int
+		wantErr        bool
+		err            error
+	}{
+		{
+			name:           "success posting signed delivery",
+			status:         http.StatusNoContent,
+			wantStatusCode: http.StatusNoContent,
+		},
+		{
+			name:           "fail posting delivery when receiver answers with an error",
+			status:         http.StatusInternalServerError,
+			wantStatusCode: http.StatusInternalServerError,
+			wantErr:        true,
+			err:            errors.New("webhook answered with status 500"),
+		},
+		{
+			name:           "fail posting delivery when receiver redirects",
+			status:         http.StatusFound,
+			wantStatusCode: http.StatusFound,
+			wantErr:        true,
+			err:            errors.New("webhook answered with status 302"),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var verifyErr error
+
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				body, _ := io.ReadAll(r.Body)
+				verifyErr = Verify(secret, r.Header.Get(SignatureTimestampHeader), r.Header.Get(SignatureHeader), body, 5*time.Minute, now)
+
+				if r.Header.Get(EventIDHeader) != "event" || r.Header.Get(EventTypeHeader) != "user.created" {
+					t.Errorf("unexpected event headers %v", r.Header)
+				}
+
+				if tt.status == http.StatusFound {
+					w.Header().Set("Location", "/elsewhere")
+				}
+				w.WriteHeader(tt.status)
+			}))
+			defer server.Close()
+
+			sender := NewHTTPSender(time.Second)
+			sender.now = func() time.Time { return now }
+
+			statusCode, err := sender.Send(context.Background(), &entities.WebhookDelivery{
+				URL:       server.URL,
+				Secret:    secret,
+				EventID:   "event",
+				EventType: "user.created",
+				Payload:   payload,
+			})
+
+			if verifyErr != nil {
+				t.Errorf("Verify() error = %v", verifyErr)
+			}
+			if statusCode != tt.wantStatusCode {
+				t.Errorf("httpSender.Send() = %v, want %v", statusCode, tt.wantStatusCode)
+			}
+			if (err != nil) != tt.wantErr {
+				t.Errorf("httpSender.Send() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if tt.wantErr {
+				if err.Error() != tt.err.Error() {
+					t.Errorf("httpSender.Send() error = %v, wantErr %v", err, tt.err)
+					return
+				}
+			}
+		})
+	}
+}
+
+func TestVerify(t *testing.T) {
+	now := time.Date(2024, 2, 1, 0, 0, 0, 0, time.UTC)
+	body := []byte(`{"id":"event"}`)
+	signature := Sign("0123456789abcdef", now.Unix(), body)
+
+	tests := []struct {
+		name      string
+		secret    string
+		timestamp string
+		signature string
+		now       time.Time
+		err       error
+	}{
+		{
+			name:      "success verifying signature",
+			secret:    "0123456789abcdef",
+			timestamp: "1706745600",
+			signature: signature,
+			now:       now.Add(time.Minute),
+		},
+		{
+			name:      "fail verifying signature with another secret",
+			secret:    "fedcba9876543210",
+			timestamp: "1706745600",
+			signature: signature,
+			now:       now,
+			err:       ErrInvalidSignature,
+		},
+		{
+			name:      "fail verifying signature with tampered timestamp",
+			secret:    "0123456789abcdef",
+			timestamp: "1706745601",
+			signature: signature,
+			now:       now,
+			err:       ErrInvalidSignature,
+		},
+		{
+			name:      "fail verifying replayed signature",
+			secret:    "0123456789abcdef",
+			timestamp: "1706745600",
+			signature: signature,
+			now:       now.Add(time.Hour),
+			err:       ErrSignatureExpired,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := Verify(tt.secret, tt.timestamp, tt.signature, body, 5*time.Minute, tt.now)
+			if !errors.Is(err, tt.err) {
+				t.Errorf("Verify() error = %v, want %v", err, tt.err)
+			}
+		})
+	}
+}
@@ -0,0 +1,74 @@
+package logging
+
+import (
+	"context"
+	"sync"
+
+	"go.uber.org/zap"
+)
+
+type (
+	loggerContextKey    struct{}
+	accessLogContextKey struct{}
+)
+
+// accessLog gathers the fields of the access log line written once the request is answered.
+// It is shared by every copy of the request context, so that the fields known only down the
+// handler chain, such as the authenticated user, make it to the line written up the chain.
+type accessLog struct {
+	mu     sync.Mutex
+	fields []interface{}
+}
+
+// WithLogger returns a copy of the context carrying the logger.
+func WithLogger(ctx context.Context, log *zap.SugaredLogger) context.Context {
+	return context.WithValue(ctx, loggerContextKey{}, log)
+}
+
+// FromContext returns the logger carried by the context, or fallback when there is none.
+func FromContext(ctx context.Context, fallback *zap.SugaredLogger) *zap.SugaredLogger {
+	if log, ok := ctx.Value(loggerContextKey{}).(*zap.SugaredLogger); ok {
+		return log
+	}
+
+	return fallback
+}
+
+// With returns a copy of the context whose logger has the key-value pairs added,
+// the context is returned untouched when it carries no logger.
+func With(ctx context.Context, args ...interface{}) context.Context {
+	if log, ok := ctx.Value(loggerContextKey{}).(*zap.SugaredLogger); ok {
+		return WithLogger(ctx, log.With(args...))
+	}
+
+	return ctx
+}
+
+// WithAccessLog returns a copy of the context gathering the fields of the request access log line.
+func WithAccessLog(ctx context.Context) context.Context {
+	return context.WithValue(ctx, accessLogContextKey{}, &accessLog{})
+}
+
+// AnnotateAccessLog adds the key-value pairs to the access log line of the request,
+// it does nothing when the context gathers none.
+func AnnotateAccessLog(ctx context.Context, args ...interface{}) {
+	if access, ok := ctx.Value(accessLogContextKey{}).(*accessLog); ok {
+		access.mu.Lock()
+		defer access.mu.Unlock()
+
+		access.fields = append(access.fields, args...)
+	}
+}
+
+// AccessLogFields returns the key-value pairs added to the access log line of the request.
+func AccessLogFields(ctx context.Context) []interface{} {
+	access, ok := ctx.Value(accessLogContextKey{}).(*accessLog)
+	if !ok {
+		return nil
+	}
+
+	access.mu.Lock()
+	defer access.mu.Unlock()
+
+	return append([]interface{}(nil), access.fields...)
+}
@@ -12,6 +12,19 @@
 //
 //	Produces:
 //	- application/json
+//	- application/problem+json
+//
+//	SecurityDefinitions:
+//	bearer:
+//	  type: apiKey
+//	  name: Authorization
+//	  in: header
+//	  description: access token returned by the login, as "Bearer {token}"
+//	apiKey:
+//	  type: apiKey
+//	  name: Authorization
+//	  in: header
+//	  description: key returned when creating an API key, as "ApiKey {key}"
 //
 // swagger:meta
 package docs
@@ -21,7 +34,7 @@ import (
 	"github.com/lyracampos/go-clean-architecture/internal/domain/usecases"
 )
 
-// Internal server error message returned as a string
+// Internal server error problem details
 // swagger:response internalServerErrorResponse
 type internalServerErrorResponseWrapper struct {
 	// error description
@@ -29,7 +42,7 @@ type internalServerErrorResponseWrapper struct {
 	Body MessageError
 }
 
-// Not found message error returned as string
+// Not found problem details
 // swagger:response notFoundResponse
 type errorNotFoundResponseWrapper struct {
 	// error description
@@ -37,7 +50,7 @@ type errorNotFoundResponseWrapper struct {
 	Body MessageError
 }
 
-// BadRequest message error returned as string
+// BadRequest problem details
 // swagger:response badRequestResponse
 type badRequestResponseWrapper struct {
 	// error description
@@ -45,7 +58,97 @@ type badRequestResponseWrapper struct {
 	Body MessageError
 }
 
+// Conflict problem details
+// swagger:response conflictResponse
+type conflictResponseWrapper struct {
+	// error description
+	// in: body
+	Body MessageError
+}
+
+// Precondition failed problem details, the If-Match ETag is stale
+// swagger:response preconditionFailedResponse
+type preconditionFailedResponseWrapper struct {
+	// error description
+	// in: body
+	Body MessageError
+}
+
+// Precondition required problem details, the If-Match header is missing
+// swagger:response preconditionRequiredResponse
+type preconditionRequiredResponseWrapper struct {
+	// error description
+	// in: body
+	Body MessageError
+}
+
+// Unprocessable entity problem details, the request breaks a storage constraint
+// swagger:response unprocessableEntityResponse
+type unprocessableEntityResponseWrapper struct {
+	// error description
+	// in: body
+	Body MessageError
+}
+
+// Service unavailable problem details, the request may succeed if retried
+// swagger:response serviceUnavailableResponse
+type serviceUnavailableResponseWrapper struct {
+	// error description
+	// in: body
+	Body MessageError
+}
+
+// Gateway timeout problem details, the request took longer than its route timeout
+// swagger:response gatewayTimeoutResponse
+type gatewayTimeoutResponseWrapper struct {
+	// error description
+	// in: body
+	Body MessageError
+}
+
+// Unauthorized problem details, the credentials or tokens are invalid
+// swagger:response unauthorizedResponse
+type unauthorizedResponseWrapper struct {
+	// error description
+	// in: body
+	Body MessageError
+}
+
+// Forbidden problem details, the caller's role does not allow the operation
+// swagger:response forbiddenResponse
+type forbiddenResponseWrapper struct {
+	// error description
+	// in: body
+	Body MessageError
+}
+
+// MessageError is the RFC 7807 problem details body of every error response
 type MessageError struct {
+	// URI reference identifying the problem type
+	Type string `json:"type"`
+	// short summary of the problem type
+	Title string `json:"title"`
+	// HTTP status code
+	Status int `json:"status"`
+	// explanation specific to this occurrence of the problem
+	Detail string `json:"detail,omitempty"`
+	// URI reference of the request that originated the problem
+	Instance string `json:"instance,omitempty"`
+	// ID of the trace of the request, to find its logs and spans
+	TraceID string `json:"trace_id,omitempty"`
+	// one entry per invalid field, only for validation errors
+	Errors []MessageFieldError `json:"errors,omitempty"`
+}
+
+// MessageFieldError describes why a single field failed validation
+type MessageFieldError struct {
+	// field name
+	Field string `json:"field"`
+	// validation rule that failed
+	Tag string `json:"tag"`
+	// parameter of the validation rule
+	Param string `json:"param,omitempty"`
+	// human readable message
 	Message string `json:"message"`
 }
 
@@ -56,12 +159,39 @@ type userGetResponseWrapper struct {
 	Body entities.User
 }
 
-// Data structure representing a list of user
+// Data structure representing a page of users
 // swagger:response userListResponse
 type userListResponseWrapper struct {
-	// Newly created product
 	// in: body
-	Body []entities.User
+	Body usecases.ListUserOutput
+}
+
+// swagger:parameters ListUsers
+type userListParametersWrapper struct {
+	// filter users by first name, case-insensitive
+	// in: query
+	FirstName string `json:"first_name"`
+	// filter users by last name, case-insensitive
+	// in: query
+	LastName string `json:"last_name"`
+	// filter users by email, case-insensitive
+	// in: query
+	Email string `json:"email"`
+	// filter users by role
+	// in: query
+	Role string `json:"role"`
+	// page size, between 1 and 100, defaults to 50
+	// in: query
+	Limit int `json:"limit"`
+	// NextCursor returned by the previous page
+	// in: query
+	Cursor string `json:"cursor"`
+	// free text searched case-insensitively across names and email, results are ranked by similarity
+	// in: query
+	Query string `json:"q"`
+	// field users are ordered by [created_at, first_name, last_name, email, relevance], prefixed with '-' for descending order
+	// in: query
+	Sort string `json:"sort"`
 }
 
 // Data structure representing user added
@@ -73,8 +203,203 @@ type userAddResponseWrapper struct {
 
 // swagger:parameters AddUser
 type userAddCommandWrapper struct {
+	// Unique key making the request safe to retry, the first response is returned again for the same key
+	// in: header
+	IdempotencyKey string `json:"Idempotency-Key"`
 	// Payload to add new user in application
 	// in: body
 	// required: true
 	Body usecases.CreateUserInput
 }
+
+// Data structure representing user updated
+// swagger:response userUpdateResponse
+type userUpdateResponseWrapper struct {
+	// in: body
+	Body usecases.UpdateUserOutput
+}
+
+// swagger:parameters UpdateUser
+type userUpdateCommandWrapper struct {
+	// Payload to replace all fields of an user
+	// in: body
+	// required: true
+	Body usecases.CreateUserInput
+}
+
+// swagger:parameters PatchUser
+type userPatchCommandWrapper struct {
+	// Payload with the user fields to be changed
+	// in: body
+	// required: true
+	Body usecases.UpdateUserInput
+}
+
+// swagger:parameters GetUser UpdateUser PatchUser DeleteUser RestoreUser SetPassword
+type userIDParameterWrapper struct {
+	// user identifier
+	// in: path
+	// required: true
+	ID int64 `json:"id"`
+}
+
+// swagger:parameters ListUsers GetUser
+type userIncludeDeletedParameterWrapper struct {
+	// include soft deleted users in the result
+	// in: query
+	IncludeDeleted bool `json:"include_deleted"`
+}
+
+// Data structure representing user restored
+// swagger:response userRestoreResponse
+type userRestoreResponseWrapper struct {
+	// in: body
+	Body usecases.RestoreUserOutput
+}
+
+// No content is returned
+// swagger:response noContentResponse
+type noContentResponseWrapper struct{}
+
+// swagger:parameters UpdateUser PatchUser DeleteUser RestoreUser
+type userIfMatchParameterWrapper struct {
+	// user ETags returned by the last reads or writes, separated by commas, or * to change the user whatever its version
+	// in: header
+	// required: true
+	IfMatch string `json:"If-Match"`
+}
+
+// Data structure representing the access token of an authenticated user
+// swagger:response loginResponse
+type loginResponseWrapper struct {
+	// in: body
+	Body usecases.LoginOutput
+}
+
+// swagger:parameters Login
+type loginCommandWrapper struct {
+	// Payload with the user credentials
+	// in: body
+	// required: true
+	Body usecases.LoginInput
+}
+
+// Data structure representing the new tokens of an authenticated user
+// swagger:response refreshTokenResponse
+type refreshTokenResponseWrapper struct {
+	// in: body
+	Body usecases.RefreshTokenOutput
+}
+
+// swagger:parameters RefreshToken
+type refreshTokenCommandWrapper struct {
+	// Payload with the refresh token
+	// in: body
+	// required: true
+	Body usecases.RefreshTokenInput
+}
+
+// swagger:parameters SetPassword
+type setPasswordCommandWrapper struct {
+	// Payload with the new user password
+	// in: body
+	// required: true
+	Body usecases.SetPasswordInput
+}
+
+// Data structure representing the created API key, the key is not returned again
+// swagger:response apiKeyCreateResponse
+type apiKeyCreateResponseWrapper struct {
+	// in: body
+	Body usecases.CreateAPIKeyOutput
+}
+
+// swagger:parameters CreateAPIKey
+type apiKeyCreateCommandWrapper struct {
+	// Payload with the API key name, scopes and expiry
+	// in: body
+	// required: true
+	Body usecases.CreateAPIKeyInput
+}
+
+// Data structure representing the API keys
+// swagger:response apiKeyListResponse
+type apiKeyListResponseWrapper struct {
+	// in: body
+	Body usecases.ListAPIKeysOutput
+}
+
+// Data structure representing the status of the config reloaded at runtime
+// swagger:response configStatusResponse
+type configStatusResponseWrapper struct {
+	// in: body
+	Body usecases.GetConfigStatusOutput
+}
+
+// swagger:parameters DeleteAPIKey
+type apiKeyIDParameterWrapper struct {
+	// API key identifier
+	// in: path
+	// required: true
+	ID int64 `json:"id"`
+}
+
+// Data structure representing a webhook, its secret is never returned
+// swagger:response webhookResponse
+type webhookResponseWrapper struct {
+	// in: body
+	Body usecases.WebhookOutput
+}
+
+// swagger:parameters CreateWebhook
+type webhookCreateCommandWrapper struct {
+	// Payload with the webhook URL, event types and secret
+	// in: body
+	// required: true
+	Body usecases.CreateWebhookInput
+}
+
+// swagger:parameters UpdateWebhook
+type webhookUpdateCommandWrapper struct {
+	// Webhook identifier
+	// in: path
+	// required: true
+	ID int64 `json:"id"`
+	// Payload with the webhook URL, event types, secret and whether it is enabled
+	// in: body
+	// required: true
+	Body usecases.UpdateWebhookInput
+}
+
+// Data structure representing the webhooks
+// swagger:response webhookListResponse
+type webhookListResponseWrapper struct {
+	// in: body
+	Body usecases.ListWebhooksOutput
+}
+
+// swagger:parameters GetWebhook DeleteWebhook
+type webhookIDParameterWrapper struct {
+	// Webhook identifier
+	// in: path
+	// required: true
+	ID int64 `json:"id"`
+}
+
+// swagger:parameters ListWebhookDeliveries
+type webhookDeliveriesParameterWrapper struct {
+	// Webhook identifier
+	// in: path
+	// required: true
+	ID int64 `json:"id"`
+	// Number of attempts returned, between 1 and 100, defaults to 50
+	// in: query
+	Limit int `json:"limit"`
+}
+
+// Data structure representing the delivery attempts of a webhook
+// swagger:response webhookDeliveryListResponse
+type webhookDeliveryListResponseWrapper struct {
+	// in: body
+	Body usecases.ListWebhookDeliveriesOutput
+}
@@ -5,4 +5,37 @@ import "errors"
 var (
 	ErrUserDoesNotExist  = errors.New("no user was found for this ID. Please check the ID and try again")
 	ErrEmailAlreadyInUse = errors.New("email in use by another user")
+
+	ErrInvalidCredentials  = errors.New("the email or password is invalid")
+	ErrInvalidRefreshToken = errors.New("the refresh token is invalid or expired. Please log in again")
+	ErrUnauthorized        = errors.New("a valid bearer access token or api key is required for this operation")
+	ErrForbidden           = errors.New("your role does not allow this operation on this user")
+
+	ErrAPIKeyDoesNotExist  = errors.New("no api key was found for this ID. Please check the ID and try again")
+	ErrWebhookDoesNotExist = errors.New("no webhook was found for this ID. Please check the ID and try again")
+
+	ErrInvalidIdempotencyKey    = errors.New("the Idempotency-Key header must have at most 255 characters")
+	ErrIdempotencyKeyReused     = errors.New("the Idempotency-Key was already used with another request. Please use a new key")
+	ErrIdempotencyKeyInProgress = errors.New("a request with this Idempotency-Key is still in progress. Please retry later")
+
+	ErrPreconditionRequired = errors.New("the If-Match header with the user ETag is required for this operation")
+	ErrInvalidIfMatch       = errors.New(`the If-Match header must be * or a list of user ETags, such as "3", "4"`)
+	ErrVersionConflict      = errors.New("the user was changed by another request. Please fetch it again and retry")
+
+	ErrInvalidLimit  = errors.New("the limit must be a number between 1 and 100")
+	ErrInvalidCursor = errors.New("the cursor is invalid for this listing. Please start again from the first page")
+
+	ErrUniqueViolation      = errors.New("the request conflicts with an existing record")
+	ErrForeignKeyViolation  = errors.New("the request references a record that does not exist")
+	ErrCheckViolation       = errors.New("the request has a value that is not allowed")
+	ErrNotNullViolation     = errors.New("the request is missing a required value")
+	ErrSerializationFailure = errors.New("the request conflicted with a concurrent request. Please try again")
+	ErrDeadlock             = errors.New("the request could not be completed due to concurrent requests. Please try again")
+
+	ErrTimeout         = errors.New("the request took too long to complete. Please try again later")
+	ErrRequestCanceled = errors.New("the request was canceled before it completed")
+
+	ErrInvalidRequestBody = errors.New("the request body is not a valid JSON document")
+	ErrValidation         = errors.New("one or more fields are invalid. Please check the errors and try again")
+	ErrInternal           = errors.New("an unexpected error happened. Please try again later")
 )
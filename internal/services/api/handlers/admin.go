@@ -0,0 +1,58 @@
+package handlers
+
+import (
+	"encoding/json"
+	"net/http"
+
+	"github.com/lyracampos/go-clean-architecture/internal/domain/usecases"
+	"github.com/lyracampos/go-clean-architecture/internal/logging"
+	"go.uber.org/zap"
+)
+
+type adminHandler struct {
+	log                    *zap.SugaredLogger
+	getConfigStatusUseCase usecases.GetConfigStatusUseCase
+}
+
+func NewAdminHandler(log *zap.SugaredLogger, getConfigStatusUseCase usecases.GetConfigStatusUseCase) *adminHandler {
+	return &adminHandler{
+		log:                    log,
+		getConfigStatusUseCase: getConfigStatusUseCase,
+	}
+}
+
+// swagger:route GET /admin/config admin GetConfigStatus
+// Return the generation of the config reloaded at runtime, and why its last reload failed
+// security:
+//
+//	bearer:
+//	apiKey:
+//
+// responses:
+//
+//	200: configStatusResponse
+//	401: unauthorizedResponse
+//	403: forbiddenResponse
+//	501: internalServerErrorResponse
+//	504: gatewayTimeoutResponse
+func (h *adminHandler) GetConfigStatus(rw http.ResponseWriter, r *http.Request) {
+	log := logging.FromContext(r.Context(), h.log)
+	log.Info("adminHandler.GetConfigStatus - started")
+
+	ctx := r.Context()
+	rw.Header().Set("Content-type", "application/json")
+
+	statusResult, err := h.getConfigStatusUseCase.Execute(ctx)
+	if err != nil {
+		writeError(log, rw, r, err)
+		return
+	}
+
+	log.Info("adminHandler.GetConfigStatus - finished successfully")
+
+	rw.Header().Set("Cache-Control", "no-store")
+
+	if err := json.NewEncoder(rw).Encode(statusResult); err != nil {
+		log.Errorf("adminHandler.GetConfigStatus - encode failed: %v", err)
+	}
+}
@@ -0,0 +1,152 @@
+package handlers
+
+import (
+	"encoding/json"
+	"io"
+	"net/http"
+	"strconv"
+
+	"github.com/gorilla/mux"
+	"github.com/lyracampos/go-clean-architecture/internal/domain/usecases"
+	"github.com/lyracampos/go-clean-architecture/internal/logging"
+	"go.uber.org/zap"
+)
+
+type apiKeyHandler struct {
+	log           *zap.SugaredLogger
+	createUseCase usecases.CreateAPIKeyUseCase
+	listUseCase   usecases.ListAPIKeysUseCase
+	deleteUseCase usecases.DeleteAPIKeyUseCase
+}
+
+func NewAPIKeyHandler(
+	log *zap.SugaredLogger,
+	createUseCase usecases.CreateAPIKeyUseCase,
+	listUseCase usecases.ListAPIKeysUseCase,
+	deleteUseCase usecases.DeleteAPIKeyUseCase,
+) *apiKeyHandler {
+	return &apiKeyHandler{
+		log:           log,
+		createUseCase: createUseCase,
+		listUseCase:   listUseCase,
+		deleteUseCase: deleteUseCase,
+	}
+}
+
+// swagger:route POST /api-keys apiKeys CreateAPIKey
+// Create an API key for service callers, the key is only returned in this response
+// security:
+//
+//	bearer:
+//	apiKey:
+//
+// responses:
+//
+//	201: apiKeyCreateResponse
+//	400: badRequestResponse
+//	401: unauthorizedResponse
+//	403: forbiddenResponse
+//	501: internalServerErrorResponse
+//	504: gatewayTimeoutResponse
+func (h *apiKeyHandler) CreateAPIKey(rw http.ResponseWriter, r *http.Request) {
+	log := logging.FromContext(r.Context(), h.log)
+	log.Info("apiKeyHandler.CreateAPIKey - started")
+
+	ctx := r.Context()
+	rw.Header().Set("Content-type", "application/json")
+
+	requestBody, _ := io.ReadAll(r.Body)
+	var input usecases.CreateAPIKeyInput
+	if err := json.Unmarshal(requestBody, &input); err != nil {
+		writeError(log, rw, r, err)
+		return
+	}
+
+	createResult, err := h.createUseCase.Execute(ctx, input)
+	if err != nil {
+		writeError(log, rw, r, err)
+		return
+	}
+
+	log.Info("apiKeyHandler.CreateAPIKey - finished successfully")
+
+	rw.Header().Set("Cache-Control", "no-store")
+	rw.WriteHeader(http.StatusCreated)
+
+	if err := json.NewEncoder(rw).Encode(createResult); err != nil {
+		log.Errorf("apiKeyHandler.CreateAPIKey - encode failed: %v", err)
+	}
+}
+
+// swagger:route GET /api-keys apiKeys ListAPIKeys
+// Return the API keys, without the keys themselves
+// security:
+//
+//	bearer:
+//	apiKey:
+//
+// responses:
+//
+//	200: apiKeyListResponse
+//	401: unauthorizedResponse
+//	403: forbiddenResponse
+//	501: internalServerErrorResponse
+//	504: gatewayTimeoutResponse
+func (h *apiKeyHandler) ListAPIKeys(rw http.ResponseWriter, r *http.Request) {
+	log := logging.FromContext(r.Context(), h.log)
+	log.Info("apiKeyHandler.ListAPIKeys - started")
+
+	ctx := r.Context()
+	rw.Header().Set("Content-type", "application/json")
+
+	listResult, err := h.listUseCase.Execute(ctx)
+	if err != nil {
+		writeError(log, rw, r, err)
+		return
+	}
+
+	log.Info("apiKeyHandler.ListAPIKeys - finished successfully")
+
+	if err := json.NewEncoder(rw).Encode(listResult); err != nil {
+		log.Errorf("apiKeyHandler.ListAPIKeys - encode failed: %v", err)
+	}
+}
+
+// swagger:route DELETE /api-keys/{id} apiKeys DeleteAPIKey
+// Revoke an API key
+// security:
+//
+//	bearer:
+//	apiKey:
+//
+// responses:
+//
+//	204: noContentResponse
+//	401: unauthorizedResponse
+//	403: forbiddenResponse
+//	404: notFoundResponse
+//	501: internalServerErrorResponse
+//	504: gatewayTimeoutResponse
+func (h *apiKeyHandler) DeleteAPIKey(rw http.ResponseWriter, r *http.Request) {
+	log := logging.FromContext(r.Context(), h.log)
+	log.Info("apiKeyHandler.DeleteAPIKey - started")
+
+	ctx := r.Context()
+	rw.Header().Set("Content-type", "application/json")
+
+	vars := mux.Vars(r)
+	id, err := strconv.Atoi(vars["id"])
+	if err != nil {
+		writeError(log, rw, r, err)
+		return
+	}
+
+	if err := h.deleteUseCase.Execute(ctx, usecases.DeleteAPIKeyInput{ID: int64(id)}); err != nil {
+		writeError(log, rw, r, err)
+		return
+	}
+
+	log.Info("apiKeyHandler.DeleteAPIKey - finished successfully")
+
+	rw.WriteHeader(http.StatusNoContent)
+}
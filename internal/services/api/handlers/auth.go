@@ -0,0 +1,159 @@
+package handlers
+
+import (
+	"encoding/json"
+	"io"
+	"net/http"
+	"strconv"
+
+	"github.com/gorilla/mux"
+	"github.com/lyracampos/go-clean-architecture/internal/domain/usecases"
+	"github.com/lyracampos/go-clean-architecture/internal/logging"
+	"go.uber.org/zap"
+)
+
+type authHandler struct {
+	log                 *zap.SugaredLogger
+	loginUseCase        usecases.LoginUseCase
+	refreshTokenUseCase usecases.RefreshTokenUseCase
+	setPasswordUseCase  usecases.SetPasswordUseCase
+}
+
+func NewAuthHandler(
+	log *zap.SugaredLogger,
+	loginUseCase usecases.LoginUseCase,
+	refreshTokenUseCase usecases.RefreshTokenUseCase,
+	setPasswordUseCase usecases.SetPasswordUseCase,
+) *authHandler {
+	return &authHandler{
+		log:                 log,
+		loginUseCase:        loginUseCase,
+		refreshTokenUseCase: refreshTokenUseCase,
+		setPasswordUseCase:  setPasswordUseCase,
+	}
+}
+
+// swagger:route POST /auth/login auth Login
+// Authenticate an user with email and password
+// responses:
+//
+//	200: loginResponse
+//	400: badRequestResponse
+//	401: unauthorizedResponse
+//	501: internalServerErrorResponse
+//	504: gatewayTimeoutResponse
+func (h *authHandler) Login(rw http.ResponseWriter, r *http.Request) {
+	log := logging.FromContext(r.Context(), h.log)
+	log.Info("authHandler.Login - started")
+
+	ctx := r.Context()
+	rw.Header().Set("Content-type", "application/json")
+
+	requestBody, _ := io.ReadAll(r.Body)
+	var input usecases.LoginInput
+	if err := json.Unmarshal(requestBody, &input); err != nil {
+		writeError(log, rw, r, err)
+		return
+	}
+
+	loginResult, err := h.loginUseCase.Execute(ctx, input)
+	if err != nil {
+		writeError(log, rw, r, err)
+		return
+	}
+
+	log.Info("authHandler.Login - finished successfully")
+
+	rw.Header().Set("Cache-Control", "no-store")
+
+	if err := json.NewEncoder(rw).Encode(loginResult); err != nil {
+		log.Errorf("authHandler.Login - encode failed: %v", err)
+	}
+}
+
+// swagger:route POST /auth/refresh auth RefreshToken
+// Exchange a refresh token for new access and refresh tokens
+// responses:
+//
+//	200: refreshTokenResponse
+//	400: badRequestResponse
+//	401: unauthorizedResponse
+//	501: internalServerErrorResponse
+//	504: gatewayTimeoutResponse
+func (h *authHandler) RefreshToken(rw http.ResponseWriter, r *http.Request) {
+	log := logging.FromContext(r.Context(), h.log)
+	log.Info("authHandler.RefreshToken - started")
+
+	ctx := r.Context()
+	rw.Header().Set("Content-type", "application/json")
+
+	requestBody, _ := io.ReadAll(r.Body)
+	var input usecases.RefreshTokenInput
+	if err := json.Unmarshal(requestBody, &input); err != nil {
+		writeError(log, rw, r, err)
+		return
+	}
+
+	refreshResult, err := h.refreshTokenUseCase.Execute(ctx, input)
+	if err != nil {
+		writeError(log, rw, r, err)
+		return
+	}
+
+	log.Info("authHandler.RefreshToken - finished successfully")
+
+	rw.Header().Set("Cache-Control", "no-store")
+
+	if err := json.NewEncoder(rw).Encode(refreshResult); err != nil {
+		log.Errorf("authHandler.RefreshToken - encode failed: %v", err)
+	}
+}
+
+// swagger:route PUT /users/{id}/password auth SetPassword
+// Set the password an user logs in with
+// security:
+//
+//	bearer:
+//	apiKey:
+//
+// responses:
+//
+//	204: noContentResponse
+//	400: badRequestResponse
+//	401: unauthorizedResponse
+//	403: forbiddenResponse
+//	404: notFoundResponse
+//	501: internalServerErrorResponse
+//	504: gatewayTimeoutResponse
+func (h *authHandler) SetPassword(rw http.ResponseWriter, r *http.Request) {
+	log := logging.FromContext(r.Context(), h.log)
+	log.Info("authHandler.SetPassword - started")
+
+	ctx := r.Context()
+	rw.Header().Set("Content-type", "application/json")
+
+	vars := mux.Vars(r)
+	id, err := strconv.Atoi(vars["id"])
+	if err != nil {
+		writeError(log, rw, r, err)
+		return
+	}
+
+	requestBody, _ := io.ReadAll(r.Body)
+	var input usecases.SetPasswordInput
+	if err := json.Unmarshal(requestBody, &input); err != nil {
+		writeError(log, rw, r, err)
+		return
+	}
+
+	input.UserID = int64(id)
+
+	if err := h.setPasswordUseCase.Execute(ctx, input); err != nil {
+		writeError(log, rw, r, err)
+		return
+	}
+
+	log.Info("authHandler.SetPassword - finished successfully")
+
+	rw.WriteHeader(http.StatusNoContent)
+}
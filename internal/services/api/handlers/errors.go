@@ -0,0 +1,111 @@
+package handlers
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+
+	"github.com/lyracampos/go-clean-architecture/internal/domain"
+	"github.com/lyracampos/go-clean-architecture/internal/services/api"
+	"go.uber.org/zap"
+)
+
+// writeError answers with the problem details matching the error.
+func writeError(log *zap.SugaredLogger, rw http.ResponseWriter, r *http.Request, err error) {
+	if err := api.WriteProblem(rw, r, handlerErrors(log, err)); err != nil {
+		log.Errorf("writeError - encode failed: %v", err)
+	}
+}
+
+// WriteError answers with the problem details matching the error, so that the middlewares
+// failing on behalf of a route answer as its handlers do.
+func WriteError(log *zap.SugaredLogger, rw http.ResponseWriter, r *http.Request, err error) {
+	writeError(log, rw, r, err)
+}
+
+func handlerErrors(log *zap.SugaredLogger, err error) *api.Problem {
+	validationError := &domain.ValidationError{}
+	var syntaxError *json.SyntaxError
+	var unmarshalTypeError *json.UnmarshalTypeError
+
+	switch {
+	case errors.As(err, &validationError):
+		log.Info(err.Error())
+		return api.NewProblem(http.StatusBadRequest, api.ProblemTypeValidation, api.ErrValidation.Error()).
+			WithFieldErrors(validationError.ValidationErrors())
+	case errors.As(err, &syntaxError), errors.As(err, &unmarshalTypeError):
+		log.Info(err.Error())
+		return api.NewProblem(http.StatusBadRequest, api.ProblemTypeInvalidRequest, api.ErrInvalidRequestBody.Error())
+	case errors.Is(err, domain.ErrEmailAlreadyInUse):
+		log.Info(err.Error())
+		return api.NewProblem(http.StatusConflict, api.ProblemTypeEmailAlreadyInUse, api.ErrEmailAlreadyInUse.Error())
+	case errors.Is(err, domain.ErrUniqueViolation):
+		log.Info(err.Error())
+		return api.NewProblem(http.StatusConflict, api.ProblemTypeUniqueViolation, api.ErrUniqueViolation.Error())
+	case errors.Is(err, domain.ErrForeignKeyViolation):
+		log.Info(err.Error())
+		return api.NewProblem(http.StatusUnprocessableEntity, api.ProblemTypeForeignKeyViolation, api.ErrForeignKeyViolation.Error())
+	case errors.Is(err, domain.ErrCheckViolation):
+		log.Info(err.Error())
+		return api.NewProblem(http.StatusUnprocessableEntity, api.ProblemTypeCheckViolation, api.ErrCheckViolation.Error())
+	case errors.Is(err, domain.ErrNotNullViolation):
+		log.Info(err.Error())
+		return api.NewProblem(http.StatusBadRequest, api.ProblemTypeNotNullViolation, api.ErrNotNullViolation.Error())
+	case errors.Is(err, domain.ErrSerializationFailure):
+		log.Warn(err.Error())
+		return api.NewProblem(http.StatusConflict, api.ProblemTypeSerializationFailure, api.ErrSerializationFailure.Error())
+	case errors.Is(err, domain.ErrDeadlock):
+		log.Warn(err.Error())
+		return api.NewProblem(http.StatusServiceUnavailable, api.ProblemTypeDeadlock, api.ErrDeadlock.Error())
+	case errors.Is(err, domain.ErrDeadlineExceeded):
+		log.Warn(err.Error())
+		return api.NewProblem(http.StatusGatewayTimeout, api.ProblemTypeTimeout, api.ErrTimeout.Error())
+	case errors.Is(err, domain.ErrCanceled):
+		// the client is no longer waiting for the answer, nothing went wrong on our side
+		log.Info(err.Error())
+		return api.NewProblem(api.StatusClientClosedRequest, api.ProblemTypeRequestCanceled, api.ErrRequestCanceled.Error())
+	case errors.Is(err, domain.ErrInvalidCredentials):
+		log.Info(err.Error())
+		return api.NewProblem(http.StatusUnauthorized, api.ProblemTypeInvalidCredentials, api.ErrInvalidCredentials.Error())
+	case errors.Is(err, domain.ErrInvalidRefreshToken), errors.Is(err, domain.ErrRefreshTokenReused):
+		log.Info(err.Error())
+		return api.NewProblem(http.StatusUnauthorized, api.ProblemTypeInvalidRefreshToken, api.ErrInvalidRefreshToken.Error())
+	case errors.Is(err, domain.ErrInvalidAccessToken), errors.Is(err, domain.ErrInvalidAPIKey):
+		log.Info(err.Error())
+		return api.NewProblem(http.StatusUnauthorized, api.ProblemTypeUnauthorized, api.ErrUnauthorized.Error())
+	case errors.Is(err, domain.ErrUnauthenticated):
+		log.Info(err.Error())
+		return api.NewProblem(http.StatusUnauthorized, api.ProblemTypeUnauthorized, api.ErrUnauthorized.Error())
+	case errors.Is(err, domain.ErrForbidden):
+		log.Info(err.Error())
+		return api.NewProblem(http.StatusForbidden, api.ProblemTypeForbidden, api.ErrForbidden.Error())
+	case errors.Is(err, domain.ErrUserDoesNotExist):
+		log.Info(err.Error())
+		return api.NewProblem(http.StatusNotFound, api.ProblemTypeUserNotFound, api.ErrUserDoesNotExist.Error())
+	case errors.Is(err, domain.ErrAPIKeyDoesNotExist):
+		log.Info(err.Error())
+		return api.NewProblem(http.StatusNotFound, api.ProblemTypeAPIKeyNotFound, api.ErrAPIKeyDoesNotExist.Error())
+	case errors.Is(err, domain.ErrWebhookDoesNotExist):
+		log.Info(err.Error())
+		return api.NewProblem(http.StatusNotFound, api.ProblemTypeWebhookNotFound, api.ErrWebhookDoesNotExist.Error())
+	case errors.Is(err, domain.ErrInvalidCursor):
+		log.Info(err.Error())
+		return api.NewProblem(http.StatusBadRequest, api.ProblemTypeInvalidRequest, api.ErrInvalidCursor.Error())
+	case errors.Is(err, api.ErrInvalidLimit):
+		log.Info(err.Error())
+		return api.NewProblem(http.StatusBadRequest, api.ProblemTypeInvalidRequest, api.ErrInvalidLimit.Error())
+	case errors.Is(err, domain.ErrVersionConflict):
+		log.Info(err.Error())
+		return api.NewProblem(http.StatusPreconditionFailed, api.ProblemTypeVersionConflict, api.ErrVersionConflict.Error())
+	case errors.Is(err, api.ErrInvalidIfMatch):
+		log.Info(err.Error())
+		return api.NewProblem(http.StatusBadRequest, api.ProblemTypeInvalidRequest, api.ErrInvalidIfMatch.Error())
+	case errors.Is(err, api.ErrPreconditionRequired):
+		log.Info(err.Error())
+		return api.NewProblem(http.StatusPreconditionRequired, api.ProblemTypePreconditionRequired, api.ErrPreconditionRequired.Error())
+	default:
+		// internal details stay in the logs, they are not leaked to clients
+		log.Error(err.Error())
+		return api.NewProblem(http.StatusInternalServerError, api.ProblemTypeDefault, api.ErrInternal.Error())
+	}
+}
@@ -0,0 +1,57 @@
+package handlers
+
+import (
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/lyracampos/go-clean-architecture/internal/domain"
+	"github.com/lyracampos/go-clean-architecture/internal/domain/entities"
+	"github.com/lyracampos/go-clean-architecture/internal/services/api"
+)
+
+// etag formats an user version as a strong entity tag.
+func etag(version int64) string {
+	return strconv.Quote(strconv.FormatInt(version, 10))
+}
+
+// ifMatchVersions reads the user versions the client based its change on from the If-Match header,
+// the change applies when the user is at any of them. "*" matches any version. The tags are compared
+// strongly, as RFC 9110 requires for If-Match, so weak tags never match.
+func ifMatchVersions(r *http.Request) ([]int64, error) {
+	ifMatch := strings.TrimSpace(r.Header.Get("If-Match"))
+	if ifMatch == "" {
+		return nil, api.ErrPreconditionRequired
+	}
+
+	if ifMatch == "*" {
+		return []int64{entities.AnyVersion}, nil
+	}
+
+	var versions []int64
+	for _, tag := range strings.Split(ifMatch, ",") {
+		tag = strings.TrimSpace(tag)
+		weak := strings.HasPrefix(tag, "W/")
+		tag = strings.TrimPrefix(tag, "W/")
+
+		if len(tag) < 2 || tag[0] != '"' || tag[len(tag)-1] != '"' || strings.Contains(tag[1:len(tag)-1], `"`) {
+			return nil, api.ErrInvalidIfMatch
+		}
+
+		if weak {
+			continue
+		}
+
+		// a tag that is not a version is well formed, it just can't match any user
+		version, err := strconv.ParseInt(tag[1:len(tag)-1], 10, 64)
+		if err == nil && version != entities.AnyVersion {
+			versions = append(versions, version)
+		}
+	}
+
+	if len(versions) == 0 {
+		return nil, domain.ErrVersionConflict
+	}
+
+	return versions, nil
+}
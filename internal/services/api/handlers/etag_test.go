@@ -0,0 +1,114 @@
+package handlers
+
+import (
+	"net/http/httptest"
+	"reflect"
+	"testing"
+
+	"github.com/lyracampos/go-clean-architecture/internal/domain"
+	"github.com/lyracampos/go-clean-architecture/internal/domain/entities"
+	"github.com/lyracampos/go-clean-architecture/internal/services/api"
+)
+
+func TestIfMatchVersions(t *testing.T) {
+	tests := []struct {
+		name    string
+		ifMatch string
+		want    []int64
+		wantErr bool
+		err     error
+	}{
+		{
+			name:    "success reading a single tag",
+			ifMatch: `"3"`,
+			want:    []int64{3},
+		},
+		{
+			name:    "success reading any version",
+			ifMatch: "*",
+			want:    []int64{entities.AnyVersion},
+		},
+		{
+			name:    "success reading a list of tags",
+			ifMatch: `"3", "4" ,"5"`,
+			want:    []int64{3, 4, 5},
+		},
+		{
+			name:    "success skipping the weak tags of a list",
+			ifMatch: `W/"3", "4"`,
+			want:    []int64{4},
+		},
+		{
+			name:    "success skipping the tags that are not a version",
+			ifMatch: `"abc", "0", "4"`,
+			want:    []int64{4},
+		},
+		{
+			name:    "fail reading a missing header",
+			ifMatch: "",
+			wantErr: true,
+			err:     api.ErrPreconditionRequired,
+		},
+		{
+			name:    "fail reading only weak tags",
+			ifMatch: `W/"3", W/"4"`,
+			wantErr: true,
+			err:     domain.ErrVersionConflict,
+		},
+		{
+			name:    "fail reading only tags that are not a version",
+			ifMatch: `"abc"`,
+			wantErr: true,
+			err:     domain.ErrVersionConflict,
+		},
+		{
+			name:    "fail reading an unquoted tag",
+			ifMatch: "3",
+			wantErr: true,
+			err:     api.ErrInvalidIfMatch,
+		},
+		{
+			name:    "fail reading a list with an empty tag",
+			ifMatch: `"3",,"4"`,
+			wantErr: true,
+			err:     api.ErrInvalidIfMatch,
+		},
+		{
+			name:    "fail reading a tag with a quote inside",
+			ifMatch: `"3"4"`,
+			wantErr: true,
+			err:     api.ErrInvalidIfMatch,
+		},
+		{
+			name:    "fail reading * within a list",
+			ifMatch: `*, "3"`,
+			wantErr: true,
+			err:     api.ErrInvalidIfMatch,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r := httptest.NewRequest("DELETE", "/users/1", nil)
+			if tt.ifMatch != "" {
+				r.Header.Set("If-Match", tt.ifMatch)
+			}
+
+			versions, err := ifMatchVersions(r)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("ifMatchVersions() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+
+			if !reflect.DeepEqual(versions, tt.want) {
+				t.Errorf("ifMatchVersions() = %v, want %v", versions, tt.want)
+			}
+
+			if tt.wantErr {
+				if err.Error() != tt.err.Error() {
+					t.Errorf("ifMatchVersions() error = %v, wantErr %v", err, tt.err)
+					return
+				}
+			}
+		})
+	}
+}
@@ -1,26 +1,134 @@
 package handlers
 
 import (
-	"log"
+	"context"
+	"encoding/json"
 	"net/http"
+	"sync"
+	"sync/atomic"
+	"time"
 
+	"github.com/lyracampos/go-clean-architecture/internal/logging"
 	"go.uber.org/zap"
 )
 
+const (
+	healthStatusUp           = "up"
+	healthStatusDown         = "down"
+	healthStatusReady        = "ready"
+	healthStatusNotReady     = "not_ready"
+	healthStatusShuttingDown = "shutting_down"
+)
+
+// HealthCheck tells whether a dependency the API relies on can be used.
+type HealthCheck struct {
+	Name string
+	// Check returns a short description of the dependency state, and an error when it can't be used.
+	Check func(ctx context.Context) (string, error)
+}
+
+type healthResponse struct {
+	Status string                       `json:"status"`
+	Checks map[string]healthCheckResult `json:"checks,omitempty"`
+}
+
+type healthCheckResult struct {
+	Status  string `json:"status"`
+	Latency string `json:"latency"`
+	Detail  string `json:"detail,omitempty"`
+}
+
 type healthHandler struct {
-	log *zap.SugaredLogger
+	log          *zap.SugaredLogger
+	checkTimeout atomic.Int64
+	checks       []HealthCheck
+	shuttingDown atomic.Bool
 }
 
-func NewHealthHandler(log *zap.SugaredLogger) *healthHandler {
-	return &healthHandler{
-		log: log,
+func NewHealthHandler(log *zap.SugaredLogger, checkTimeout time.Duration, checks ...HealthCheck) *healthHandler {
+	h := &healthHandler{
+		log:    log,
+		checks: checks,
 	}
+	h.SetCheckTimeout(checkTimeout)
+
+	return h
+}
+
+// SetCheckTimeout replaces how long the health checks of the readiness probe may take.
+func (h *healthHandler) SetCheckTimeout(checkTimeout time.Duration) {
+	h.checkTimeout.Store(int64(checkTimeout))
+}
+
+// ShutDown makes the readiness probe fail from now on, so that the traffic is drained
+// before the server stops accepting connections.
+func (h *healthHandler) ShutDown() {
+	h.shuttingDown.Store(true)
+}
+
+// Live answers as long as the process is able to serve requests, its dependencies are not checked.
+func (h *healthHandler) Live(rw http.ResponseWriter, r *http.Request) {
+	h.writeHealth(rw, r, http.StatusOK, healthResponse{Status: healthStatusUp})
 }
 
-func (h *healthHandler) CheckStatus(rw http.ResponseWriter, r *http.Request) {
-	h.log.Info("Checking API status")
-	_, err := rw.Write([]byte("status ok"))
-	if err != nil {
-		log.Printf("CheckStatus - write failed: %v", err)
+// Ready runs every health check and answers 503 when any of them fails or the API is shutting down.
+func (h *healthHandler) Ready(rw http.ResponseWriter, r *http.Request) {
+	if h.shuttingDown.Load() {
+		h.writeHealth(rw, r, http.StatusServiceUnavailable, healthResponse{Status: healthStatusShuttingDown})
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(r.Context(), time.Duration(h.checkTimeout.Load()))
+	defer cancel()
+
+	log := logging.FromContext(ctx, h.log)
+	results := make([]healthCheckResult, len(h.checks))
+
+	var wg sync.WaitGroup
+	for i, check := range h.checks {
+		wg.Add(1)
+		go func(i int, check HealthCheck) {
+			defer wg.Done()
+
+			start := time.Now()
+			detail, err := check.Check(ctx)
+
+			results[i] = healthCheckResult{
+				Status:  healthStatusUp,
+				Latency: time.Since(start).String(),
+				Detail:  detail,
+			}
+
+			if err != nil {
+				// the cause stays in the logs, the probe is not authenticated
+				log.Warnf("healthHandler.Ready - %s check failed: %v", check.Name, err)
+				results[i].Status = healthStatusDown
+			}
+		}(i, check)
+	}
+	wg.Wait()
+
+	response := healthResponse{Status: healthStatusReady, Checks: map[string]healthCheckResult{}}
+	statusCode := http.StatusOK
+
+	for i, check := range h.checks {
+		response.Checks[check.Name] = results[i]
+
+		if results[i].Status != healthStatusUp {
+			response.Status = healthStatusNotReady
+			statusCode = http.StatusServiceUnavailable
+		}
+	}
+
+	h.writeHealth(rw, r, statusCode, response)
+}
+
+func (h *healthHandler) writeHealth(rw http.ResponseWriter, r *http.Request, statusCode int, response healthResponse) {
+	rw.Header().Set("Content-type", "application/json")
+	rw.Header().Set("Cache-Control", "no-store")
+	rw.WriteHeader(statusCode)
+
+	if err := json.NewEncoder(rw).Encode(response); err != nil {
+		logging.FromContext(r.Context(), h.log).Errorf("healthHandler - encode failed: %v", err)
 	}
 }
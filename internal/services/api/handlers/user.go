@@ -1,26 +1,26 @@
 package handlers
 
 import (
-	"context"
 	"encoding/json"
-	"errors"
 	"io"
-	"log"
 	"net/http"
 	"strconv"
 
 	"github.com/gorilla/mux"
-	"github.com/lyracampos/go-clean-architecture/internal/domain"
 	"github.com/lyracampos/go-clean-architecture/internal/domain/usecases"
+	"github.com/lyracampos/go-clean-architecture/internal/logging"
 	"github.com/lyracampos/go-clean-architecture/internal/services/api"
 	"go.uber.org/zap"
 )
 
 type userHandler struct {
-	log           *zap.SugaredLogger
-	listUseCase   usecases.ListUserUseCase
-	getUseCase    usecases.GetUserUseCase
-	createUseCase usecases.CreateUserUseCase
+	log            *zap.SugaredLogger
+	listUseCase    usecases.ListUserUseCase
+	getUseCase     usecases.GetUserUseCase
+	createUseCase  usecases.CreateUserUseCase
+	updateUseCase  usecases.UpdateUserUseCase
+	deleteUseCase  usecases.DeleteUserUseCase
+	restoreUseCase usecases.RestoreUserUseCase
 }
 
 func NewUserHandler(
@@ -28,127 +28,162 @@ func NewUserHandler(
 	listUseCase usecases.ListUserUseCase,
 	getUseCase usecases.GetUserUseCase,
 	createUseCase usecases.CreateUserUseCase,
+	updateUseCase usecases.UpdateUserUseCase,
+	deleteUseCase usecases.DeleteUserUseCase,
+	restoreUseCase usecases.RestoreUserUseCase,
 ) *userHandler {
 	return &userHandler{
-		log:           log,
-		listUseCase:   listUseCase,
-		getUseCase:    getUseCase,
-		createUseCase: createUseCase,
+		log:            log,
+		listUseCase:    listUseCase,
+		getUseCase:     getUseCase,
+		createUseCase:  createUseCase,
+		updateUseCase:  updateUseCase,
+		deleteUseCase:  deleteUseCase,
+		restoreUseCase: restoreUseCase,
 	}
 }
 
 // swagger:route GET /users users ListUsers
-// Return a list of users from system
+// Return a page of users from system
+// security:
+//
+//	bearer:
+//	apiKey:
+//
 // responses:
 //
 //	200: userListResponse
+//	400: badRequestResponse
+//	401: unauthorizedResponse
+//	403: forbiddenResponse
 //	501: internalServerErrorResponse
+//	504: gatewayTimeoutResponse
 func (h *userHandler) ListUsers(rw http.ResponseWriter, r *http.Request) {
-	h.log.Info("userHandler.ListUsers - started")
+	log := logging.FromContext(r.Context(), h.log)
+	log.Info("userHandler.ListUsers - started")
 
-	ctx := context.Background()
+	ctx := r.Context()
 	rw.Header().Set("Content-type", "application/json")
 
 	firstName := r.URL.Query().Get("first_name")
 	lastName := r.URL.Query().Get("last_name")
 	emailFilter := r.URL.Query().Get("email")
 	roleFilter := r.URL.Query().Get("role")
+	includeDeleted := r.URL.Query().Get("include_deleted") == "true"
+	query := r.URL.Query().Get("q")
+	cursor := r.URL.Query().Get("cursor")
+	sort := r.URL.Query().Get("sort")
+
+	limit, err := queryInt(r, "limit")
+	if err != nil {
+		writeError(log, rw, r, api.ErrInvalidLimit)
+		return
+	}
 
 	listUserResult, err := h.listUseCase.Execute(ctx, usecases.ListUserInput{
-		FirstName: firstName,
-		LastName:  lastName,
-		Email:     emailFilter,
-		Role:      roleFilter,
+		FirstName:      firstName,
+		LastName:       lastName,
+		Email:          emailFilter,
+		Role:           roleFilter,
+		IncludeDeleted: includeDeleted,
+		Query:          query,
+		Limit:          limit,
+		Cursor:         cursor,
+		Sort:           sort,
 	})
 
 	if err != nil {
-		errMsg, statusCode := h.handlerErrors(err)
-		rw.WriteHeader(statusCode)
-		_, err := rw.Write([]byte(errMsg))
-		if err != nil {
-			log.Printf("userHandler.ListUsers - write failed: %v", err)
-		}
-
+		writeError(log, rw, r, err)
 		return
 	}
 
-	h.log.Info("userHandler.ListUsers - finished successfully")
+	log.Info("userHandler.ListUsers - finished successfully")
 
 	if err := json.NewEncoder(rw).Encode(listUserResult); err != nil {
-		log.Printf("userHandler.ListUsers - encode failed: %v", err)
+		log.Errorf("userHandler.ListUsers - encode failed: %v", err)
 	}
 }
 
 // swagger:route GET /users/{id} users GetUser
 // Return an user from system
+// security:
+//
+//	bearer:
+//	apiKey:
+//
 // responses:
 //
 //	200: userGetResponse
+//	401: unauthorizedResponse
+//	403: forbiddenResponse
 //	404: notFoundResponse
 //	501: internalServerErrorResponse
+//	504: gatewayTimeoutResponse
 func (h *userHandler) GetUser(rw http.ResponseWriter, r *http.Request) {
-	h.log.Info("userHandler.GetUser - started")
+	log := logging.FromContext(r.Context(), h.log)
+	log.Info("userHandler.GetUser - started")
 
-	ctx := context.Background()
+	ctx := r.Context()
 	rw.Header().Set("Content-type", "application/json")
 
 	vars := mux.Vars(r)
 	id, err := strconv.Atoi(vars["id"])
 	if err != nil {
-		errMsg, statusCode := h.handlerErrors(err)
-		rw.WriteHeader(statusCode)
-		_, err := rw.Write([]byte(errMsg))
-		if err != nil {
-			log.Printf("userHandler.GetUser - write failed: %v", err)
-		}
-
+		writeError(log, rw, r, err)
 		return
 	}
 
-	getUserResult, err := h.getUseCase.Execute(ctx, usecases.GetUserInput{ID: int64(id)})
-	if err != nil {
-		errMsg, statusCode := h.handlerErrors(err)
-		rw.WriteHeader(statusCode)
-		_, err := rw.Write([]byte(errMsg))
-		if err != nil {
-			log.Printf("userHandler.GetUser - write failed: %v", err)
-		}
+	includeDeleted := r.URL.Query().Get("include_deleted") == "true"
 
+	getUserResult, err := h.getUseCase.Execute(ctx, usecases.GetUserInput{
+		ID:             int64(id),
+		IncludeDeleted: includeDeleted,
+	})
+	if err != nil {
+		writeError(log, rw, r, err)
 		return
 	}
 
-	h.log.Info("userHandler.GetUser - finished successfully")
+	log.Info("userHandler.GetUser - finished successfully")
+
+	rw.Header().Set("ETag", etag(getUserResult.Version))
 
 	if err := json.NewEncoder(rw).Encode(getUserResult); err != nil {
-		log.Printf("userHandler.GetUser - encode failed: %v", err)
+		log.Errorf("userHandler.GetUser - encode failed: %v", err)
 	}
 }
 
 // swagger:route POST /users  users AddUser
-// Add new user in the application
+// Add new user in the application, retries with the same Idempotency-Key return the first response
+// security:
+//
+//	bearer:
+//	apiKey:
+//
 // responses:
 //
 //	201: userAddResponse
-//	400: notFoundResponse
+//	400: badRequestResponse
+//	401: unauthorizedResponse
+//	403: forbiddenResponse
+//	409: conflictResponse
+//	422: unprocessableEntityResponse
 //	501: internalServerErrorResponse
+//	503: serviceUnavailableResponse
+//	504: gatewayTimeoutResponse
 func (h *userHandler) CreateUser(rw http.ResponseWriter, r *http.Request) {
-	ctx := context.Background()
+	log := logging.FromContext(r.Context(), h.log)
+	ctx := r.Context()
 	rw.Header().Set("Content-type", "application/json")
 
 	requestBody, _ := io.ReadAll(r.Body)
 	var input usecases.CreateUserInput
 	if err := json.Unmarshal(requestBody, &input); err != nil {
-		errMsg, statusCode := h.handlerErrors(err)
-		rw.WriteHeader(statusCode)
-		_, err := rw.Write([]byte(errMsg))
-		if err != nil {
-			log.Printf("userHandler.CreateUser - json unmarshal failed: %v", err)
-		}
-
+		writeError(log, rw, r, err)
 		return
 	}
 
-	h.log.Info("userHandler.CreateUser - started")
+	log.Info("userHandler.CreateUser - started")
 
 	createUserResult, err := h.createUseCase.Execute(ctx, usecases.CreateUserInput{
 		FirstName: input.FirstName,
@@ -157,38 +192,263 @@ func (h *userHandler) CreateUser(rw http.ResponseWriter, r *http.Request) {
 		Role:      input.Role,
 	})
 	if err != nil {
-		errMsg, statusCode := h.handlerErrors(err)
-		rw.WriteHeader(statusCode)
-		_, err := rw.Write([]byte(errMsg))
-		if err != nil {
-			log.Printf("userHandler.CreateUser - write failed: %v", err)
-		}
-
+		writeError(log, rw, r, err)
 		return
 	}
 
-	h.log.Info("userHandler.CreateUser - finished successfully")
+	log.Info("userHandler.CreateUser - finished successfully")
 
+	rw.Header().Set("ETag", etag(createUserResult.Version))
 	rw.WriteHeader(http.StatusCreated)
 
 	if err := json.NewEncoder(rw).Encode(createUserResult); err != nil {
-		log.Printf("userHandler.CreateUser - encode failed: %v", err)
+		log.Errorf("userHandler.CreateUser - encode failed: %v", err)
+	}
+}
+
+// swagger:route PUT /users/{id} users UpdateUser
+// Replace all fields of an user from system
+// security:
+//
+//	bearer:
+//	apiKey:
+//
+// responses:
+//
+//	200: userUpdateResponse
+//	400: badRequestResponse
+//	401: unauthorizedResponse
+//	403: forbiddenResponse
+//	404: notFoundResponse
+//	409: conflictResponse
+//	412: preconditionFailedResponse
+//	428: preconditionRequiredResponse
+//	422: unprocessableEntityResponse
+//	501: internalServerErrorResponse
+//	503: serviceUnavailableResponse
+//	504: gatewayTimeoutResponse
+func (h *userHandler) UpdateUser(rw http.ResponseWriter, r *http.Request) {
+	log := logging.FromContext(r.Context(), h.log)
+	ctx := r.Context()
+	rw.Header().Set("Content-type", "application/json")
+
+	vars := mux.Vars(r)
+	id, err := strconv.Atoi(vars["id"])
+	if err != nil {
+		writeError(log, rw, r, err)
+		return
+	}
+
+	versions, err := ifMatchVersions(r)
+	if err != nil {
+		writeError(log, rw, r, err)
+		return
+	}
+
+	requestBody, _ := io.ReadAll(r.Body)
+	var input usecases.CreateUserInput
+	if err := json.Unmarshal(requestBody, &input); err != nil {
+		writeError(log, rw, r, err)
+		return
+	}
+
+	log.Info("userHandler.UpdateUser - started")
+
+	updateUserResult, err := h.updateUseCase.Execute(ctx, *usecases.NewUpdateUserInput(int64(id),
+		usecases.WithUpdateUserInputVersions(versions...),
+		usecases.WithUpdateUserInputFirstName(input.FirstName),
+		usecases.WithUpdateUserInputLastName(input.LastName),
+		usecases.WithUpdateUserInputEmail(input.Email),
+		usecases.WithUpdateUserInputRole(input.Role),
+	))
+	if err != nil {
+		writeError(log, rw, r, err)
+		return
+	}
+
+	log.Info("userHandler.UpdateUser - finished successfully")
+
+	rw.Header().Set("ETag", etag(updateUserResult.Version))
+
+	if err := json.NewEncoder(rw).Encode(updateUserResult); err != nil {
+		log.Errorf("userHandler.UpdateUser - encode failed: %v", err)
+	}
+}
+
+// swagger:route PATCH /users/{id} users PatchUser
+// Update only the informed fields of an user from system
+// security:
+//
+//	bearer:
+//	apiKey:
+//
+// responses:
+//
+//	200: userUpdateResponse
+//	400: badRequestResponse
+//	401: unauthorizedResponse
+//	403: forbiddenResponse
+//	404: notFoundResponse
+//	409: conflictResponse
+//	412: preconditionFailedResponse
+//	428: preconditionRequiredResponse
+//	422: unprocessableEntityResponse
+//	501: internalServerErrorResponse
+//	503: serviceUnavailableResponse
+//	504: gatewayTimeoutResponse
+func (h *userHandler) PatchUser(rw http.ResponseWriter, r *http.Request) {
+	log := logging.FromContext(r.Context(), h.log)
+	ctx := r.Context()
+	rw.Header().Set("Content-type", "application/json")
+
+	vars := mux.Vars(r)
+	id, err := strconv.Atoi(vars["id"])
+	if err != nil {
+		writeError(log, rw, r, err)
+		return
+	}
+
+	versions, err := ifMatchVersions(r)
+	if err != nil {
+		writeError(log, rw, r, err)
+		return
+	}
+
+	requestBody, _ := io.ReadAll(r.Body)
+	var input usecases.UpdateUserInput
+	if err := json.Unmarshal(requestBody, &input); err != nil {
+		writeError(log, rw, r, err)
+		return
+	}
+
+	log.Info("userHandler.PatchUser - started")
+
+	input.ID = int64(id)
+	input.Versions = versions
+
+	patchUserResult, err := h.updateUseCase.Execute(ctx, input)
+	if err != nil {
+		writeError(log, rw, r, err)
+		return
+	}
+
+	log.Info("userHandler.PatchUser - finished successfully")
+
+	rw.Header().Set("ETag", etag(patchUserResult.Version))
+
+	if err := json.NewEncoder(rw).Encode(patchUserResult); err != nil {
+		log.Errorf("userHandler.PatchUser - encode failed: %v", err)
 	}
 }
 
-func (h *userHandler) handlerErrors(err error) (string, int) {
-	h.log.Error(err.Error())
+// swagger:route DELETE /users/{id} users DeleteUser
+// Soft delete an user from system
+// security:
+//
+//	bearer:
+//	apiKey:
+//
+// responses:
+//
+//	204: noContentResponse
+//	400: badRequestResponse
+//	401: unauthorizedResponse
+//	403: forbiddenResponse
+//	404: notFoundResponse
+//	412: preconditionFailedResponse
+//	428: preconditionRequiredResponse
+//	501: internalServerErrorResponse
+//	504: gatewayTimeoutResponse
+func (h *userHandler) DeleteUser(rw http.ResponseWriter, r *http.Request) {
+	log := logging.FromContext(r.Context(), h.log)
+	log.Info("userHandler.DeleteUser - started")
 
-	validationError := &domain.ValidationError{}
+	ctx := r.Context()
+	rw.Header().Set("Content-type", "application/json")
 
-	switch {
-	case errors.As(err, &validationError):
-		return err.Error(), http.StatusBadRequest
-	case errors.Is(err, domain.ErrEmailAlreadyInUse):
-		return api.ErrEmailAlreadyInUse.Error(), http.StatusConflict
-	case errors.Is(err, domain.ErrUserDoesNotExist):
-		return api.ErrUserDoesNotExist.Error(), http.StatusNotFound
-	default:
-		return err.Error(), http.StatusInternalServerError
+	vars := mux.Vars(r)
+	id, err := strconv.Atoi(vars["id"])
+	if err != nil {
+		writeError(log, rw, r, err)
+		return
+	}
+
+	versions, err := ifMatchVersions(r)
+	if err != nil {
+		writeError(log, rw, r, err)
+		return
+	}
+
+	if err := h.deleteUseCase.Execute(ctx, usecases.DeleteUserInput{ID: int64(id), Versions: versions}); err != nil {
+		writeError(log, rw, r, err)
+		return
 	}
+
+	log.Info("userHandler.DeleteUser - finished successfully")
+
+	rw.WriteHeader(http.StatusNoContent)
+}
+
+// swagger:route POST /users/{id}/restore users RestoreUser
+// Restore a soft deleted user
+// security:
+//
+//	bearer:
+//	apiKey:
+//
+// responses:
+//
+//	200: userRestoreResponse
+//	400: badRequestResponse
+//	401: unauthorizedResponse
+//	403: forbiddenResponse
+//	404: notFoundResponse
+//	409: conflictResponse
+//	412: preconditionFailedResponse
+//	428: preconditionRequiredResponse
+//	501: internalServerErrorResponse
+//	504: gatewayTimeoutResponse
+func (h *userHandler) RestoreUser(rw http.ResponseWriter, r *http.Request) {
+	log := logging.FromContext(r.Context(), h.log)
+	log.Info("userHandler.RestoreUser - started")
+
+	ctx := r.Context()
+	rw.Header().Set("Content-type", "application/json")
+
+	vars := mux.Vars(r)
+	id, err := strconv.Atoi(vars["id"])
+	if err != nil {
+		writeError(log, rw, r, err)
+		return
+	}
+
+	versions, err := ifMatchVersions(r)
+	if err != nil {
+		writeError(log, rw, r, err)
+		return
+	}
+
+	restoreUserResult, err := h.restoreUseCase.Execute(ctx, usecases.RestoreUserInput{ID: int64(id), Versions: versions})
+	if err != nil {
+		writeError(log, rw, r, err)
+		return
+	}
+
+	log.Info("userHandler.RestoreUser - finished successfully")
+
+	rw.Header().Set("ETag", etag(restoreUserResult.Version))
+
+	if err := json.NewEncoder(rw).Encode(restoreUserResult); err != nil {
+		log.Errorf("userHandler.RestoreUser - encode failed: %v", err)
+	}
+}
+
+// queryInt reads an optional integer query parameter, returning zero when it is absent.
+func queryInt(r *http.Request, name string) (int, error) {
+	value := r.URL.Query().Get(name)
+	if value == "" {
+		return 0, nil
+	}
+
+	return strconv.Atoi(value)
 }
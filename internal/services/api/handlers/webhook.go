@@ -0,0 +1,307 @@
+package handlers
+
+import (
+	"encoding/json"
+	"io"
+	"net/http"
+	"strconv"
+
+	"github.com/gorilla/mux"
+	"github.com/lyracampos/go-clean-architecture/internal/domain/usecases"
+	"github.com/lyracampos/go-clean-architecture/internal/logging"
+	"github.com/lyracampos/go-clean-architecture/internal/services/api"
+	"go.uber.org/zap"
+)
+
+type webhookHandler struct {
+	log                   *zap.SugaredLogger
+	createUseCase         usecases.CreateWebhookUseCase
+	listUseCase           usecases.ListWebhooksUseCase
+	getUseCase            usecases.GetWebhookUseCase
+	updateUseCase         usecases.UpdateWebhookUseCase
+	deleteUseCase         usecases.DeleteWebhookUseCase
+	listDeliveriesUseCase usecases.ListWebhookDeliveriesUseCase
+}
+
+func NewWebhookHandler(
+	log *zap.SugaredLogger,
+	createUseCase usecases.CreateWebhookUseCase,
+	listUseCase usecases.ListWebhooksUseCase,
+	getUseCase usecases.GetWebhookUseCase,
+	updateUseCase usecases.UpdateWebhookUseCase,
+	deleteUseCase usecases.DeleteWebhookUseCase,
+	listDeliveriesUseCase usecases.ListWebhookDeliveriesUseCase,
+) *webhookHandler {
+	return &webhookHandler{
+		log:                   log,
+		createUseCase:         createUseCase,
+		listUseCase:           listUseCase,
+		getUseCase:            getUseCase,
+		updateUseCase:         updateUseCase,
+		deleteUseCase:         deleteUseCase,
+		listDeliveriesUseCase: listDeliveriesUseCase,
+	}
+}
+
+// swagger:route POST /webhooks webhooks CreateWebhook
+// Subscribe an URL to user events, the deliveries are signed with the secret in the X-Signature header
+// security:
+//
+//	bearer:
+//	apiKey:
+//
+// responses:
+//
+//	201: webhookResponse
+//	400: badRequestResponse
+//	401: unauthorizedResponse
+//	403: forbiddenResponse
+//	501: internalServerErrorResponse
+//	504: gatewayTimeoutResponse
+func (h *webhookHandler) CreateWebhook(rw http.ResponseWriter, r *http.Request) {
+	log := logging.FromContext(r.Context(), h.log)
+	log.Info("webhookHandler.CreateWebhook - started")
+
+	ctx := r.Context()
+	rw.Header().Set("Content-type", "application/json")
+
+	requestBody, _ := io.ReadAll(r.Body)
+	var input usecases.CreateWebhookInput
+	if err := json.Unmarshal(requestBody, &input); err != nil {
+		writeError(log, rw, r, err)
+		return
+	}
+
+	createResult, err := h.createUseCase.Execute(ctx, input)
+	if err != nil {
+		writeError(log, rw, r, err)
+		return
+	}
+
+	log.Info("webhookHandler.CreateWebhook - finished successfully")
+
+	rw.WriteHeader(http.StatusCreated)
+
+	if err := json.NewEncoder(rw).Encode(createResult); err != nil {
+		log.Errorf("webhookHandler.CreateWebhook - encode failed: %v", err)
+	}
+}
+
+// swagger:route GET /webhooks webhooks ListWebhooks
+// Return the webhooks, without their secrets
+// security:
+//
+//	bearer:
+//	apiKey:
+//
+// responses:
+//
+//	200: webhookListResponse
+//	401: unauthorizedResponse
+//	403: forbiddenResponse
+//	501: internalServerErrorResponse
+//	504: gatewayTimeoutResponse
+func (h *webhookHandler) ListWebhooks(rw http.ResponseWriter, r *http.Request) {
+	log := logging.FromContext(r.Context(), h.log)
+	log.Info("webhookHandler.ListWebhooks - started")
+
+	ctx := r.Context()
+	rw.Header().Set("Content-type", "application/json")
+
+	listResult, err := h.listUseCase.Execute(ctx)
+	if err != nil {
+		writeError(log, rw, r, err)
+		return
+	}
+
+	log.Info("webhookHandler.ListWebhooks - finished successfully")
+
+	if err := json.NewEncoder(rw).Encode(listResult); err != nil {
+		log.Errorf("webhookHandler.ListWebhooks - encode failed: %v", err)
+	}
+}
+
+// swagger:route GET /webhooks/{id} webhooks GetWebhook
+// Return a webhook, without its secret
+// security:
+//
+//	bearer:
+//	apiKey:
+//
+// responses:
+//
+//	200: webhookResponse
+//	401: unauthorizedResponse
+//	403: forbiddenResponse
+//	404: notFoundResponse
+//	501: internalServerErrorResponse
+//	504: gatewayTimeoutResponse
+func (h *webhookHandler) GetWebhook(rw http.ResponseWriter, r *http.Request) {
+	log := logging.FromContext(r.Context(), h.log)
+	log.Info("webhookHandler.GetWebhook - started")
+
+	ctx := r.Context()
+	rw.Header().Set("Content-type", "application/json")
+
+	vars := mux.Vars(r)
+	id, err := strconv.Atoi(vars["id"])
+	if err != nil {
+		writeError(log, rw, r, err)
+		return
+	}
+
+	getResult, err := h.getUseCase.Execute(ctx, usecases.GetWebhookInput{ID: int64(id)})
+	if err != nil {
+		writeError(log, rw, r, err)
+		return
+	}
+
+	log.Info("webhookHandler.GetWebhook - finished successfully")
+
+	if err := json.NewEncoder(rw).Encode(getResult); err != nil {
+		log.Errorf("webhookHandler.GetWebhook - encode failed: %v", err)
+	}
+}
+
+// swagger:route PUT /webhooks/{id} webhooks UpdateWebhook
+// Update a webhook, enabling it again resets its failure count
+// security:
+//
+//	bearer:
+//	apiKey:
+//
+// responses:
+//
+//	200: webhookResponse
+//	400: badRequestResponse
+//	401: unauthorizedResponse
+//	403: forbiddenResponse
+//	404: notFoundResponse
+//	501: internalServerErrorResponse
+//	504: gatewayTimeoutResponse
+func (h *webhookHandler) UpdateWebhook(rw http.ResponseWriter, r *http.Request) {
+	log := logging.FromContext(r.Context(), h.log)
+	log.Info("webhookHandler.UpdateWebhook - started")
+
+	ctx := r.Context()
+	rw.Header().Set("Content-type", "application/json")
+
+	vars := mux.Vars(r)
+	id, err := strconv.Atoi(vars["id"])
+	if err != nil {
+		writeError(log, rw, r, err)
+		return
+	}
+
+	requestBody, _ := io.ReadAll(r.Body)
+	var input usecases.UpdateWebhookInput
+	if err := json.Unmarshal(requestBody, &input); err != nil {
+		writeError(log, rw, r, err)
+		return
+	}
+
+	input.ID = int64(id)
+
+	updateResult, err := h.updateUseCase.Execute(ctx, input)
+	if err != nil {
+		writeError(log, rw, r, err)
+		return
+	}
+
+	log.Info("webhookHandler.UpdateWebhook - finished successfully")
+
+	if err := json.NewEncoder(rw).Encode(updateResult); err != nil {
+		log.Errorf("webhookHandler.UpdateWebhook - encode failed: %v", err)
+	}
+}
+
+// swagger:route DELETE /webhooks/{id} webhooks DeleteWebhook
+// Delete a webhook along with its pending deliveries
+// security:
+//
+//	bearer:
+//	apiKey:
+//
+// responses:
+//
+//	204: noContentResponse
+//	401: unauthorizedResponse
+//	403: forbiddenResponse
+//	404: notFoundResponse
+//	501: internalServerErrorResponse
+//	504: gatewayTimeoutResponse
+func (h *webhookHandler) DeleteWebhook(rw http.ResponseWriter, r *http.Request) {
+	log := logging.FromContext(r.Context(), h.log)
+	log.Info("webhookHandler.DeleteWebhook - started")
+
+	ctx := r.Context()
+	rw.Header().Set("Content-type", "application/json")
+
+	vars := mux.Vars(r)
+	id, err := strconv.Atoi(vars["id"])
+	if err != nil {
+		writeError(log, rw, r, err)
+		return
+	}
+
+	if err := h.deleteUseCase.Execute(ctx, usecases.DeleteWebhookInput{ID: int64(id)}); err != nil {
+		writeError(log, rw, r, err)
+		return
+	}
+
+	log.Info("webhookHandler.DeleteWebhook - finished successfully")
+
+	rw.WriteHeader(http.StatusNoContent)
+}
+
+// swagger:route GET /webhooks/{id}/deliveries webhooks ListWebhookDeliveries
+// Return the latest delivery attempts of a webhook, most recent first
+// security:
+//
+//	bearer:
+//	apiKey:
+//
+// responses:
+//
+//	200: webhookDeliveryListResponse
+//	400: badRequestResponse
+//	401: unauthorizedResponse
+//	403: forbiddenResponse
+//	404: notFoundResponse
+//	501: internalServerErrorResponse
+//	504: gatewayTimeoutResponse
+func (h *webhookHandler) ListWebhookDeliveries(rw http.ResponseWriter, r *http.Request) {
+	log := logging.FromContext(r.Context(), h.log)
+	log.Info("webhookHandler.ListWebhookDeliveries - started")
+
+	ctx := r.Context()
+	rw.Header().Set("Content-type", "application/json")
+
+	vars := mux.Vars(r)
+	id, err := strconv.Atoi(vars["id"])
+	if err != nil {
+		writeError(log, rw, r, err)
+		return
+	}
+
+	limit, err := queryInt(r, "limit")
+	if err != nil {
+		writeError(log, rw, r, api.ErrInvalidLimit)
+		return
+	}
+
+	listResult, err := h.listDeliveriesUseCase.Execute(ctx, usecases.ListWebhookDeliveriesInput{
+		WebhookID: int64(id),
+		Limit:     limit,
+	})
+	if err != nil {
+		writeError(log, rw, r, err)
+		return
+	}
+
+	log.Info("webhookHandler.ListWebhookDeliveries - finished successfully")
+
+	if err := json.NewEncoder(rw).Encode(listResult); err != nil {
+		log.Errorf("webhookHandler.ListWebhookDeliveries - encode failed: %v", err)
+	}
+}
@@ -0,0 +1,99 @@
+package middlewares
+
+import (
+	"errors"
+	"net/http"
+	"strings"
+
+	"github.com/lyracampos/go-clean-architecture/internal/domain"
+	"github.com/lyracampos/go-clean-architecture/internal/domain/ports"
+	"github.com/lyracampos/go-clean-architecture/internal/domain/usecases"
+	"github.com/lyracampos/go-clean-architecture/internal/logging"
+	"github.com/lyracampos/go-clean-architecture/internal/services/api"
+	"github.com/lyracampos/go-clean-architecture/internal/services/api/handlers"
+	"go.uber.org/zap"
+)
+
+const (
+	bearerScheme = "Bearer"
+	apiKeyScheme = "ApiKey"
+)
+
+type authentication struct {
+	log                       *zap.SugaredLogger
+	tokenService              ports.TokenService
+	authenticateAPIKeyUseCase usecases.AuthenticateAPIKeyUseCase
+}
+
+func NewAuthentication(
+	log *zap.SugaredLogger,
+	tokenService ports.TokenService,
+	authenticateAPIKeyUseCase usecases.AuthenticateAPIKeyUseCase,
+) *authentication {
+	return &authentication{
+		log:                       log,
+		tokenService:              tokenService,
+		authenticateAPIKeyUseCase: authenticateAPIKeyUseCase,
+	}
+}
+
+// Middleware rejects requests without either a valid bearer access token or
+// a valid API key, and puts the authenticated caller into the request context.
+func (m *authentication) Middleware(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(rw http.ResponseWriter, r *http.Request) {
+		var caller domain.Caller
+		var err error
+
+		if token, ok := authorizationCredentials(r, bearerScheme); ok {
+			caller, err = m.tokenService.ParseAccessToken(r.Context(), token)
+		} else if key, ok := authorizationCredentials(r, apiKeyScheme); ok {
+			caller, err = m.authenticateAPIKeyUseCase.Execute(r.Context(), usecases.AuthenticateAPIKeyInput{Key: key})
+		} else {
+			m.unauthorized(rw, r, "missing bearer token or api key")
+			return
+		}
+
+		// only invalid credentials are the caller's fault, failing to check them is ours
+		if errors.Is(err, domain.ErrInvalidAccessToken) || errors.Is(err, domain.ErrInvalidAPIKey) {
+			m.unauthorized(rw, r, err.Error())
+			return
+		}
+
+		if err != nil {
+			handlers.WriteError(logging.FromContext(r.Context(), m.log), rw, r, err)
+			return
+		}
+
+		callerFields := []interface{}{"user_id", caller.UserID, "role", caller.Role}
+		logging.AnnotateAccessLog(r.Context(), callerFields...)
+
+		ctx := logging.With(r.Context(), callerFields...)
+		next.ServeHTTP(rw, r.WithContext(domain.WithCaller(ctx, caller)))
+	})
+}
+
+func (m *authentication) unauthorized(rw http.ResponseWriter, r *http.Request, reason string) {
+	log := logging.FromContext(r.Context(), m.log)
+	log.Infof("authentication - unauthorized request to %s: %s", r.URL.Path, reason)
+
+	rw.Header().Add("WWW-Authenticate", bearerScheme)
+	rw.Header().Add("WWW-Authenticate", apiKeyScheme)
+
+	problem := api.NewProblem(http.StatusUnauthorized, api.ProblemTypeUnauthorized, api.ErrUnauthorized.Error())
+	if err := api.WriteProblem(rw, r, problem); err != nil {
+		log.Errorf("authentication - encode failed: %v", err)
+	}
+}
+
+// authorizationCredentials returns the credentials of the Authorization header
+// when it uses the given scheme, which is matched ignoring case.
+func authorizationCredentials(r *http.Request, scheme string) (string, bool) {
+	headerScheme, credentials, ok := strings.Cut(r.Header.Get("Authorization"), " ")
+	if !ok || !strings.EqualFold(headerScheme, scheme) {
+		return "", false
+	}
+
+	credentials = strings.TrimSpace(credentials)
+
+	return credentials, credentials != ""
+}
@@ -0,0 +1,199 @@
+package middlewares
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"io"
+	"net/http"
+
+	"github.com/lyracampos/go-clean-architecture/internal/domain"
+	"github.com/lyracampos/go-clean-architecture/internal/domain/usecases"
+	"github.com/lyracampos/go-clean-architecture/internal/logging"
+	"github.com/lyracampos/go-clean-architecture/internal/services/api"
+	"go.uber.org/zap"
+)
+
+const (
+	idempotencyKeyHeader = "Idempotency-Key"
+	// idempotentReplayedHeader tells clients the response was stored by an earlier request.
+	idempotentReplayedHeader = "Idempotent-Replayed"
+)
+
+// replayedHeaders are the response headers stored along with the status and body.
+var replayedHeaders = []string{"Content-Type", "Location", "ETag", "Cache-Control"}
+
+type idempotency struct {
+	log                              *zap.SugaredLogger
+	beginIdempotentRequestUseCase    usecases.BeginIdempotentRequestUseCase
+	completeIdempotentRequestUseCase usecases.CompleteIdempotentRequestUseCase
+}
+
+func NewIdempotency(
+	log *zap.SugaredLogger,
+	beginIdempotentRequestUseCase usecases.BeginIdempotentRequestUseCase,
+	completeIdempotentRequestUseCase usecases.CompleteIdempotentRequestUseCase,
+) *idempotency {
+	return &idempotency{
+		log:                              log,
+		beginIdempotentRequestUseCase:    beginIdempotentRequestUseCase,
+		completeIdempotentRequestUseCase: completeIdempotentRequestUseCase,
+	}
+}
+
+// Middleware makes the routes using it safe to retry with an Idempotency-Key header: the response
+// of the first request is stored and returned again to the requests repeating the key.
+// Requests without the header are not affected. It must run after the authentication,
+// as the keys are kept apart per caller.
+func (m *idempotency) Middleware(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(rw http.ResponseWriter, r *http.Request) {
+		key := r.Header.Get(idempotencyKeyHeader)
+		if key == "" {
+			next.ServeHTTP(rw, r)
+			return
+		}
+
+		body, err := io.ReadAll(r.Body)
+		if err != nil {
+			m.writeError(rw, r, err)
+			return
+		}
+
+		r.Body = io.NopCloser(bytes.NewReader(body))
+
+		began, err := m.beginIdempotentRequestUseCase.Execute(r.Context(), usecases.BeginIdempotentRequestInput{
+			Key:         key,
+			Fingerprint: requestFingerprint(r, body),
+		})
+		if err != nil {
+			m.writeError(rw, r, err)
+			return
+		}
+
+		if began.Replayed {
+			logging.FromContext(r.Context(), m.log).Infof("idempotency - replaying response of key %q to %s %s", key, r.Method, r.URL.Path)
+			replay(rw, began)
+			return
+		}
+
+		recorder := &responseRecorder{ResponseWriter: rw}
+		completed := false
+
+		// a panicking handler must not leave the key reserved until it expires,
+		// completing with a server error releases it
+		defer func() {
+			if !completed {
+				m.complete(r, usecases.CompleteIdempotentRequestInput{Key: key, StatusCode: http.StatusInternalServerError, ReservedAt: began.ReservedAt})
+			}
+		}()
+
+		next.ServeHTTP(recorder, r)
+
+		completed = true
+
+		header := map[string][]string{}
+		for _, name := range replayedHeaders {
+			if values := rw.Header().Values(name); len(values) > 0 {
+				header[name] = values
+			}
+		}
+
+		statusCode := recorder.statusCode
+		if statusCode == 0 {
+			statusCode = http.StatusOK
+		}
+
+		m.complete(r, usecases.CompleteIdempotentRequestInput{
+			Key:        key,
+			StatusCode: statusCode,
+			Header:     header,
+			Body:       recorder.body.Bytes(),
+			ReservedAt: began.ReservedAt,
+		})
+	})
+}
+
+// complete stores the response once it was sent, failing to store it is only logged
+// as the client already has the response.
+func (m *idempotency) complete(r *http.Request, input usecases.CompleteIdempotentRequestInput) {
+	// the response is stored even when the client went away meanwhile
+	err := m.completeIdempotentRequestUseCase.Execute(context.WithoutCancel(r.Context()), input)
+	if err != nil {
+		logging.FromContext(r.Context(), m.log).Errorf("idempotency - failed to store response of key %q: %v", input.Key, err)
+	}
+}
+
+func (m *idempotency) writeError(rw http.ResponseWriter, r *http.Request, err error) {
+	log := logging.FromContext(r.Context(), m.log)
+	validationError := &domain.ValidationError{}
+
+	var problem *api.Problem
+
+	switch {
+	case errors.As(err, &validationError):
+		log.Info(err.Error())
+		problem = api.NewProblem(http.StatusBadRequest, api.ProblemTypeValidation, api.ErrInvalidIdempotencyKey.Error())
+	case errors.Is(err, domain.ErrIdempotencyKeyReused):
+		log.Info(err.Error())
+		problem = api.NewProblem(http.StatusUnprocessableEntity, api.ProblemTypeIdempotencyKeyReused, api.ErrIdempotencyKeyReused.Error())
+	case errors.Is(err, domain.ErrIdempotencyKeyInProgress):
+		log.Info(err.Error())
+		problem = api.NewProblem(http.StatusConflict, api.ProblemTypeIdempotencyKeyInProgress, api.ErrIdempotencyKeyInProgress.Error())
+	default:
+		log.Errorf("idempotency - %v", err)
+		problem = api.NewProblem(http.StatusInternalServerError, api.ProblemTypeDefault, api.ErrInternal.Error())
+	}
+
+	if err := api.WriteProblem(rw, r, problem); err != nil {
+		log.Errorf("idempotency - encode failed: %v", err)
+	}
+}
+
+func replay(rw http.ResponseWriter, began usecases.BeginIdempotentRequestOutput) {
+	for name, values := range began.Header {
+		for _, value := range values {
+			rw.Header().Add(name, value)
+		}
+	}
+
+	rw.Header().Set(idempotentReplayedHeader, "true")
+	rw.WriteHeader(began.StatusCode)
+
+	_, _ = rw.Write(began.Body)
+}
+
+// requestFingerprint tells apart requests reusing a key for another operation or payload.
+func requestFingerprint(r *http.Request, body []byte) string {
+	hash := sha256.New()
+	hash.Write([]byte(r.Method + " " + r.URL.Path + "\n"))
+	hash.Write(body)
+
+	return hex.EncodeToString(hash.Sum(nil))
+}
+
+// responseRecorder passes the response through while keeping a copy of it.
+type responseRecorder struct {
+	http.ResponseWriter
+	statusCode int
+	body       bytes.Buffer
+}
+
+func (r *responseRecorder) WriteHeader(statusCode int) {
+	if r.statusCode == 0 {
+		r.statusCode = statusCode
+	}
+
+	r.ResponseWriter.WriteHeader(statusCode)
+}
+
+func (r *responseRecorder) Write(b []byte) (int, error) {
+	if r.statusCode == 0 {
+		r.statusCode = http.StatusOK
+	}
+
+	r.body.Write(b)
+
+	return r.ResponseWriter.Write(b)
+}
@@ -0,0 +1,287 @@
+package middlewares
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/lyracampos/go-clean-architecture/internal/domain"
+	"github.com/lyracampos/go-clean-architecture/internal/domain/usecases"
+	mock "github.com/lyracampos/go-clean-architecture/internal/domain/usecases/mocks"
+	"github.com/lyracampos/go-clean-architecture/internal/services/api"
+	"go.uber.org/mock/gomock"
+	"go.uber.org/zap"
+)
+
+func TestIdempotencyMiddleware(t *testing.T) {
+	reservedAt := time.Date(2024, 2, 1, 0, 0, 0, 0, time.UTC)
+	requestBody := `{"FirstName":"firstName"}`
+	fingerprint := requestFingerprint(httptest.NewRequest(http.MethodPost, "/users", nil), []byte(requestBody))
+	begin := usecases.BeginIdempotentRequestInput{Key: "key", Fingerprint: fingerprint}
+
+	// created answers as the create user handler does
+	created := func(rw http.ResponseWriter, _ *http.Request) {
+		rw.Header().Set("Content-Type", "application/json")
+		rw.Header().Set("Location", "/users/1")
+		rw.Header().Set("ETag", `"1"`)
+		rw.Header().Set("X-Request-Id", "request")
+		rw.WriteHeader(http.StatusCreated)
+		_, _ = rw.Write([]byte(`{"ID":1}`))
+	}
+
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	tests := []struct {
+		name           string
+		idempotencyKey string
+		next           http.HandlerFunc
+		beforeTest     func(beginUseCase *mock.MockBeginIdempotentRequestUseCase, completeUseCase *mock.MockCompleteIdempotentRequestUseCase)
+		wantStatus     int
+		wantHeader     map[string]string
+		wantBody       string
+		wantNextCalled bool
+	}{
+		{
+			name:           "success passing through requests without key",
+			next:           created,
+			beforeTest:     nil,
+			wantStatus:     http.StatusCreated,
+			wantBody:       `{"ID":1}`,
+			wantNextCalled: true,
+		},
+		{
+			name:           "success storing the response of the first request",
+			idempotencyKey: "key",
+			next:           created,
+			beforeTest: func(beginUseCase *mock.MockBeginIdempotentRequestUseCase, completeUseCase *mock.MockCompleteIdempotentRequestUseCase) {
+				beginUseCase.EXPECT().Execute(gomock.Any(), begin).Return(usecases.BeginIdempotentRequestOutput{ReservedAt: reservedAt}, nil)
+				completeUseCase.EXPECT().Execute(gomock.Any(), usecases.CompleteIdempotentRequestInput{
+					Key:        "key",
+					StatusCode: http.StatusCreated,
+					Header: map[string][]string{
+						"Content-Type": {"application/json"},
+						"Location":     {"/users/1"},
+						"ETag":         {`"1"`},
+					},
+					Body:       []byte(`{"ID":1}`),
+					ReservedAt: reservedAt,
+				}).Return(nil)
+			},
+			wantStatus:     http.StatusCreated,
+			wantBody:       `{"ID":1}`,
+			wantNextCalled: true,
+		},
+		{
+			name:           "success storing an implicit 200 response",
+			idempotencyKey: "key",
+			next: func(rw http.ResponseWriter, _ *http.Request) {
+				rw.Header().Set("Content-Type", "text/plain")
+				_, _ = rw.Write([]byte("ok"))
+			},
+			beforeTest: func(beginUseCase *mock.MockBeginIdempotentRequestUseCase, completeUseCase *mock.MockCompleteIdempotentRequestUseCase) {
+				beginUseCase.EXPECT().Execute(gomock.Any(), begin).Return(usecases.BeginIdempotentRequestOutput{ReservedAt: reservedAt}, nil)
+				completeUseCase.EXPECT().Execute(gomock.Any(), usecases.CompleteIdempotentRequestInput{
+					Key:        "key",
+					StatusCode: http.StatusOK,
+					Header:     map[string][]string{"Content-Type": {"text/plain"}},
+					Body:       []byte("ok"),
+					ReservedAt: reservedAt,
+				}).Return(nil)
+			},
+			wantStatus:     http.StatusOK,
+			wantBody:       "ok",
+			wantNextCalled: true,
+		},
+		{
+			name:           "success replaying the response of a completed request",
+			idempotencyKey: "key",
+			next:           created,
+			beforeTest: func(beginUseCase *mock.MockBeginIdempotentRequestUseCase, _ *mock.MockCompleteIdempotentRequestUseCase) {
+				beginUseCase.EXPECT().Execute(gomock.Any(), begin).Return(usecases.BeginIdempotentRequestOutput{
+					Replayed:   true,
+					StatusCode: http.StatusCreated,
+					Header: map[string][]string{
+						"Content-Type": {"application/json"},
+						"Location":     {"/users/1"},
+					},
+					Body: []byte(`{"ID":1}`),
+				}, nil)
+			},
+			wantStatus: http.StatusCreated,
+			wantHeader: map[string]string{
+				"Content-Type":        "application/json",
+				"Location":            "/users/1",
+				"Idempotent-Replayed": "true",
+			},
+			wantBody: `{"ID":1}`,
+		},
+		{
+			name:           "success handing the server errors to be released",
+			idempotencyKey: "key",
+			next: func(rw http.ResponseWriter, _ *http.Request) {
+				rw.WriteHeader(http.StatusServiceUnavailable)
+			},
+			beforeTest: func(beginUseCase *mock.MockBeginIdempotentRequestUseCase, completeUseCase *mock.MockCompleteIdempotentRequestUseCase) {
+				beginUseCase.EXPECT().Execute(gomock.Any(), begin).Return(usecases.BeginIdempotentRequestOutput{ReservedAt: reservedAt}, nil)
+				completeUseCase.EXPECT().Execute(gomock.Any(), usecases.CompleteIdempotentRequestInput{
+					Key:        "key",
+					StatusCode: http.StatusServiceUnavailable,
+					Header:     map[string][]string{},
+					ReservedAt: reservedAt,
+				}).Return(nil)
+			},
+			wantStatus:     http.StatusServiceUnavailable,
+			wantNextCalled: true,
+		},
+		{
+			name:           "success handing the requests the client gave up on to be released",
+			idempotencyKey: "key",
+			next: func(rw http.ResponseWriter, _ *http.Request) {
+				rw.WriteHeader(api.StatusClientClosedRequest)
+			},
+			beforeTest: func(beginUseCase *mock.MockBeginIdempotentRequestUseCase, completeUseCase *mock.MockCompleteIdempotentRequestUseCase) {
+				beginUseCase.EXPECT().Execute(gomock.Any(), begin).Return(usecases.BeginIdempotentRequestOutput{ReservedAt: reservedAt}, nil)
+				completeUseCase.EXPECT().Execute(gomock.Any(), usecases.CompleteIdempotentRequestInput{
+					Key:        "key",
+					StatusCode: api.StatusClientClosedRequest,
+					Header:     map[string][]string{},
+					ReservedAt: reservedAt,
+				}).Return(nil)
+			},
+			wantStatus:     api.StatusClientClosedRequest,
+			wantNextCalled: true,
+		},
+		{
+			name:           "success answering when the response can't be stored",
+			idempotencyKey: "key",
+			next:           created,
+			beforeTest: func(beginUseCase *mock.MockBeginIdempotentRequestUseCase, completeUseCase *mock.MockCompleteIdempotentRequestUseCase) {
+				beginUseCase.EXPECT().Execute(gomock.Any(), begin).Return(usecases.BeginIdempotentRequestOutput{ReservedAt: reservedAt}, nil)
+				completeUseCase.EXPECT().Execute(gomock.Any(), gomock.Any()).Return(domain.ErrIdempotencyKeyDoesNotExist)
+			},
+			wantStatus:     http.StatusCreated,
+			wantBody:       `{"ID":1}`,
+			wantNextCalled: true,
+		},
+		{
+			name:           "fail reusing a key with another request",
+			idempotencyKey: "key",
+			next:           created,
+			beforeTest: func(beginUseCase *mock.MockBeginIdempotentRequestUseCase, _ *mock.MockCompleteIdempotentRequestUseCase) {
+				beginUseCase.EXPECT().Execute(gomock.Any(), begin).Return(usecases.BeginIdempotentRequestOutput{}, domain.ErrIdempotencyKeyReused)
+			},
+			wantStatus: http.StatusUnprocessableEntity,
+			wantHeader: map[string]string{"Content-Type": api.ProblemContentType},
+		},
+		{
+			name:           "fail reusing a key while its request is in progress",
+			idempotencyKey: "key",
+			next:           created,
+			beforeTest: func(beginUseCase *mock.MockBeginIdempotentRequestUseCase, _ *mock.MockCompleteIdempotentRequestUseCase) {
+				beginUseCase.EXPECT().Execute(gomock.Any(), begin).Return(usecases.BeginIdempotentRequestOutput{}, domain.ErrIdempotencyKeyInProgress)
+			},
+			wantStatus: http.StatusConflict,
+			wantHeader: map[string]string{"Content-Type": api.ProblemContentType},
+		},
+		{
+			name:           "fail using an invalid key",
+			idempotencyKey: "key",
+			next:           created,
+			beforeTest: func(beginUseCase *mock.MockBeginIdempotentRequestUseCase, _ *mock.MockCompleteIdempotentRequestUseCase) {
+				beginUseCase.EXPECT().Execute(gomock.Any(), begin).Return(usecases.BeginIdempotentRequestOutput{}, domain.NewValidationError(nil))
+			},
+			wantStatus: http.StatusBadRequest,
+			wantHeader: map[string]string{"Content-Type": api.ProblemContentType},
+		},
+		{
+			name:           "fail reserving a key when database fails",
+			idempotencyKey: "key",
+			next:           created,
+			beforeTest: func(beginUseCase *mock.MockBeginIdempotentRequestUseCase, _ *mock.MockCompleteIdempotentRequestUseCase) {
+				beginUseCase.EXPECT().Execute(gomock.Any(), begin).Return(usecases.BeginIdempotentRequestOutput{}, domain.ErrDeadlock)
+			},
+			wantStatus: http.StatusInternalServerError,
+			wantHeader: map[string]string{"Content-Type": api.ProblemContentType},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockBeginUseCase := mock.NewMockBeginIdempotentRequestUseCase(ctrl)
+			mockCompleteUseCase := mock.NewMockCompleteIdempotentRequestUseCase(ctrl)
+			middleware := NewIdempotency(zap.NewNop().Sugar(), mockBeginUseCase, mockCompleteUseCase)
+
+			if tt.beforeTest != nil {
+				tt.beforeTest(mockBeginUseCase, mockCompleteUseCase)
+			}
+
+			nextCalled := false
+			next := http.HandlerFunc(func(rw http.ResponseWriter, r *http.Request) {
+				nextCalled = true
+				tt.next(rw, r)
+			})
+
+			r := httptest.NewRequest(http.MethodPost, "/users", strings.NewReader(requestBody))
+			if tt.idempotencyKey != "" {
+				r.Header.Set(idempotencyKeyHeader, tt.idempotencyKey)
+			}
+
+			rw := httptest.NewRecorder()
+			middleware.Middleware(next).ServeHTTP(rw, r)
+
+			if rw.Code != tt.wantStatus {
+				t.Errorf("idempotency.Middleware() status = %v, want %v", rw.Code, tt.wantStatus)
+			}
+
+			for name, value := range tt.wantHeader {
+				if got := rw.Header().Get(name); got != value {
+					t.Errorf("idempotency.Middleware() header %s = %v, want %v", name, got, value)
+				}
+			}
+
+			if tt.wantBody != "" && rw.Body.String() != tt.wantBody {
+				t.Errorf("idempotency.Middleware() body = %v, want %v", rw.Body.String(), tt.wantBody)
+			}
+
+			if nextCalled != tt.wantNextCalled {
+				t.Errorf("idempotency.Middleware() called next = %v, want %v", nextCalled, tt.wantNextCalled)
+			}
+		})
+	}
+}
+
+func TestIdempotencyMiddlewarePanic(t *testing.T) {
+	reservedAt := time.Date(2024, 2, 1, 0, 0, 0, 0, time.UTC)
+
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockBeginUseCase := mock.NewMockBeginIdempotentRequestUseCase(ctrl)
+	mockCompleteUseCase := mock.NewMockCompleteIdempotentRequestUseCase(ctrl)
+	middleware := NewIdempotency(zap.NewNop().Sugar(), mockBeginUseCase, mockCompleteUseCase)
+
+	mockBeginUseCase.EXPECT().Execute(gomock.Any(), gomock.Any()).Return(usecases.BeginIdempotentRequestOutput{ReservedAt: reservedAt}, nil)
+	// a panicking handler releases the key as a server error would
+	mockCompleteUseCase.EXPECT().Execute(gomock.Any(), usecases.CompleteIdempotentRequestInput{
+		Key:        "key",
+		StatusCode: http.StatusInternalServerError,
+		ReservedAt: reservedAt,
+	}).Return(nil)
+
+	next := http.HandlerFunc(func(http.ResponseWriter, *http.Request) {
+		panic("handler failed")
+	})
+
+	r := httptest.NewRequest(http.MethodPost, "/users", strings.NewReader("{}"))
+	r.Header.Set(idempotencyKeyHeader, "key")
+
+	defer func() {
+		if recovered := recover(); recovered != "handler failed" {
+			t.Errorf("idempotency.Middleware() recovered = %v, want the handler panic", recovered)
+		}
+	}()
+
+	middleware.Middleware(next).ServeHTTP(httptest.NewRecorder(), r)
+}
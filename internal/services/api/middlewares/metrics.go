@@ -0,0 +1,41 @@
+package middlewares
+
+import (
+	"net/http"
+	"time"
+
+	"github.com/gorilla/mux"
+	"github.com/lyracampos/go-clean-architecture/internal/telemetry"
+)
+
+type metrics struct {
+	metrics *telemetry.Metrics
+}
+
+func NewMetrics(m *telemetry.Metrics) *metrics {
+	return &metrics{
+		metrics: m,
+	}
+}
+
+// Middleware counts and times the requests by their route template and status.
+// It must be used at the router level, as the route is only known after it was matched.
+func (m *metrics) Middleware(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(rw http.ResponseWriter, r *http.Request) {
+		start := time.Now()
+
+		var route string
+		if currentRoute := mux.CurrentRoute(r); currentRoute != nil {
+			route, _ = currentRoute.GetPathTemplate()
+		}
+
+		recorder := &accessRecorder{ResponseWriter: rw}
+		next.ServeHTTP(recorder, r)
+
+		if recorder.statusCode == 0 {
+			recorder.statusCode = http.StatusOK
+		}
+
+		m.metrics.ObserveRequest(route, r.Method, recorder.statusCode, time.Since(start))
+	})
+}
@@ -0,0 +1,102 @@
+package middlewares
+
+import (
+	"net/http"
+	"regexp"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/gorilla/mux"
+	"github.com/lyracampos/go-clean-architecture/internal/logging"
+	"github.com/lyracampos/go-clean-architecture/internal/telemetry"
+	"go.uber.org/zap"
+)
+
+const requestIDHeader = "X-Request-ID"
+
+// validRequestID keeps the IDs informed by clients short and free of characters that could forge log lines.
+var validRequestID = regexp.MustCompile(`^[A-Za-z0-9._:-]{1,128}$`)
+
+type requestLogging struct {
+	log *zap.SugaredLogger
+}
+
+func NewRequestLogging(log *zap.SugaredLogger) *requestLogging {
+	return &requestLogging{
+		log: log,
+	}
+}
+
+// Middleware tags the request with the X-Request-ID informed by the client, or a new one,
+// puts a logger carrying it and the request details into the context, and writes
+// one access log line once the request is answered.
+// It must be used at the router level, as the route is only known after it was matched.
+func (m *requestLogging) Middleware(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(rw http.ResponseWriter, r *http.Request) {
+		start := time.Now()
+
+		requestID := r.Header.Get(requestIDHeader)
+		if !validRequestID.MatchString(requestID) {
+			requestID = uuid.NewString()
+		}
+
+		rw.Header().Set(requestIDHeader, requestID)
+
+		var route string
+		if currentRoute := mux.CurrentRoute(r); currentRoute != nil {
+			route, _ = currentRoute.GetPathTemplate()
+		}
+
+		log := m.log.With(
+			"request_id", requestID,
+			"method", r.Method,
+			"route", route,
+			"remote_addr", r.RemoteAddr,
+		)
+
+		if traceID := telemetry.TraceID(r.Context()); traceID != "" {
+			log = log.With("trace_id", traceID)
+		}
+
+		ctx := logging.WithAccessLog(logging.WithLogger(r.Context(), log))
+
+		recorder := &accessRecorder{ResponseWriter: rw}
+		next.ServeHTTP(recorder, r.WithContext(ctx))
+
+		if recorder.statusCode == 0 {
+			recorder.statusCode = http.StatusOK
+		}
+
+		logging.FromContext(ctx, m.log).With(logging.AccessLogFields(ctx)...).Infow("request completed",
+			"status", recorder.statusCode,
+			"bytes", recorder.bytes,
+			"latency", time.Since(start),
+		)
+	})
+}
+
+// accessRecorder passes the response through while counting what was written.
+type accessRecorder struct {
+	http.ResponseWriter
+	statusCode int
+	bytes      int
+}
+
+func (r *accessRecorder) WriteHeader(statusCode int) {
+	if r.statusCode == 0 {
+		r.statusCode = statusCode
+	}
+
+	r.ResponseWriter.WriteHeader(statusCode)
+}
+
+func (r *accessRecorder) Write(b []byte) (int, error) {
+	if r.statusCode == 0 {
+		r.statusCode = http.StatusOK
+	}
+
+	n, err := r.ResponseWriter.Write(b)
+	r.bytes += n
+
+	return n, err
+}
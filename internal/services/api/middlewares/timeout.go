@@ -0,0 +1,67 @@
+package middlewares
+
+import (
+	"context"
+	"net/http"
+	"strings"
+	"sync/atomic"
+	"time"
+
+	"github.com/gorilla/mux"
+)
+
+type timeout struct {
+	timeouts atomic.Pointer[timeouts]
+}
+
+type timeouts struct {
+	defaultTimeout time.Duration
+	routeTimeouts  map[string]time.Duration
+}
+
+// NewTimeout instantiates the middleware with the default timeout and its overrides,
+// keyed by the lowercased route names.
+func NewTimeout(defaultTimeout time.Duration, routeTimeouts map[string]time.Duration) *timeout {
+	m := &timeout{}
+	m.SetTimeouts(defaultTimeout, routeTimeouts)
+
+	return m
+}
+
+// SetTimeouts replaces the timeouts, the requests already running keep theirs.
+func (m *timeout) SetTimeouts(defaultTimeout time.Duration, routeTimeouts map[string]time.Duration) {
+	m.timeouts.Store(&timeouts{
+		defaultTimeout: defaultTimeout,
+		routeTimeouts:  routeTimeouts,
+	})
+}
+
+// Middleware sets a deadline on the request context, so that the use cases and
+// database queries serving it give up once the route timeout passes.
+// It must be used at the router level, as the route is only known after it was matched.
+func (m *timeout) Middleware(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(rw http.ResponseWriter, r *http.Request) {
+		timeout := m.routeTimeout(r)
+		if timeout <= 0 {
+			next.ServeHTTP(rw, r)
+			return
+		}
+
+		ctx, cancel := context.WithTimeout(r.Context(), timeout)
+		defer cancel()
+
+		next.ServeHTTP(rw, r.WithContext(ctx))
+	})
+}
+
+func (m *timeout) routeTimeout(r *http.Request) time.Duration {
+	timeouts := m.timeouts.Load()
+
+	if route := mux.CurrentRoute(r); route != nil {
+		if timeout, ok := timeouts.routeTimeouts[strings.ToLower(route.GetName())]; ok {
+			return timeout
+		}
+	}
+
+	return timeouts.defaultTimeout
+}
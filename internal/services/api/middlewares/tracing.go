@@ -0,0 +1,58 @@
+package middlewares
+
+import (
+	"net/http"
+
+	"github.com/gorilla/mux"
+	"github.com/lyracampos/go-clean-architecture/internal/telemetry"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/propagation"
+	semconv "go.opentelemetry.io/otel/semconv/v1.24.0"
+	"go.opentelemetry.io/otel/trace"
+)
+
+type tracing struct{}
+
+func NewTracing() *tracing {
+	return &tracing{}
+}
+
+// Middleware opens a server span for every request, continuing the trace of the caller
+// when the request carries a W3C traceparent header.
+// It must be used at the router level before the other middlewares, so that they run within the span.
+func (m *tracing) Middleware(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(rw http.ResponseWriter, r *http.Request) {
+		ctx := otel.GetTextMapPropagator().Extract(r.Context(), propagation.HeaderCarrier(r.Header))
+
+		var route string
+		if currentRoute := mux.CurrentRoute(r); currentRoute != nil {
+			route, _ = currentRoute.GetPathTemplate()
+		}
+
+		ctx, span := telemetry.Tracer().Start(ctx, r.Method+" "+route,
+			trace.WithSpanKind(trace.SpanKindServer),
+			trace.WithAttributes(
+				semconv.HTTPRequestMethodKey.String(r.Method),
+				semconv.HTTPRoute(route),
+				semconv.URLPath(r.URL.Path),
+				semconv.ClientAddress(r.RemoteAddr),
+			),
+		)
+		defer span.End()
+
+		recorder := &accessRecorder{ResponseWriter: rw}
+		next.ServeHTTP(recorder, r.WithContext(ctx))
+
+		if recorder.statusCode == 0 {
+			recorder.statusCode = http.StatusOK
+		}
+
+		span.SetAttributes(semconv.HTTPResponseStatusCode(recorder.statusCode))
+
+		// client errors are not errors of the server span
+		if recorder.statusCode >= http.StatusInternalServerError {
+			span.SetStatus(codes.Error, http.StatusText(recorder.statusCode))
+		}
+	})
+}
@@ -0,0 +1,107 @@
+package api
+
+import (
+	"encoding/json"
+	"net/http"
+
+	"github.com/lyracampos/go-clean-architecture/internal/domain"
+	"github.com/lyracampos/go-clean-architecture/internal/telemetry"
+)
+
+// ProblemContentType is the media type of the error responses, see RFC 7807.
+const ProblemContentType = "application/problem+json"
+
+// StatusClientClosedRequest is answered when the client went away before the response was ready,
+// it is not a standard status, the code is borrowed from nginx.
+const StatusClientClosedRequest = 499
+
+// Problem types identify each kind of error, they are relative URI references.
+const (
+	ProblemTypeDefault                  = "about:blank"
+	ProblemTypeValidation               = "/problems/validation-error"
+	ProblemTypeInvalidRequest           = "/problems/invalid-request"
+	ProblemTypeUserNotFound             = "/problems/user-not-found"
+	ProblemTypeEmailAlreadyInUse        = "/problems/email-already-in-use"
+	ProblemTypeVersionConflict          = "/problems/version-conflict"
+	ProblemTypePreconditionRequired     = "/problems/precondition-required"
+	ProblemTypeUniqueViolation          = "/problems/unique-violation"
+	ProblemTypeForeignKeyViolation      = "/problems/foreign-key-violation"
+	ProblemTypeCheckViolation           = "/problems/check-violation"
+	ProblemTypeNotNullViolation         = "/problems/not-null-violation"
+	ProblemTypeSerializationFailure     = "/problems/serialization-failure"
+	ProblemTypeDeadlock                 = "/problems/deadlock"
+	ProblemTypeInvalidCredentials       = "/problems/invalid-credentials"
+	ProblemTypeInvalidRefreshToken      = "/problems/invalid-refresh-token"
+	ProblemTypeUnauthorized             = "/problems/unauthorized"
+	ProblemTypeForbidden                = "/problems/forbidden"
+	ProblemTypeAPIKeyNotFound           = "/problems/api-key-not-found"
+	ProblemTypeWebhookNotFound          = "/problems/webhook-not-found"
+	ProblemTypeIdempotencyKeyReused     = "/problems/idempotency-key-reused"
+	ProblemTypeIdempotencyKeyInProgress = "/problems/idempotency-key-in-progress"
+	ProblemTypeTimeout                  = "/problems/timeout"
+	ProblemTypeRequestCanceled          = "/problems/request-canceled"
+)
+
+// Problem is the body of every error response, following RFC 7807.
+type Problem struct {
+	Type     string              `json:"type"`
+	Title    string              `json:"title"`
+	Status   int                 `json:"status"`
+	Detail   string              `json:"detail,omitempty"`
+	Instance string              `json:"instance,omitempty"`
+	TraceID  string              `json:"trace_id,omitempty"`
+	Errors   []ProblemFieldError `json:"errors,omitempty"`
+}
+
+// ProblemFieldError describes why a single field failed validation.
+type ProblemFieldError struct {
+	Field   string `json:"field"`
+	Tag     string `json:"tag"`
+	Param   string `json:"param,omitempty"`
+	Message string `json:"message"`
+}
+
+// NewProblem instantiates a problem titled after the HTTP status.
+func NewProblem(status int, problemType string, detail string) *Problem {
+	return &Problem{
+		Type:   problemType,
+		Title:  statusText(status),
+		Status: status,
+		Detail: detail,
+	}
+}
+
+// WithFieldErrors adds one entry per field that failed validation.
+func (p *Problem) WithFieldErrors(fieldErrors []domain.FieldError) *Problem {
+	for _, fieldError := range fieldErrors {
+		fieldError := fieldError
+		p.Errors = append(p.Errors, ProblemFieldError{
+			Field:   fieldError.Field(),
+			Tag:     fieldError.Tag(),
+			Param:   fieldError.Param(),
+			Message: fieldError.Error(),
+		})
+	}
+
+	return p
+}
+
+// WriteProblem answers the request with the problem details.
+func WriteProblem(rw http.ResponseWriter, r *http.Request, problem *Problem) error {
+	problem.Instance = r.URL.RequestURI()
+	problem.TraceID = telemetry.TraceID(r.Context())
+
+	rw.Header().Set("Content-type", ProblemContentType)
+	rw.WriteHeader(problem.Status)
+
+	return json.NewEncoder(rw).Encode(problem)
+}
+
+// statusText is http.StatusText knowing the non-standard statuses too.
+func statusText(status int) string {
+	if status == StatusClientClosedRequest {
+		return "Client Closed Request"
+	}
+
+	return http.StatusText(status)
+}
@@ -0,0 +1,50 @@
+package worker
+
+import (
+	"context"
+	"time"
+
+	"github.com/lyracampos/go-clean-architecture/config"
+	"github.com/lyracampos/go-clean-architecture/internal/domain/usecases"
+	"go.uber.org/zap"
+)
+
+type dispatcher struct {
+	log                    *zap.SugaredLogger
+	deliverWebhooksUseCase usecases.DeliverWebhooksUseCase
+	pollInterval           time.Duration
+	input                  usecases.DeliverWebhooksInput
+}
+
+func NewDispatcher(log *zap.SugaredLogger, deliverWebhooksUseCase usecases.DeliverWebhooksUseCase, pollInterval time.Duration, config config.Webhooks) *dispatcher {
+	return &dispatcher{
+		log:                    log,
+		deliverWebhooksUseCase: deliverWebhooksUseCase,
+		pollInterval:           pollInterval,
+		input: usecases.DeliverWebhooksInput{
+			BatchSize: config.BatchSize,
+			Lease:     config.Lease,
+		},
+	}
+}
+
+// Run posts the pending webhook deliveries until the context is canceled.
+func (d *dispatcher) Run(ctx context.Context) {
+	d.log.Infof("delivering webhooks every %s in batches of %d", d.pollInterval, d.input.BatchSize)
+
+	poll(ctx, d.pollInterval, d.input.BatchSize, func(ctx context.Context) (int, error) {
+		output, err := d.deliverWebhooksUseCase.Execute(ctx, d.input)
+		if err != nil {
+			d.log.Errorf("failed to deliver webhooks: %v", err)
+		}
+
+		if output.Claimed > 0 {
+			d.log.Infof("posted %d webhook deliveries: %d delivered, %d retried, %d failed",
+				output.Claimed, output.Delivered, output.Retried, output.Failed)
+		}
+
+		return output.Claimed, err
+	})
+
+	d.log.Info("webhook dispatcher stopped")
+}
@@ -0,0 +1,30 @@
+package worker
+
+import (
+	"context"
+	"time"
+)
+
+// poll calls batch until the context is canceled, right away after a full batch
+// as more work may be waiting, or after pollInterval otherwise. The batch in flight
+// is not interrupted, so that its work is recorded instead of waiting for its lease.
+func poll(ctx context.Context, pollInterval time.Duration, batchSize int, batch func(ctx context.Context) (int, error)) {
+	timer := time.NewTimer(0)
+	defer timer.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-timer.C:
+		}
+
+		claimed, err := batch(context.WithoutCancel(ctx))
+
+		if err == nil && claimed == batchSize {
+			timer.Reset(0)
+		} else {
+			timer.Reset(pollInterval)
+		}
+	}
+}
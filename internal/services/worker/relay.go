@@ -0,0 +1,50 @@
+package worker
+
+import (
+	"context"
+	"time"
+
+	"github.com/lyracampos/go-clean-architecture/config"
+	"github.com/lyracampos/go-clean-architecture/internal/domain/usecases"
+	"go.uber.org/zap"
+)
+
+type relay struct {
+	log                *zap.SugaredLogger
+	relayEventsUseCase usecases.RelayEventsUseCase
+	pollInterval       time.Duration
+	input              usecases.RelayEventsInput
+}
+
+func NewRelay(log *zap.SugaredLogger, relayEventsUseCase usecases.RelayEventsUseCase, config config.Worker) *relay {
+	return &relay{
+		log:                log,
+		relayEventsUseCase: relayEventsUseCase,
+		pollInterval:       config.PollInterval,
+		input: usecases.RelayEventsInput{
+			BatchSize: config.BatchSize,
+			Lease:     config.Lease,
+		},
+	}
+}
+
+// Run relays the outbox events until the context is canceled.
+func (r *relay) Run(ctx context.Context) {
+	r.log.Infof("relaying outbox events every %s in batches of %d", r.pollInterval, r.input.BatchSize)
+
+	poll(ctx, r.pollInterval, r.input.BatchSize, func(ctx context.Context) (int, error) {
+		output, err := r.relayEventsUseCase.Execute(ctx, r.input)
+		if err != nil {
+			r.log.Errorf("failed to relay outbox events: %v", err)
+		}
+
+		if output.Claimed > 0 {
+			r.log.Infof("relayed %d outbox events: %d delivered, %d retried, %d dead lettered",
+				output.Claimed, output.Delivered, output.Retried, output.DeadLettered)
+		}
+
+		return output.Claimed, err
+	})
+
+	r.log.Info("outbox relay stopped")
+}
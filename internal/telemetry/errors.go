@@ -0,0 +1,85 @@
+package telemetry
+
+import (
+	"errors"
+
+	"github.com/lyracampos/go-clean-architecture/internal/domain"
+)
+
+// Error classes reported by the use case metrics.
+const (
+	ErrorClassNone         = "ok"
+	ErrorClassValidation   = "validation"
+	ErrorClassNotFound     = "not_found"
+	ErrorClassConflict     = "conflict"
+	ErrorClassUnauthorized = "unauthorized"
+	ErrorClassTimeout      = "timeout"
+	ErrorClassCanceled     = "canceled"
+	ErrorClassInternal     = "internal"
+)
+
+var errorClasses = []struct {
+	class  string
+	errors []error
+}{
+	{ErrorClassNotFound, []error{
+		domain.ErrUserDoesNotExist,
+		domain.ErrCredentialsDoNotExist,
+		domain.ErrRefreshTokenDoesNotExist,
+		domain.ErrAPIKeyDoesNotExist,
+		domain.ErrEventDoesNotExist,
+		domain.ErrWebhookDoesNotExist,
+		domain.ErrWebhookDeliveryDoesNotExist,
+		domain.ErrIdempotencyKeyDoesNotExist,
+	}},
+	{ErrorClassConflict, []error{
+		domain.ErrEmailAlreadyInUse,
+		domain.ErrVersionConflict,
+		domain.ErrRefreshTokenAlreadyUsed,
+		domain.ErrIdempotencyKeyReused,
+		domain.ErrIdempotencyKeyInProgress,
+		domain.ErrUniqueViolation,
+		domain.ErrSerializationFailure,
+		domain.ErrDeadlock,
+	}},
+	{ErrorClassValidation, []error{
+		domain.ErrInvalidCursor,
+		domain.ErrForeignKeyViolation,
+		domain.ErrCheckViolation,
+		domain.ErrNotNullViolation,
+	}},
+	{ErrorClassUnauthorized, []error{
+		domain.ErrUnauthenticated,
+		domain.ErrForbidden,
+		domain.ErrInvalidCredentials,
+		domain.ErrInvalidAccessToken,
+		domain.ErrInvalidRefreshToken,
+		domain.ErrRefreshTokenReused,
+		domain.ErrInvalidAPIKey,
+	}},
+	{ErrorClassTimeout, []error{domain.ErrDeadlineExceeded}},
+	{ErrorClassCanceled, []error{domain.ErrCanceled}},
+}
+
+// ErrorClass groups the errors returned by the use cases into a few classes,
+// keeping the cardinality of the metrics labels low.
+func ErrorClass(err error) string {
+	if err == nil {
+		return ErrorClassNone
+	}
+
+	validationError := &domain.ValidationError{}
+	if errors.As(err, &validationError) {
+		return ErrorClassValidation
+	}
+
+	for _, errorClass := range errorClasses {
+		for _, target := range errorClass.errors {
+			if errors.Is(err, target) {
+				return errorClass.class
+			}
+		}
+	}
+
+	return ErrorClassInternal
+}
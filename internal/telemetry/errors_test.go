@@ -0,0 +1,66 @@
+package telemetry
+
+import (
+	"errors"
+	"fmt"
+	"testing"
+
+	"github.com/lyracampos/go-clean-architecture/internal/domain"
+)
+
+func TestErrorClass(t *testing.T) {
+	tests := []struct {
+		name string
+		err  error
+		want string
+	}{
+		{
+			name: "no error",
+			err:  nil,
+			want: ErrorClassNone,
+		},
+		{
+			name: "validation error",
+			err:  fmt.Errorf("input is invalid: %w", domain.NewValidationError(nil)),
+			want: ErrorClassValidation,
+		},
+		{
+			name: "wrapped not found error",
+			err:  fmt.Errorf("failed to get user from database: %w", domain.ErrUserDoesNotExist),
+			want: ErrorClassNotFound,
+		},
+		{
+			name: "constraint error",
+			err:  domain.NewConstraintError(domain.ErrEmailAlreadyInUse, "users_email_key", "", errors.New("duplicate key")),
+			want: ErrorClassConflict,
+		},
+		{
+			name: "permission error",
+			err:  domain.ErrForbidden,
+			want: ErrorClassUnauthorized,
+		},
+		{
+			name: "deadline exceeded",
+			err:  domain.NewTransientError(domain.ErrDeadlineExceeded, errors.New("i/o timeout")),
+			want: ErrorClassTimeout,
+		},
+		{
+			name: "canceled",
+			err:  domain.NewTransientError(domain.ErrCanceled, errors.New("context canceled")),
+			want: ErrorClassCanceled,
+		},
+		{
+			name: "unknown error",
+			err:  errors.New("connection refused"),
+			want: ErrorClassInternal,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := ErrorClass(tt.err); got != tt.want {
+				t.Errorf("ErrorClass() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
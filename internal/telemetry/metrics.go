@@ -0,0 +1,78 @@
+package telemetry
+
+import (
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/collectors"
+	"github.com/prometheus/client_golang/prometheus/promhttp"
+)
+
+// Metrics holds the Prometheus collectors shared by the services of the application.
+type Metrics struct {
+	Registry *prometheus.Registry
+
+	httpRequests        *prometheus.CounterVec
+	httpRequestDuration *prometheus.HistogramVec
+	useCaseExecutions   *prometheus.CounterVec
+	useCaseDuration     *prometheus.HistogramVec
+}
+
+// NewMetrics instantiates the collectors and registers them, along with the Go runtime and process ones.
+func NewMetrics() *Metrics {
+	registry := prometheus.NewRegistry()
+
+	m := &Metrics{
+		Registry: registry,
+		httpRequests: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Name: "http_requests_total",
+			Help: "Number of HTTP requests answered, by route template, method and status.",
+		}, []string{"route", "method", "status"}),
+		httpRequestDuration: prometheus.NewHistogramVec(prometheus.HistogramOpts{
+			Name:    "http_request_duration_seconds",
+			Help:    "Time taken to answer the HTTP requests, by route template, method and status.",
+			Buckets: prometheus.DefBuckets,
+		}, []string{"route", "method", "status"}),
+		useCaseExecutions: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Name: "usecase_executions_total",
+			Help: "Number of use case executions, by use case and result, which is either ok or the class of the error.",
+		}, []string{"usecase", "result"}),
+		useCaseDuration: prometheus.NewHistogramVec(prometheus.HistogramOpts{
+			Name:    "usecase_duration_seconds",
+			Help:    "Time taken by the use case executions, by use case.",
+			Buckets: prometheus.DefBuckets,
+		}, []string{"usecase"}),
+	}
+
+	registry.MustRegister(
+		collectors.NewGoCollector(),
+		collectors.NewProcessCollector(collectors.ProcessCollectorOpts{}),
+		m.httpRequests,
+		m.httpRequestDuration,
+		m.useCaseExecutions,
+		m.useCaseDuration,
+	)
+
+	return m
+}
+
+// Handler serves the metrics in the Prometheus text format.
+func (m *Metrics) Handler() http.Handler {
+	return promhttp.HandlerFor(m.Registry, promhttp.HandlerOpts{Registry: m.Registry})
+}
+
+// ObserveRequest records an answered HTTP request.
+func (m *Metrics) ObserveRequest(route, method string, status int, duration time.Duration) {
+	statusLabel := strconv.Itoa(status)
+
+	m.httpRequests.WithLabelValues(route, method, statusLabel).Inc()
+	m.httpRequestDuration.WithLabelValues(route, method, statusLabel).Observe(duration.Seconds())
+}
+
+// ObserveUseCase records a use case execution and the class of its error, if any.
+func (m *Metrics) ObserveUseCase(useCase string, err error, duration time.Duration) {
+	m.useCaseExecutions.WithLabelValues(useCase, ErrorClass(err)).Inc()
+	m.useCaseDuration.WithLabelValues(useCase).Observe(duration.Seconds())
+}
@@ -0,0 +1,97 @@
+package telemetry
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/lyracampos/go-clean-architecture/config"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/exporters/otlp/otlptrace/otlptracehttp"
+	"go.opentelemetry.io/otel/exporters/stdout/stdouttrace"
+	"go.opentelemetry.io/otel/propagation"
+	"go.opentelemetry.io/otel/sdk/resource"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	semconv "go.opentelemetry.io/otel/semconv/v1.24.0"
+	"go.opentelemetry.io/otel/trace"
+)
+
+// Exporters the spans can be sent to.
+const (
+	TracingExporterNone     = "none"
+	TracingExporterStdout   = "stdout"
+	TracingExporterOTLPHTTP = "otlp-http"
+)
+
+const instrumentationName = "github.com/lyracampos/go-clean-architecture"
+
+// tracer follows the provider installed by SetupTracing, spans started before it are dropped.
+var tracer = otel.Tracer(instrumentationName)
+
+// SetupTracing installs the global tracer provider exporting the spans as configured,
+// and the W3C trace context propagator. The returned function flushes the spans
+// still buffered and must be called on shutdown.
+func SetupTracing(ctx context.Context, serviceName string, tracing config.Tracing) (func(context.Context) error, error) {
+	// the trace context is propagated even when the spans are not exported,
+	// so that the logs can still be tied to the traces of the callers
+	otel.SetTextMapPropagator(propagation.NewCompositeTextMapPropagator(propagation.TraceContext{}, propagation.Baggage{}))
+
+	var exporter sdktrace.SpanExporter
+	var err error
+
+	switch tracing.Exporter {
+	case "", TracingExporterNone:
+		return func(context.Context) error { return nil }, nil
+	case TracingExporterStdout:
+		exporter, err = stdouttrace.New()
+	case TracingExporterOTLPHTTP:
+		options := []otlptracehttp.Option{}
+		if tracing.Endpoint != "" {
+			options = append(options, otlptracehttp.WithEndpoint(tracing.Endpoint))
+		}
+
+		if tracing.Insecure {
+			options = append(options, otlptracehttp.WithInsecure())
+		}
+
+		exporter, err = otlptracehttp.New(ctx, options...)
+	default:
+		return nil, fmt.Errorf("unknown tracing exporter %q, must be one of [%s, %s, %s]",
+			tracing.Exporter, TracingExporterNone, TracingExporterStdout, TracingExporterOTLPHTTP)
+	}
+
+	if err != nil {
+		return nil, fmt.Errorf("failed to create %s span exporter: %w", tracing.Exporter, err)
+	}
+
+	serviceResource, err := resource.Merge(resource.Default(),
+		resource.NewWithAttributes(semconv.SchemaURL, semconv.ServiceName(serviceName)))
+	if err != nil {
+		return nil, fmt.Errorf("failed to create tracing resource: %w", err)
+	}
+
+	provider := sdktrace.NewTracerProvider(
+		sdktrace.WithBatcher(exporter),
+		sdktrace.WithResource(serviceResource),
+		// the callers decide for the traces they started, the ratio only applies to new traces
+		sdktrace.WithSampler(sdktrace.ParentBased(sdktrace.TraceIDRatioBased(tracing.SampleRatio))),
+	)
+
+	otel.SetTracerProvider(provider)
+
+	return provider.Shutdown, nil
+}
+
+// Tracer returns the tracer of the application spans.
+func Tracer() trace.Tracer {
+	return tracer
+}
+
+// TraceID returns the ID of the trace the context belongs to, or an empty string when there is none.
+func TraceID(ctx context.Context) string {
+	spanContext := trace.SpanContextFromContext(ctx)
+	if !spanContext.HasTraceID() {
+		return ""
+	}
+
+	return spanContext.TraceID().String()
+}
@@ -0,0 +1,120 @@
+package telemetry
+
+import (
+	"context"
+	"time"
+
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+)
+
+// The shapes of the use cases Execute methods, the use case interfaces match one of them.
+type (
+	useCase[I, O any] interface {
+		Execute(ctx context.Context, input I) (O, error)
+	}
+
+	useCaseWithoutInput[O any] interface {
+		Execute(ctx context.Context) (O, error)
+	}
+
+	useCaseWithoutOutput[I any] interface {
+		Execute(ctx context.Context, input I) error
+	}
+)
+
+type instrumentedUseCase[I, O any] struct {
+	metrics *Metrics
+	name    string
+	next    useCase[I, O]
+}
+
+// InstrumentUseCase decorates the use case so that its executions are traced and measured under the name.
+func InstrumentUseCase[I, O any](metrics *Metrics, name string, next useCase[I, O]) *instrumentedUseCase[I, O] {
+	return &instrumentedUseCase[I, O]{
+		metrics: metrics,
+		name:    name,
+		next:    next,
+	}
+}
+
+func (u *instrumentedUseCase[I, O]) Execute(ctx context.Context, input I) (O, error) {
+	var output O
+	err := observeUseCase(ctx, u.metrics, u.name, func(ctx context.Context) error {
+		var err error
+		output, err = u.next.Execute(ctx, input)
+
+		return err
+	})
+
+	return output, err
+}
+
+type instrumentedUseCaseWithoutInput[O any] struct {
+	metrics *Metrics
+	name    string
+	next    useCaseWithoutInput[O]
+}
+
+// InstrumentUseCaseWithoutInput is InstrumentUseCase for the use cases taking no input.
+func InstrumentUseCaseWithoutInput[O any](metrics *Metrics, name string, next useCaseWithoutInput[O]) *instrumentedUseCaseWithoutInput[O] {
+	return &instrumentedUseCaseWithoutInput[O]{
+		metrics: metrics,
+		name:    name,
+		next:    next,
+	}
+}
+
+func (u *instrumentedUseCaseWithoutInput[O]) Execute(ctx context.Context) (O, error) {
+	var output O
+	err := observeUseCase(ctx, u.metrics, u.name, func(ctx context.Context) error {
+		var err error
+		output, err = u.next.Execute(ctx)
+
+		return err
+	})
+
+	return output, err
+}
+
+type instrumentedUseCaseWithoutOutput[I any] struct {
+	metrics *Metrics
+	name    string
+	next    useCaseWithoutOutput[I]
+}
+
+// InstrumentUseCaseWithoutOutput is InstrumentUseCase for the use cases returning only an error.
+func InstrumentUseCaseWithoutOutput[I any](metrics *Metrics, name string, next useCaseWithoutOutput[I]) *instrumentedUseCaseWithoutOutput[I] {
+	return &instrumentedUseCaseWithoutOutput[I]{
+		metrics: metrics,
+		name:    name,
+		next:    next,
+	}
+}
+
+func (u *instrumentedUseCaseWithoutOutput[I]) Execute(ctx context.Context, input I) error {
+	return observeUseCase(ctx, u.metrics, u.name, func(ctx context.Context) error {
+		return u.next.Execute(ctx, input)
+	})
+}
+
+// observeUseCase runs the execution within a span and records its duration and result.
+func observeUseCase(ctx context.Context, metrics *Metrics, name string, execute func(ctx context.Context) error) error {
+	ctx, span := tracer.Start(ctx, "usecase "+name)
+	defer span.End()
+
+	start := time.Now()
+	err := execute(ctx)
+	metrics.ObserveUseCase(name, err, time.Since(start))
+
+	errorClass := ErrorClass(err)
+	span.SetAttributes(attribute.String("usecase.result", errorClass))
+
+	// errors caused by the caller, such as validation ones, are expected and don't flag the span
+	if errorClass == ErrorClassInternal || errorClass == ErrorClassTimeout {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+
+	return err
+}